@@ -0,0 +1,72 @@
+// Command import-claimreviews reads schema.org ClaimReview JSON-LD files,
+// such as those published by fact-checkers or exported as data feeds, and
+// merges them into the claim review database for CLAIM_REVIEW_DB_PATH.
+//
+// Usage:
+//
+//	import-claimreviews -db claimreviews.db feed.jsonld [more.jsonld ...]
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/reality-filter/internal/adapters/secondary/claimreview"
+)
+
+func main() {
+	dbPath := flag.String("db", "claimreviews.db", "path of the database to create or update")
+	flag.Parse()
+	if flag.NArg() == 0 {
+		fatal("no ClaimReview files given")
+	}
+
+	db, err := claimreview.LoadDatabase(*dbPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		db, err = claimreview.NewDatabase(), nil
+	}
+	if err != nil {
+		fatal("%v", err)
+	}
+
+	total := 0
+	for _, path := range flag.Args() {
+		reviews, err := readFile(path)
+		if err != nil {
+			fatal("%v", err)
+		}
+		added, err := db.Add(reviews...)
+		if err != nil {
+			fatal("failed to import %s: %v", path, err)
+		}
+		total += added
+		fmt.Printf("%s: %d reviews, %d new\n", path, len(reviews), added)
+	}
+
+	if err := db.Save(*dbPath); err != nil {
+		fatal("%v", err)
+	}
+	fmt.Printf("added %d reviews, wrote %s with %d\n", total, *dbPath, db.Len())
+}
+
+func readFile(path string) ([]claimreview.Review, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	reviews, err := claimreview.ReadJSONLD(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return reviews, nil
+}
+
+func fatal(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "import-claimreviews: "+format+"\n", args...)
+	os.Exit(1)
+}
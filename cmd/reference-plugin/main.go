@@ -0,0 +1,192 @@
+// Command reference-plugin is a minimal analyzer plugin written against the
+// plugin protocol. It serves as a template for plugins in other languages and
+// as a fixture for exercising the host: -delay slows every call down,
+// -crash-after makes it exit abruptly and -protocol-version makes it claim
+// another protocol version in the handshake.
+//
+// Copy the built binary into PLUGIN_DIR to load it.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/reality-filter/pkg/plugin"
+)
+
+const version = "1.0.0"
+
+var (
+	positiveWords = map[string]bool{
+		"good": true, "great": true, "excellent": true, "success": true, "win": true,
+		"improve": true, "improved": true, "benefit": true, "praised": true, "welcome": true,
+	}
+	negativeWords = map[string]bool{
+		"bad": true, "terrible": true, "failure": true, "crisis": true, "loss": true,
+		"worse": true, "harm": true, "condemned": true, "scandal": true, "disaster": true,
+	}
+
+	wordPattern         = regexp.MustCompile(`[\p{L}']+`)
+	organizationPattern = regexp.MustCompile(`\b(?:[A-Z][\w&]*\s+)+(?:Inc|Ltd|LLC|Corp|plc)\b\.?`)
+	hedgePattern        = regexp.MustCompile(`(?i)\b(?:sources say|reportedly|allegedly|rumou?red|it is said)\b`)
+
+	// knownFalse are statements the plugin treats as debunked
+	knownFalse = []string{
+		"the earth is flat",
+		"vaccines cause autism",
+		"the moon landing was faked",
+	}
+)
+
+func main() {
+	name := flag.String("name", "reference", "name announced in the handshake")
+	delay := flag.Duration("delay", 0, "time to wait before answering each call")
+	crashAfter := flag.Int("crash-after", 0, "exit without answering after this many calls (0 never)")
+	protocolVersion := flag.Int("protocol-version", plugin.ProtocolVersion, "protocol version to claim in the handshake")
+	flag.Parse()
+
+	if *protocolVersion != plugin.ProtocolVersion {
+		if err := announceVersion(*name, *protocolVersion); err != nil {
+			fmt.Fprintln(os.Stderr, "reference-plugin:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	calls := 0
+	wrap := func() {
+		calls++
+		if *crashAfter > 0 && calls > *crashAfter {
+			fmt.Fprintln(os.Stderr, "reference-plugin: crashing on purpose")
+			os.Exit(2)
+		}
+		time.Sleep(*delay)
+	}
+
+	handlers := plugin.Handlers{
+		AnalyzeSentiment: func(ctx context.Context, params plugin.TextParams) (plugin.SentimentResult, error) {
+			wrap()
+			return plugin.SentimentResult{Score: sentiment(params)}, nil
+		},
+		ExtractEntities: func(ctx context.Context, params plugin.TextParams) (plugin.EntitiesResult, error) {
+			wrap()
+			return plugin.EntitiesResult{Entities: organizations(params.Text)}, nil
+		},
+		DetectBias: func(ctx context.Context, params plugin.TextParams) (plugin.FlagsResult, error) {
+			wrap()
+			return plugin.FlagsResult{Flags: hedging(params.Text)}, nil
+		},
+		CheckFacts: func(ctx context.Context, params plugin.ArticleParams) (plugin.FlagsResult, error) {
+			wrap()
+			return plugin.FlagsResult{Flags: debunked(params.Article.Content)}, nil
+		},
+	}
+
+	if err := plugin.Serve(context.Background(), *name, version, handlers, os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "reference-plugin:", err)
+		os.Exit(1)
+	}
+}
+
+// announceVersion answers the handshake with another protocol version and
+// exits, as a plugin built against that version would
+func announceVersion(name string, protocolVersion int) error {
+	var request plugin.Request
+	if err := json.NewDecoder(os.Stdin).Decode(&request); err != nil {
+		return fmt.Errorf("failed to read handshake: %w", err)
+	}
+	result, err := json.Marshal(plugin.HandshakeResult{
+		ProtocolVersion: protocolVersion,
+		Name:            name,
+		Version:         version,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode handshake: %w", err)
+	}
+	return json.NewEncoder(os.Stdout).Encode(plugin.Response{ID: request.ID, Result: result})
+}
+
+// sentiment scores English text by counting words from two small lists
+func sentiment(params plugin.TextParams) float64 {
+	if params.Language != "" && params.Language != "en" {
+		return 0.5
+	}
+
+	var positive, negative int
+	for _, word := range wordPattern.FindAllString(strings.ToLower(params.Text), -1) {
+		if positiveWords[word] {
+			positive++
+		}
+		if negativeWords[word] {
+			negative++
+		}
+	}
+	if positive+negative == 0 {
+		return 0.5
+	}
+	return 0.5 + 0.5*float64(positive-negative)/float64(positive+negative)
+}
+
+// organizations finds company names ending in a legal suffix
+func organizations(text string) []plugin.Entity {
+	var entities []plugin.Entity
+	index := make(map[string]int)
+	for _, loc := range organizationPattern.FindAllStringIndex(text, -1) {
+		value := strings.TrimSuffix(text[loc[0]:loc[1]], ".")
+		span := plugin.Span{
+			Start: utf8.RuneCountInString(text[:loc[0]]),
+			End:   utf8.RuneCountInString(text[:loc[0]]) + utf8.RuneCountInString(value),
+		}
+
+		i, seen := index[value]
+		if !seen {
+			i = len(entities)
+			index[value] = i
+			entities = append(entities, plugin.Entity{Type: "ORGANIZATION", Value: value})
+		}
+		entities[i].Count++
+		entities[i].Mentions = append(entities[i].Mentions, span)
+	}
+	return entities
+}
+
+// hedging flags text that leans on unattributed hedges
+func hedging(text string) []plugin.Flag {
+	hedges := hedgePattern.FindAllString(text, -1)
+	if len(hedges) < 2 {
+		return nil
+	}
+
+	confidence := 1.0
+	for range hedges {
+		confidence *= 0.5
+	}
+	return []plugin.Flag{{
+		Type:       "UNVERIFIED",
+		Confidence: 1 - confidence,
+		Details:    fmt.Sprintf("%d unattributed hedges such as %q", len(hedges), hedges[0]),
+	}}
+}
+
+// debunked flags articles repeating a known falsehood
+func debunked(content string) []plugin.Flag {
+	lower := strings.ToLower(content)
+	var flags []plugin.Flag
+	for _, statement := range knownFalse {
+		if strings.Contains(lower, statement) {
+			flags = append(flags, plugin.Flag{
+				Type:       "FACTUAL_ERROR",
+				Confidence: 0.9,
+				Details:    fmt.Sprintf("repeats the debunked claim %q", statement),
+			})
+		}
+	}
+	return flags
+}
@@ -19,12 +19,33 @@ import (
 	"github.com/go-redis/redis/v8"
 	"github.com/reality-filter/docs"
 	"github.com/reality-filter/internal/adapters/primary/http/handler"
+	"github.com/reality-filter/internal/adapters/secondary/bias"
+	"github.com/reality-filter/internal/adapters/secondary/claimreview"
+	"github.com/reality-filter/internal/adapters/secondary/claims"
+	"github.com/reality-filter/internal/adapters/secondary/classifier"
+	"github.com/reality-filter/internal/adapters/secondary/clickbait"
+	"github.com/reality-filter/internal/adapters/secondary/composite"
+	"github.com/reality-filter/internal/adapters/secondary/hatespeech"
+	"github.com/reality-filter/internal/adapters/secondary/headline"
+	"github.com/reality-filter/internal/adapters/secondary/keyphrase"
+	"github.com/reality-filter/internal/adapters/secondary/knowledgebase"
+	"github.com/reality-filter/internal/adapters/secondary/langid"
 	"github.com/reality-filter/internal/adapters/secondary/mongodb"
+	"github.com/reality-filter/internal/adapters/secondary/ner"
+	"github.com/reality-filter/internal/adapters/secondary/numeric"
+	"github.com/reality-filter/internal/adapters/secondary/plugins"
+	"github.com/reality-filter/internal/adapters/secondary/quotes"
 	redisadapter "github.com/reality-filter/internal/adapters/secondary/redis"
+	"github.com/reality-filter/internal/adapters/secondary/sentiment"
+	"github.com/reality-filter/internal/adapters/secondary/sources"
+	"github.com/reality-filter/internal/adapters/secondary/spam"
+	"github.com/reality-filter/internal/adapters/secondary/synthetic"
+	"github.com/reality-filter/internal/adapters/secondary/temporal"
 	"github.com/reality-filter/internal/application"
 	"github.com/reality-filter/internal/core/domain"
 	"github.com/reality-filter/pkg/config"
 	"github.com/reality-filter/pkg/logger"
+	"github.com/reality-filter/pkg/plugin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -79,13 +100,168 @@ func main() {
 
 	repository := mongodb.NewArticleRepository(mongoClient, cfg.MongoDB.Database)
 	cache := redisadapter.NewArticleCache(redisClient)
+	documentFrequencies := mongodb.NewDocumentFrequencyStore(mongoClient, cfg.MongoDB.Database)
+	sourceRepository := mongodb.NewSourceRepository(mongoClient, cfg.MongoDB.Database)
+	reputationHistory := mongodb.NewReputationHistory(mongoClient, cfg.MongoDB.Database)
+	reviewOutcomes := mongodb.NewReviewOutcomeStore(mongoClient, cfg.MongoDB.Database)
 
-	// TODO: Implement these interfaces
-	var (
-		factChecker     = &mockFactChecker{}     // Replace with actual implementation
-		contentAnalyzer = &mockContentAnalyzer{} // Replace with actual implementation
-		eventPublisher  = &mockEventPublisher{}  // Replace with actual implementation
-	)
+	analysisConfig := cfg.GetAnalysisConfig()
+
+	sentimentAnalyzer, err := sentiment.NewAnalyzer(analysisConfig.GetSentimentLexiconPath())
+	if err != nil {
+		logger.Fatal("Failed to load sentiment lexicon", zap.Error(err))
+	}
+
+	entityExtractor, err := ner.NewExtractor(analysisConfig.GetEntityGazetteerPath())
+	if err != nil {
+		logger.Fatal("Failed to load entity gazetteer", zap.Error(err))
+	}
+
+	biasDetector, err := bias.NewDetector(bias.DefaultThreshold, analysisConfig.GetBiasLexiconPath())
+	if err != nil {
+		logger.Fatal("Failed to load bias lexicon", zap.Error(err))
+	}
+
+	hateSpeechDetector, err := hatespeech.NewDetector(hatespeech.DefaultThreshold, analysisConfig.GetHateSpeechTermsPath())
+	if err != nil {
+		logger.Fatal("Failed to load hate-speech terms", zap.Error(err))
+	}
+
+	languageDetector, err := langid.NewDetector(analysisConfig.GetLanguageProfileDir())
+	if err != nil {
+		logger.Fatal("Failed to load language profiles", zap.Error(err))
+	}
+
+	children := []composite.Child{
+		{Name: "sentiment", Analyzer: sentimentAnalyzer, SentimentWeight: 1},
+		{Name: "entities", Analyzer: entityExtractor},
+		{Name: bias.DetectorName, Analyzer: biasDetector},
+		{Name: hatespeech.DetectorName, Analyzer: hateSpeechDetector},
+		{Name: spam.DetectorName, Analyzer: spam.NewDetector(spam.DefaultThreshold)},
+	}
+
+	syntheticDetector := synthetic.NewDetector(synthetic.DefaultThreshold, nil)
+	if modelPath := analysisConfig.GetCharacterModelPath(); modelPath != "" {
+		syntheticDetector, err = synthetic.NewDetectorFromFile(synthetic.DefaultThreshold, modelPath)
+		if err != nil {
+			logger.Fatal("Failed to load character language model", zap.Error(err))
+		}
+	}
+	children = append(children, composite.Child{Name: synthetic.DetectorName, Analyzer: syntheticDetector})
+
+	if modelPath := analysisConfig.GetClassifierModelPath(); modelPath != "" {
+		textClassifier, err := classifier.NewAnalyzerFromFile(modelPath)
+		if err != nil {
+			logger.Fatal("Failed to load classifier model", zap.Error(err))
+		}
+		children = append(children, composite.Child{Name: classifier.DetectorName, Analyzer: textClassifier})
+	}
+
+	kb := knowledgebase.New()
+	if kbPath := analysisConfig.GetKnowledgeBasePath(); kbPath != "" {
+		kb, err = knowledgebase.Load(kbPath)
+		if err != nil {
+			logger.Fatal("Failed to load knowledge base", zap.Error(err))
+		}
+		logger.Info("Loaded knowledge base", zap.String("path", kbPath), zap.Int("facts", kb.Len()))
+	}
+	factCheckers := []composite.FactCheckerChild{
+		{Name: sources.CheckerName, Checker: sources.NewChecker(sourceRepository), ReputationWeight: 1},
+		{Name: knowledgebase.CheckerName, Checker: knowledgebase.NewChecker(kb, knowledgebase.DefaultTolerance), ReputationWeight: 1},
+	}
+
+	if dbPath := analysisConfig.GetClaimReviewDBPath(); dbPath != "" {
+		claimReviews, err := claimreview.LoadDatabase(dbPath)
+		if err != nil {
+			logger.Fatal("Failed to load claim review database", zap.Error(err))
+		}
+		logger.Info("Loaded claim review database", zap.String("path", dbPath), zap.Int("reviews", claimReviews.Len()))
+		factCheckers = append(factCheckers, composite.FactCheckerChild{
+			Name:    claimreview.CheckerName,
+			Checker: claimreview.NewChecker(claimReviews, claimreview.DefaultThreshold),
+		})
+	}
+
+	// TODO: Implement this interface
+	eventPublisher := &mockEventPublisher{} // Replace with actual implementation
+
+	if pluginDir := analysisConfig.GetPluginDir(); pluginDir != "" {
+		pluginConfig := plugins.DefaultConfig()
+		pluginConfig.CallTimeout = analysisConfig.GetPluginCallTimeout()
+
+		loaded, err := plugins.LoadDir(context.Background(), pluginDir, pluginConfig)
+		if err != nil {
+			logger.Fatal("Failed to load plugins", zap.Error(err))
+		}
+
+		for _, p := range loaded {
+			defer p.Close()
+
+			info := p.Info()
+			logger.Info("Loaded plugin",
+				zap.String("name", info.Name),
+				zap.String("version", info.Version),
+				zap.Strings("capabilities", info.Capabilities),
+			)
+
+			if p.Supports(plugin.MethodAnalyzeSentiment) || p.Supports(plugin.MethodExtractEntities) ||
+				p.Supports(plugin.MethodDetectBias) {
+				child := composite.Child{Name: "plugin:" + info.Name, Analyzer: p}
+				if p.Supports(plugin.MethodAnalyzeSentiment) {
+					child.SentimentWeight = 1
+				}
+				children = append(children, child)
+			}
+
+			if p.Supports(plugin.MethodCheckFacts) || p.Supports(plugin.MethodSourceReputation) {
+				child := composite.FactCheckerChild{Name: "plugin:" + info.Name, Checker: p}
+				if p.Supports(plugin.MethodSourceReputation) {
+					child.ReputationWeight = 1
+				}
+				factCheckers = append(factCheckers, child)
+			}
+		}
+	}
+
+	contentAnalyzer, err := composite.NewAnalyzer(children, composite.WithObserver(func(call composite.Call) {
+		if call.Err != nil {
+			logger.Warn("Content analyzer failed",
+				zap.String("analyzer", call.Child),
+				zap.String("method", call.Method),
+				zap.Duration("duration", call.Duration),
+				zap.Error(call.Err),
+			)
+			return
+		}
+		logger.Debug("Content analyzer finished",
+			zap.String("analyzer", call.Child),
+			zap.String("method", call.Method),
+			zap.Duration("duration", call.Duration),
+		)
+	}))
+	if err != nil {
+		logger.Fatal("Failed to build content analyzer", zap.Error(err))
+	}
+
+	factChecker, err := composite.NewFactChecker(factCheckers, composite.WithObserver(func(call composite.Call) {
+		if call.Err != nil {
+			logger.Warn("Fact checker failed",
+				zap.String("checker", call.Child),
+				zap.String("method", call.Method),
+				zap.Duration("duration", call.Duration),
+				zap.Error(call.Err),
+			)
+			return
+		}
+		logger.Debug("Fact checker finished",
+			zap.String("checker", call.Child),
+			zap.String("method", call.Method),
+			zap.Duration("duration", call.Duration),
+		)
+	}))
+	if err != nil {
+		logger.Fatal("Failed to build fact checker", zap.Error(err))
+	}
 
 	analyzer := application.NewArticleAnalyzerService(
 		repository,
@@ -93,9 +269,21 @@ func main() {
 		factChecker,
 		contentAnalyzer,
 		eventPublisher,
+		application.WithLanguageDetector(languageDetector),
+		application.WithTitleAnalyzer(clickbait.NewDetector(clickbait.DefaultThreshold)),
+		application.WithClaimExtractor(claims.NewExtractor(claims.DefaultThreshold)),
+		application.WithQuoteExtractor(quotes.NewExtractor()),
+		application.WithSourceRepository(sourceRepository),
+		application.WithReputationHistory(reputationHistory, reviewOutcomes),
+		application.WithKeyphraseExtractor(keyphrase.NewExtractor(documentFrequencies, keyphrase.DefaultMaxTopics)),
+		application.WithConsistencyCheckers(
+			headline.NewChecker(entityExtractor, headline.DefaultThreshold),
+			numeric.NewChecker(),
+			temporal.NewChecker(),
+		),
 	)
 
-	handler := handler.NewHandler(analyzer, analyzer) // Using analyzer as both ArticleAnalyzer and ArticleManager
+	handler := handler.NewHandler(analyzer, analyzer, analyzer, analyzer, analyzer) // The service implements ArticleAnalyzer, ArticleManager, AuthorProfiler, AnalyticsProvider and SourceRegistry
 
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New() // Use New() instead of Default() to avoid using the default logger
@@ -165,31 +353,7 @@ func main() {
 	logger.Info("Server exited successfully")
 }
 
-// Mock implementations for remaining interfaces
-type mockFactChecker struct{}
-
-func (m *mockFactChecker) CheckFacts(ctx context.Context, article *domain.Article) ([]domain.Flag, error) {
-	return nil, nil
-}
-
-func (m *mockFactChecker) GetSourceReputation(ctx context.Context, source string) (float64, error) {
-	return 0.8, nil
-}
-
-type mockContentAnalyzer struct{}
-
-func (m *mockContentAnalyzer) AnalyzeSentiment(ctx context.Context, text string) (float64, error) {
-	return 0.5, nil
-}
-
-func (m *mockContentAnalyzer) ExtractEntities(ctx context.Context, text string) ([]domain.Entity, error) {
-	return nil, nil
-}
-
-func (m *mockContentAnalyzer) DetectBias(ctx context.Context, text string) ([]domain.Flag, error) {
-	return nil, nil
-}
-
+// Mock implementation for the remaining interface
 type mockEventPublisher struct{}
 
 func (m *mockEventPublisher) PublishArticleAnalyzed(ctx context.Context, article *domain.Article) error {
@@ -0,0 +1,211 @@
+// Command train fits the text classifier on articles reviewers have marked
+// VERIFIED or REJECTED and writes a model file for CLASSIFIER_MODEL_PATH.
+// Rejected articles are labelled with the flag types the review confirms,
+// never with the classifier's own flags.
+// With -charlm-out it also fits the character language model of the
+// machine-generated text detector on the VERIFIED articles, for
+// CHARACTER_MODEL_PATH.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/reality-filter/internal/adapters/secondary/claimreview"
+	"github.com/reality-filter/internal/adapters/secondary/classifier"
+	"github.com/reality-filter/internal/adapters/secondary/knowledgebase"
+	"github.com/reality-filter/internal/adapters/secondary/mongodb"
+	"github.com/reality-filter/internal/adapters/secondary/synthetic"
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/internal/core/ports/secondary"
+	"github.com/reality-filter/pkg/config"
+	"github.com/reality-filter/pkg/sanitize"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const pageSize = 500
+
+func main() {
+	defaults := classifier.DefaultTrainerConfig()
+
+	out := flag.String("out", "classifier.model", "path of the model file to write")
+	epochs := flag.Int("epochs", defaults.Epochs, "number of passes over the training data")
+	minPositives := flag.Int("min-positives", defaults.MinPositives, "minimum rejected articles per flag type")
+	threshold := flag.Float64("threshold", defaults.Threshold, "calibrated confidence at which flags are emitted")
+	holdOut := flag.Float64("holdout", defaults.HoldOut, "fraction of articles reserved for calibration")
+	charModelOut := flag.String("charlm-out", "", "path of the character language model to write (skipped if empty)")
+	charModelOrder := flag.Int("charlm-order", synthetic.DefaultCharModelConfig().Order, "longest character n-gram of the language model")
+	flag.Parse()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		fatal("failed to load configuration: %v", err)
+	}
+
+	ctx := context.Background()
+	mongoClient, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.GetMongoDBConfig().GetURI()))
+	if err != nil {
+		fatal("failed to connect to MongoDB: %v", err)
+	}
+	defer mongoClient.Disconnect(ctx)
+
+	repository := mongodb.NewArticleRepository(mongoClient, cfg.MongoDB.Database)
+
+	verified, err := loadArticles(ctx, repository, domain.ArticleStatusVerified)
+	if err != nil {
+		fatal("%v", err)
+	}
+	rejected, err := loadArticles(ctx, repository, domain.ArticleStatusRejected)
+	if err != nil {
+		fatal("%v", err)
+	}
+	examples := trainingExamples(verified, rejected)
+
+	trainerConfig := defaults
+	trainerConfig.Epochs = *epochs
+	trainerConfig.MinPositives = *minPositives
+	trainerConfig.Threshold = *threshold
+	trainerConfig.HoldOut = *holdOut
+
+	model, err := classifier.Train(examples, trainerConfig)
+	if err != nil {
+		fatal("failed to train model: %v", err)
+	}
+	if err := model.Save(*out); err != nil {
+		fatal("%v", err)
+	}
+
+	fmt.Printf("trained on %d articles, wrote %s\n", len(examples), *out)
+	for _, label := range model.Labels {
+		fmt.Printf("  %-14s positives=%d negatives=%d holdout accuracy=%.3f log loss=%.3f\n",
+			label.FlagType, label.Metrics.Positives, label.Metrics.Negatives,
+			label.Metrics.Accuracy, label.Metrics.LogLoss)
+	}
+
+	if *charModelOut != "" {
+		trainCharModel(verified, *charModelOut, *charModelOrder)
+	}
+}
+
+// trainCharModel fits the character language model on the analyzed text
+// of the verified articles, so that its baseline describes the same clean
+// text the detector scores
+func trainCharModel(verified []*domain.Article, out string, order int) {
+	documents := make([]string, len(verified))
+	for i, article := range verified {
+		documents[i] = analyzedText(article)
+	}
+
+	charConfig := synthetic.DefaultCharModelConfig()
+	charConfig.Order = order
+	model, err := synthetic.TrainCharModel(documents, charConfig)
+	if err != nil {
+		fatal("failed to train language model: %v", err)
+	}
+	if err := model.Save(out); err != nil {
+		fatal("%v", err)
+	}
+
+	fmt.Printf("trained language model on %d verified articles, wrote %s\n", len(documents), out)
+	fmt.Printf("  %d n-grams, baseline %.2f±%.2f bits/char over %d held-out articles\n",
+		len(model.Ngrams), model.Baseline.MeanEntropy, model.Baseline.StdEntropy, model.Baseline.Documents)
+}
+
+// loadArticles reads every article with the given status
+func loadArticles(ctx context.Context, repository secondary.ArticleRepository, status domain.ArticleStatus) ([]*domain.Article, error) {
+	var all []*domain.Article
+	for offset := 0; ; offset += pageSize {
+		articles, err := repository.FindByStatus(ctx, status, pageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s articles: %w", status, err)
+		}
+		all = append(all, articles...)
+		if len(articles) < pageSize {
+			return all, nil
+		}
+	}
+}
+
+// trainingExamples turns reviewed articles into training examples; verified
+// articles are negatives. A rejected article is a positive example for the
+// flag types the reviewer's decision confirms: FACTUAL_ERROR when a reviewer
+// rated one of its claims FALSE, and the types of the flags other detectors
+// raised, which the reviewer saw when rejecting it. Flags the classifier
+// raised itself are left out, so the model is not trained on its own output.
+// Examples are the analyzed text, which is what the classifier sees when it
+// runs.
+func trainingExamples(verified, rejected []*domain.Article) []classifier.Example {
+	var examples []classifier.Example
+	for _, article := range verified {
+		examples = append(examples, classifier.Example{Text: analyzedText(article)})
+	}
+	for _, article := range rejected {
+		example := classifier.Example{Text: analyzedText(article), FlagTypes: reviewedFlagTypes(article)}
+		if len(example.FlagTypes) > 0 {
+			examples = append(examples, example)
+		}
+	}
+	return examples
+}
+
+// automaticCheckers are the fact checkers that record claim verdicts during
+// analysis; any other CheckedBy is a reviewer
+var automaticCheckers = map[string]bool{
+	claimreview.CheckerName:   true,
+	knowledgebase.CheckerName: true,
+}
+
+// reviewedFlagTypes returns the flag types a rejected article is labelled
+// with, each once
+func reviewedFlagTypes(article *domain.Article) []domain.FlagType {
+	var types []domain.FlagType
+	seen := make(map[domain.FlagType]bool)
+	add := func(t domain.FlagType) {
+		if !seen[t] {
+			seen[t] = true
+			types = append(types, t)
+		}
+	}
+
+	for _, claim := range article.Claims {
+		if claim.Verdict == domain.ClaimVerdictFalse && claim.CheckedBy != "" && !automaticCheckers[claim.CheckedBy] {
+			add(domain.FlagTypeFactualError)
+		}
+	}
+	for _, f := range article.Flags {
+		if !raisedByClassifier(f) {
+			add(f.Type)
+		}
+	}
+	return types
+}
+
+// raisedByClassifier reports whether the classifier contributed to a flag.
+// Flags merged from several detectors list them all, comma-separated.
+func raisedByClassifier(f domain.Flag) bool {
+	for _, detector := range strings.Split(f.DetectedBy, ",") {
+		if strings.TrimSpace(detector) == classifier.DetectorName {
+			return true
+		}
+	}
+	return false
+}
+
+// analyzedText returns the text the analyzers ran on. Articles analyzed
+// before content normalization existed have no clean text; their content is
+// cleaned the same way analysis would clean it.
+func analyzedText(article *domain.Article) string {
+	if article.CleanText != "" {
+		return article.CleanText
+	}
+	return sanitize.Sanitize(article.Content).Text
+}
+
+func fatal(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "train: "+format+"\n", args...)
+	os.Exit(1)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -11,15 +12,22 @@ import (
 
 // Handler handles HTTP requests for the article analysis API
 type Handler struct {
-	analyzer primary.ArticleAnalyzer
-	manager  primary.ArticleManager
+	analyzer  primary.ArticleAnalyzer
+	manager   primary.ArticleManager
+	authors   primary.AuthorProfiler
+	analytics primary.AnalyticsProvider
+	sources   primary.SourceRegistry
 }
 
 // NewHandler creates a new HTTP handler
-func NewHandler(analyzer primary.ArticleAnalyzer, manager primary.ArticleManager) *Handler {
+func NewHandler(analyzer primary.ArticleAnalyzer, manager primary.ArticleManager, authors primary.AuthorProfiler,
+	analytics primary.AnalyticsProvider, sources primary.SourceRegistry) *Handler {
 	return &Handler{
-		analyzer: analyzer,
-		manager:  manager,
+		analyzer:  analyzer,
+		manager:   manager,
+		authors:   authors,
+		analytics: analytics,
+		sources:   sources,
 	}
 }
 
@@ -32,7 +40,20 @@ func (h *Handler) RegisterRoutes(r *gin.Engine) {
 		api.POST("/articles/:id/analyze", h.AnalyzeArticle)
 		api.GET("/articles/:id/analysis", h.GetAnalysisResult)
 		api.POST("/articles/:id/reprocess", h.ReprocessArticle)
+		api.GET("/articles/:id/claims", h.ListClaims)
+		api.PUT("/articles/:id/claims/:claimId", h.UpdateClaimVerdict)
+		api.PUT("/articles/:id/status", h.UpdateArticleStatus)
 		api.GET("/articles/flagged", h.ListFlaggedArticles)
+		api.GET("/authors/:author/profile", h.GetAuthorProfile)
+		api.GET("/topics/trending", h.GetTrendingTopics)
+		api.POST("/sources", h.CreateSource)
+		api.GET("/sources", h.ListSources)
+		api.GET("/sources/:domain", h.GetSource)
+		api.PUT("/sources/:domain", h.UpdateSource)
+		api.DELETE("/sources/:domain", h.DeleteSource)
+		api.GET("/sources/:domain/reputation", h.ExplainReputation)
+		api.PUT("/sources/:domain/reputation/override", h.OverrideReputation)
+		api.DELETE("/sources/:domain/reputation/override", h.ClearReputationOverride)
 	}
 }
 
@@ -160,6 +181,7 @@ func (h *Handler) GetAnalysisResult(c *gin.Context) {
 		"flags":     article.Flags,
 		"status":    article.Status,
 		"metadata":  article.MetaData,
+		"claims":    article.Claims,
 	})
 }
 
@@ -184,6 +206,126 @@ func (h *Handler) ReprocessArticle(c *gin.Context) {
 	c.Status(http.StatusAccepted)
 }
 
+// ListClaims godoc
+// @Summary List article claims
+// @Description Retrieve the check-worthy claims extracted from an article
+// @Tags Claims
+// @Accept json
+// @Produce json
+// @Param id path string true "Article ID"
+// @Success 200 {object} map[string]interface{} "Claims with their verdicts"
+// @Failure 404 {object} map[string]string "Article not found"
+// @Router /articles/{id}/claims [get]
+func (h *Handler) ListClaims(c *gin.Context) {
+	articleID := c.Param("id")
+
+	article, err := h.manager.GetArticle(c.Request.Context(), articleID)
+	if err != nil || article == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Article not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"articleId": article.ID,
+		"claims":    article.Claims,
+	})
+}
+
+// UpdateClaimVerdict godoc
+// @Summary Review a claim
+// @Description Record a reviewer's verdict on a claim extracted from an article
+// @Tags Claims
+// @Accept json
+// @Produce json
+// @Param id path string true "Article ID"
+// @Param claimId path string true "Claim ID"
+// @Success 204 "Verdict recorded"
+// @Failure 400 {object} map[string]string "Invalid request payload"
+// @Failure 404 {object} map[string]string "Article or claim not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /articles/{id}/claims/{claimId} [put]
+func (h *Handler) UpdateClaimVerdict(c *gin.Context) {
+	var request struct {
+		Verdict  domain.ClaimVerdict `json:"verdict" binding:"required"`
+		Details  string              `json:"details"`
+		Reviewer string              `json:"reviewer" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !request.Verdict.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid verdict"})
+		return
+	}
+
+	err := h.manager.UpdateClaimVerdict(c.Request.Context(), c.Param("id"), c.Param("claimId"),
+		request.Verdict, request.Details, request.Reviewer)
+	if err != nil {
+		c.JSON(claimErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// claimErrorStatus maps claim review errors to HTTP status codes
+func claimErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidClaimReview):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrArticleNotFound), errors.Is(err, domain.ErrClaimNotFound):
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+// UpdateArticleStatus godoc
+// @Summary Review an article
+// @Description Set an article's status. Verifying or rejecting an article updates the reputation of its source; moving it away from VERIFIED or REJECTED withdraws that update.
+// @Tags Articles
+// @Accept json
+// @Produce json
+// @Param id path string true "Article ID"
+// @Param status body object true "New status"
+// @Success 204 "Status updated"
+// @Failure 400 {object} map[string]string "Invalid request payload"
+// @Failure 404 {object} map[string]string "Article not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /articles/{id}/status [put]
+func (h *Handler) UpdateArticleStatus(c *gin.Context) {
+	var request struct {
+		Status domain.ArticleStatus `json:"status" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !request.Status.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status"})
+		return
+	}
+
+	article, err := h.manager.GetArticle(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if article == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Article not found"})
+		return
+	}
+
+	if err := h.manager.UpdateArticleStatus(c.Request.Context(), c.Param("id"), request.Status); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 // ListFlaggedArticles godoc
 // @Summary List flagged articles
 // @Description Retrieve a list of articles that have been flagged during analysis
@@ -226,3 +368,377 @@ func (h *Handler) ListFlaggedArticles(c *gin.Context) {
 		"offset":   offset,
 	})
 }
+
+// GetAuthorProfile godoc
+// @Summary Get an author's style profile
+// @Description Retrieve the stylometric profile built from an author's analyzed articles
+// @Tags Authors
+// @Accept json
+// @Produce json
+// @Param author path string true "Author name"
+// @Success 200 {object} domain.AuthorProfile
+// @Failure 404 {object} map[string]string "Author profile not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /authors/{author}/profile [get]
+func (h *Handler) GetAuthorProfile(c *gin.Context) {
+	profile, err := h.authors.GetAuthorProfile(c.Request.Context(), c.Param("author"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if profile == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Author profile not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// GetTrendingTopics godoc
+// @Summary List trending topics
+// @Description Retrieve the topics whose share of the last day's articles has risen most against the week before
+// @Tags Analytics
+// @Accept json
+// @Produce json
+// @Param limit query int false "Maximum number of topics to return (default: 10)"
+// @Success 200 {object} map[string]interface{} "Trending topics, most trending first"
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /topics/trending [get]
+func (h *Handler) GetTrendingTopics(c *gin.Context) {
+	limit := 10 // Default limit
+
+	if limitParam := c.Query("limit"); limitParam != "" {
+		if _, err := fmt.Sscanf(limitParam, "%d", &limit); err != nil || limit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
+			return
+		}
+	}
+
+	topics, err := h.analytics.GetTrendingTopics(c.Request.Context(), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"topics": topics,
+		"limit":  limit,
+	})
+}
+
+// sourceRequest is the body of the source create and update requests
+type sourceRequest struct {
+	Domain     string   `json:"domain"`
+	Name       string   `json:"name" binding:"required"`
+	Country    string   `json:"country"`
+	Ownership  string   `json:"ownership"`
+	Reputation *float64 `json:"reputation"`
+	Provenance struct {
+		Method     domain.ReputationMethod `json:"method"`
+		AssessedBy string                  `json:"assessedBy"`
+		Reference  string                  `json:"reference"`
+	} `json:"provenance"`
+}
+
+// apply copies the request onto the source. A request without a reputation
+// leaves the source's assessment as it is; one without a method records a
+// manual assessment.
+func (r *sourceRequest) apply(source *domain.Source) {
+	source.Name = r.Name
+	source.Country = r.Country
+	source.Ownership = r.Ownership
+	if r.Reputation == nil {
+		return
+	}
+	method := r.Provenance.Method
+	if method == "" {
+		method = domain.ReputationMethodManual
+	}
+	source.Assess(*r.Reputation, domain.ReputationProvenance{
+		Method:     method,
+		AssessedBy: r.Provenance.AssessedBy,
+		Reference:  r.Provenance.Reference,
+	})
+}
+
+// sourceErrorStatus maps source registry errors to HTTP status codes
+func sourceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidSource):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrSourceNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrSourceExists), errors.Is(err, domain.ErrSourceConflict):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// CreateSource godoc
+// @Summary Register a news source
+// @Description Add a source to the registry. Sources without a reputation start at 0.5.
+// @Tags Sources
+// @Accept json
+// @Produce json
+// @Param source body sourceRequest true "Source to register"
+// @Success 201 {object} domain.Source
+// @Failure 400 {object} map[string]string "Invalid request payload"
+// @Failure 409 {object} map[string]string "Source already exists"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /sources [post]
+func (h *Handler) CreateSource(c *gin.Context) {
+	var request sourceRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	source := domain.NewSource(request.Domain, request.Name)
+	request.apply(source)
+
+	if err := h.sources.CreateSource(c.Request.Context(), source); err != nil {
+		c.JSON(sourceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, source)
+}
+
+// ListSources godoc
+// @Summary List news sources
+// @Description Retrieve the registered sources ordered by domain
+// @Tags Sources
+// @Accept json
+// @Produce json
+// @Param limit query int false "Maximum number of sources to return (default: 50)"
+// @Param offset query int false "Number of sources to skip (default: 0)"
+// @Success 200 {object} map[string]interface{} "List of sources"
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /sources [get]
+func (h *Handler) ListSources(c *gin.Context) {
+	limit := 50 // Default limit
+	offset := 0 // Default offset
+
+	if limitParam := c.Query("limit"); limitParam != "" {
+		if _, err := fmt.Sscanf(limitParam, "%d", &limit); err != nil || limit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
+			return
+		}
+	}
+
+	if offsetParam := c.Query("offset"); offsetParam != "" {
+		if _, err := fmt.Sscanf(offsetParam, "%d", &offset); err != nil || offset < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset parameter"})
+			return
+		}
+	}
+
+	sources, err := h.sources.ListSources(c.Request.Context(), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"sources": sources,
+		"limit":   limit,
+		"offset":  offset,
+	})
+}
+
+// GetSource godoc
+// @Summary Get a news source
+// @Description Retrieve a registered source and the provenance of its reputation
+// @Tags Sources
+// @Accept json
+// @Produce json
+// @Param domain path string true "Source domain"
+// @Success 200 {object} domain.Source
+// @Failure 404 {object} map[string]string "Source not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /sources/{domain} [get]
+func (h *Handler) GetSource(c *gin.Context) {
+	source, err := h.sources.GetSource(c.Request.Context(), c.Param("domain"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if source == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Source not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, source)
+}
+
+// UpdateSource godoc
+// @Summary Update a news source
+// @Description Replace a source's details. A reputation in the request is recorded with its provenance; without one the reputation is kept.
+// @Tags Sources
+// @Accept json
+// @Produce json
+// @Param domain path string true "Source domain"
+// @Param source body sourceRequest true "Source details"
+// @Success 200 {object} domain.Source
+// @Failure 400 {object} map[string]string "Invalid request payload"
+// @Failure 404 {object} map[string]string "Source not found"
+// @Failure 409 {object} map[string]string "Source was modified concurrently"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /sources/{domain} [put]
+func (h *Handler) UpdateSource(c *gin.Context) {
+	var request sourceRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	source, err := h.sources.GetSource(c.Request.Context(), c.Param("domain"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if source == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Source not found"})
+		return
+	}
+	request.apply(source)
+
+	if err := h.sources.UpdateSource(c.Request.Context(), source); err != nil {
+		c.JSON(sourceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, source)
+}
+
+// DeleteSource godoc
+// @Summary Delete a news source
+// @Description Remove a source from the registry
+// @Tags Sources
+// @Accept json
+// @Produce json
+// @Param domain path string true "Source domain"
+// @Success 204 "Source deleted"
+// @Failure 404 {object} map[string]string "Source not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /sources/{domain} [delete]
+func (h *Handler) DeleteSource(c *gin.Context) {
+	if err := h.sources.DeleteSource(c.Request.Context(), c.Param("domain")); err != nil {
+		c.JSON(sourceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ExplainReputation godoc
+// @Summary Explain a source's reputation
+// @Description Show how a source's reputation came about: its assessment, its track record of verified and rejected articles decayed by age, the reputation learned from both, any override, and the history of changes, most recent first.
+// @Tags Sources
+// @Accept json
+// @Produce json
+// @Param domain path string true "Source domain"
+// @Param limit query int false "Maximum number of history entries to return (default: 50)"
+// @Param offset query int false "Number of history entries to skip (default: 0)"
+// @Success 200 {object} domain.ReputationExplanation
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 404 {object} map[string]string "Source not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /sources/{domain}/reputation [get]
+func (h *Handler) ExplainReputation(c *gin.Context) {
+	limit := 50 // Default limit
+	offset := 0 // Default offset
+
+	if limitParam := c.Query("limit"); limitParam != "" {
+		if _, err := fmt.Sscanf(limitParam, "%d", &limit); err != nil || limit < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
+			return
+		}
+	}
+
+	if offsetParam := c.Query("offset"); offsetParam != "" {
+		if _, err := fmt.Sscanf(offsetParam, "%d", &offset); err != nil || offset < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset parameter"})
+			return
+		}
+	}
+
+	explanation, err := h.sources.ExplainReputation(c.Request.Context(), c.Param("domain"), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if explanation == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Source not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, explanation)
+}
+
+// OverrideReputation godoc
+// @Summary Override a source's reputation
+// @Description Pin a source's reputation. The override takes precedence over the learned reputation until it is removed; reviews keep updating the track record meanwhile.
+// @Tags Sources
+// @Accept json
+// @Produce json
+// @Param domain path string true "Source domain"
+// @Param override body object true "Reputation, who sets it and why"
+// @Success 200 {object} domain.Source
+// @Failure 400 {object} map[string]string "Invalid request payload"
+// @Failure 404 {object} map[string]string "Source not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /sources/{domain}/reputation/override [put]
+func (h *Handler) OverrideReputation(c *gin.Context) {
+	var request struct {
+		Reputation *float64 `json:"reputation" binding:"required"`
+		SetBy      string   `json:"setBy" binding:"required"`
+		Reason     string   `json:"reason"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	source, err := h.sources.OverrideReputation(c.Request.Context(), c.Param("domain"), domain.ReputationOverride{
+		Reputation: *request.Reputation,
+		SetBy:      request.SetBy,
+		Reason:     request.Reason,
+	})
+	if err != nil {
+		c.JSON(sourceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, source)
+}
+
+// ClearReputationOverride godoc
+// @Summary Remove a source's reputation override
+// @Description Release a source's reputation back to the value learned from its assessment and track record
+// @Tags Sources
+// @Accept json
+// @Produce json
+// @Param domain path string true "Source domain"
+// @Param by query string true "Who removes the override"
+// @Success 200 {object} domain.Source
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 404 {object} map[string]string "Source not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /sources/{domain}/reputation/override [delete]
+func (h *Handler) ClearReputationOverride(c *gin.Context) {
+	source, err := h.sources.ClearReputationOverride(c.Request.Context(), c.Param("domain"), c.Query("by"))
+	if err != nil {
+		c.JSON(sourceErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	if source == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Source not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, source)
+}
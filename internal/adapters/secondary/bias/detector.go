@@ -0,0 +1,231 @@
+package bias
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/internal/core/ports/secondary"
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+//go:embed lexicons/*.lex
+var lexiconFiles embed.FS
+
+const (
+	// DetectorName identifies flags raised by the bias detector
+	DetectorName = "bias_detector"
+	// DefaultThreshold is the category confidence at which a flag is raised
+	DefaultThreshold = 0.5
+	// DefaultLanguage is used when the context carries no detected language
+	DefaultLanguage = "en"
+
+	// minWords is the text length below which densities are computed as if
+	// the text had this many words, so short texts are not over-penalised
+	minWords = 50
+	// maxExamples caps how many offending phrases are quoted per flag
+	maxExamples = 8
+)
+
+// sensitivity is the weighted hits per 100 words at which a category reaches
+// a confidence of about 0.63. Hedging is common in careful reporting, so it
+// needs a much higher density before it counts as bias.
+var sensitivity = map[Category]float64{
+	CategoryLoadedTerm:            1.5,
+	CategorySubjectiveIntensifier: 2.0,
+	CategoryOneSidedAttribution:   1.2,
+	CategoryHedging:               4.0,
+}
+
+// Detector implements lexicon-based detection of loaded and biased language
+// with one lexicon per language
+type Detector struct {
+	lexicons  map[string]*Lexicon
+	threshold float64
+}
+
+// Ensure Detector implements secondary.ContentAnalyzer
+var _ secondary.ContentAnalyzer = (*Detector)(nil)
+
+// NewDetector creates a bias detector using the built-in lexicons, with the
+// English lexicon extended by the lexicon files at the given paths.
+// Categories are flagged when their confidence reaches threshold.
+func NewDetector(threshold float64, lexiconPaths ...string) (*Detector, error) {
+	d := &Detector{lexicons: make(map[string]*Lexicon), threshold: threshold}
+
+	entries, err := lexiconFiles.ReadDir("lexicons")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read built-in bias lexicons: %w", err)
+	}
+	for _, entry := range entries {
+		data, err := lexiconFiles.ReadFile("lexicons/" + entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read built-in bias lexicon: %w", err)
+		}
+		lexicon := NewLexicon()
+		if err := lexicon.Parse(bytes.NewReader(data)); err != nil {
+			return nil, fmt.Errorf("failed to load built-in bias lexicon %s: %w", entry.Name(), err)
+		}
+		d.AddLexicon(strings.TrimSuffix(entry.Name(), ".lex"), lexicon)
+	}
+
+	for _, path := range lexiconPaths {
+		if path == "" {
+			continue
+		}
+		extra, err := LoadLexiconFile(path)
+		if err != nil {
+			return nil, err
+		}
+		d.AddLexicon(DefaultLanguage, extra)
+	}
+
+	return d, nil
+}
+
+// NewDetectorWithLexicon creates a bias detector using only the given lexicon
+// for English
+func NewDetectorWithLexicon(lexicon *Lexicon, threshold float64) *Detector {
+	return &Detector{lexicons: map[string]*Lexicon{DefaultLanguage: lexicon}, threshold: threshold}
+}
+
+// AddLexicon merges a lexicon into the one used for the given ISO 639-1
+// language
+func (d *Detector) AddLexicon(language string, lexicon *Lexicon) {
+	existing, ok := d.lexicons[language]
+	if !ok {
+		existing = NewLexicon()
+		d.lexicons[language] = existing
+	}
+	existing.Merge(lexicon)
+}
+
+// match is a lexicon phrase found in the text
+type match struct {
+	phrase phrase
+	text   string
+}
+
+// DetectBias returns one BIASED flag per cue category whose density in the
+// text is high enough. Each flag's details quote the offending phrases. The
+// lexicon is chosen by the language in the context; texts in a language
+// without a lexicon are not flagged.
+func (d *Detector) DetectBias(ctx context.Context, text string) ([]domain.Flag, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	language := secondary.LanguageFromContext(ctx)
+	if language == "" {
+		language = DefaultLanguage
+	}
+	lexicon, ok := d.lexicons[language]
+	tokens := tokenize.Words(text)
+	if !ok || len(tokens) == 0 {
+		return nil, nil
+	}
+
+	byCategory := make(map[Category][]match)
+	for _, m := range findPhrases(lexicon, text, tokens) {
+		byCategory[m.phrase.category] = append(byCategory[m.phrase.category], m)
+	}
+
+	words := math.Max(float64(len(tokens)), minWords)
+	var flags []domain.Flag
+	for _, category := range Categories {
+		matches := byCategory[category]
+		if len(matches) < 2 {
+			continue
+		}
+
+		var weighted float64
+		for _, m := range matches {
+			weighted += m.phrase.weight
+		}
+		density := weighted * 100 / words
+		confidence := 1 - math.Exp(-density/sensitivity[category])
+		if confidence < d.threshold {
+			continue
+		}
+
+		flags = append(flags, domain.Flag{
+			Type:       domain.FlagTypeBiased,
+			Confidence: confidence,
+			Details: fmt.Sprintf("%s: %d matches (%.1f per 100 words): %s",
+				strings.ReplaceAll(string(category), "_", " "), len(matches), density, examples(matches)),
+			DetectedBy: DetectorName,
+		})
+	}
+	return flags, nil
+}
+
+// AnalyzeSentiment is not supported by the bias detector
+func (d *Detector) AnalyzeSentiment(ctx context.Context, text string) (float64, error) {
+	return 0.5, nil
+}
+
+// ExtractEntities is not supported by the bias detector
+func (d *Detector) ExtractEntities(ctx context.Context, text string) ([]domain.Entity, error) {
+	return nil, nil
+}
+
+// findPhrases returns the longest lexicon phrase starting at each token,
+// without overlaps
+func findPhrases(lexicon *Lexicon, text string, tokens []tokenize.Token) []match {
+	words := make([]string, len(tokens))
+	for i, t := range tokens {
+		words[i] = tokenize.Normalize(t.Text)
+	}
+
+	var matches []match
+	for i := 0; i < len(words); {
+		matched := 0
+		for n := min(lexicon.maxLen, len(words)-i); n > 0; n-- {
+			if p, ok := lexicon.phrases[strings.Join(words[i:i+n], " ")]; ok {
+				matches = append(matches, match{phrase: p, text: text[tokens[i].Start:tokens[i+n-1].End]})
+				matched = n
+				break
+			}
+		}
+		if matched == 0 {
+			matched = 1
+		}
+		i += matched
+	}
+	return matches
+}
+
+// examples formats the distinct offending phrases, most frequent first
+func examples(matches []match) string {
+	counts := make(map[string]int)
+	for _, m := range matches {
+		counts[strings.ToLower(m.text)]++
+	}
+	phrases := make([]string, 0, len(counts))
+	for p := range counts {
+		phrases = append(phrases, p)
+	}
+	sort.Slice(phrases, func(a, b int) bool {
+		if counts[phrases[a]] != counts[phrases[b]] {
+			return counts[phrases[a]] > counts[phrases[b]]
+		}
+		return phrases[a] < phrases[b]
+	})
+	if len(phrases) > maxExamples {
+		phrases = phrases[:maxExamples]
+	}
+
+	quoted := make([]string, len(phrases))
+	for i, p := range phrases {
+		quoted[i] = fmt.Sprintf("%q", p)
+		if counts[p] > 1 {
+			quoted[i] += fmt.Sprintf(" x%d", counts[p])
+		}
+	}
+	return strings.Join(quoted, ", ")
+}
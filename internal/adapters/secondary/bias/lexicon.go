@@ -0,0 +1,141 @@
+package bias
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+// Category groups related bias cues
+type Category string
+
+const (
+	CategorySubjectiveIntensifier Category = "subjective_intensifiers"
+	CategoryLoadedTerm            Category = "loaded_terms"
+	CategoryOneSidedAttribution   Category = "one_sided_attribution"
+	CategoryHedging               Category = "hedging"
+)
+
+// Categories lists every category in reporting order
+var Categories = []Category{
+	CategoryLoadedTerm,
+	CategorySubjectiveIntensifier,
+	CategoryOneSidedAttribution,
+	CategoryHedging,
+}
+
+// Lexicon maps normalized phrases to their category and weight
+type Lexicon struct {
+	phrases map[string]phrase
+	maxLen  int
+}
+
+// phrase is a single lexicon entry
+type phrase struct {
+	text     string
+	category Category
+	weight   float64
+}
+
+// NewLexicon creates an empty lexicon
+func NewLexicon() *Lexicon {
+	return &Lexicon{phrases: make(map[string]phrase)}
+}
+
+// LoadLexiconFile reads a lexicon from a file on disk
+func LoadLexiconFile(path string) (*Lexicon, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bias lexicon: %w", err)
+	}
+	defer f.Close()
+
+	lexicon := NewLexicon()
+	if err := lexicon.Parse(f); err != nil {
+		return nil, fmt.Errorf("failed to parse bias lexicon %s: %w", path, err)
+	}
+	return lexicon, nil
+}
+
+// Parse reads entries in the sectioned text format, one phrase per line with
+// an optional tab-separated weight, and adds them to the lexicon
+func (l *Lexicon) Parse(r io.Reader) error {
+	var category Category
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			category = Category(strings.ToLower(strings.Trim(line, "[]")))
+			if !validCategory(category) {
+				return fmt.Errorf("line %d: unknown section %q", lineNo, category)
+			}
+			continue
+		}
+		if category == "" {
+			return fmt.Errorf("line %d: entry outside of a section", lineNo)
+		}
+
+		text, weight := line, 1.0
+		if idx := strings.LastIndex(line, "\t"); idx >= 0 {
+			w, err := strconv.ParseFloat(strings.TrimSpace(line[idx+1:]), 64)
+			if err != nil {
+				return fmt.Errorf("line %d: invalid weight: %w", lineNo, err)
+			}
+			text, weight = strings.TrimSpace(line[:idx]), w
+		}
+		l.Add(category, text, weight)
+	}
+	return scanner.Err()
+}
+
+// Add registers a phrase, replacing any existing entry for it
+func (l *Lexicon) Add(category Category, text string, weight float64) {
+	key := phraseKey(text)
+	if key == "" {
+		return
+	}
+	l.phrases[key] = phrase{text: text, category: category, weight: weight}
+	if n := len(strings.Fields(key)); n > l.maxLen {
+		l.maxLen = n
+	}
+}
+
+// Merge copies every entry of other into the lexicon, overriding existing
+// entries
+func (l *Lexicon) Merge(other *Lexicon) {
+	for key, p := range other.phrases {
+		l.phrases[key] = p
+	}
+	if other.maxLen > l.maxLen {
+		l.maxLen = other.maxLen
+	}
+}
+
+// phraseKey normalizes a phrase into space-separated lower-case words
+func phraseKey(text string) string {
+	words := tokenize.Words(text)
+	parts := make([]string, len(words))
+	for i, w := range words {
+		parts[i] = tokenize.Normalize(w.Text)
+	}
+	return strings.Join(parts, " ")
+}
+
+func validCategory(c Category) bool {
+	for _, known := range Categories {
+		if c == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,355 @@
+package claimreview
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/internal/core/ports/secondary"
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+const (
+	// CheckerName identifies flags and verdicts from claim reviews
+	CheckerName = "claim_review"
+	// DefaultThreshold is the match score at which a sentence is taken to
+	// repeat a reviewed claim
+	DefaultThreshold = 0.55
+
+	// Ratings at or below falseBelow are false, ratings below trueFrom are
+	// misleading, and the rest are true
+	falseBelow = 0.25
+	trueFrom   = 0.75
+
+	// reportedClaimWeight scales the confidence of a flag on a sentence that
+	// attributes the claim to others, as in "the claim that ...", rather
+	// than asserting it
+	reportedClaimWeight = 0.5
+)
+
+// Textual ratings by truthiness, 0 for false and 1 for true. Reviewers use
+// their own scales; these are the common ones.
+var ratingNames = map[string]float64{
+	"false": 0, "pants on fire": 0, "fake": 0, "hoax": 0, "incorrect": 0, "wrong": 0,
+	"fabricated": 0, "not true": 0, "baseless": 0, "scam": 0, "four pinocchios": 0,
+	"mostly false": 0.25, "largely false": 0.25, "inaccurate": 0.25, "three pinocchios": 0.25,
+	"half true": 0.5, "half truth": 0.5, "mixture": 0.5, "mixed": 0.5, "partly false": 0.5,
+	"partly true": 0.5, "misleading": 0.5, "needs context": 0.5, "missing context": 0.5,
+	"out of context": 0.5, "exaggerated": 0.5, "exaggeration": 0.5, "two pinocchios": 0.5,
+	"mostly true": 0.75, "mostly correct": 0.75, "largely true": 0.75, "one pinocchio": 0.75,
+	"true": 1, "correct": 1, "accurate": 1, "geppetto checkmark": 1,
+	// German, Spanish and French
+	"falsch": 0, "falso": 0, "faux": 0, "irreführend": 0.5, "engañoso": 0.5, "trompeur": 0.5,
+	"wahr": 1, "verdadero": 1, "vrai": 1,
+}
+
+// Ratings saying the reviewer could not establish the claim either way
+var unverifiableNames = []string{
+	"unproven", "unverified", "unsupported", "no evidence", "unsubstantiated",
+	"research in progress", "not enough evidence",
+}
+
+// Words that negate the rating name they precede, as in "Not correct" or
+// "isn't true", degree words that soften the negation, as in "Not entirely
+// true", which is half true, and words that stress it, as in "not at all
+// true"
+var (
+	negators = map[string]bool{
+		"not": true, "no": true, "never": true, "t": true, // t as in "isn't"
+		"nicht": true, "kein": true, "pas": true,
+	}
+	negationDegrees = map[string]bool{
+		"entirely": true, "completely": true, "fully": true, "totally": true,
+		"wholly": true, "quite": true, "exactly": true,
+		"ganz": true, "völlig": true, "completamente": true, "totalement": true,
+	}
+	negationStresses = map[string]bool{"at": true, "all": true, "du": true, "tout": true, "überhaupt": true}
+	// copulas may stand between a negator and the rating name, as in "no es
+	// verdadero"
+	copulas = map[string]bool{"is": true, "es": true, "ist": true, "est": true}
+)
+
+// Phrases with which a sentence presents a claim as refuted, as in "the claim
+// ... is false" or "there is no evidence that ...", and phrases with which it
+// reports a claim without asserting it
+var (
+	refutationPhrases = []string{
+		"false", "falsely", "untrue", "debunk", "debunked", "debunking", "refute", "refuted",
+		"disproved", "disproven", "no evidence", "baseless", "unfounded", "hoax", "myth",
+		"fact checkers", "fact checked", "conspiracy theory", "conspiracy theories",
+		"misinformation", "disinformation",
+	}
+	reportingPhrases = []string{
+		"claim that", "claims that", "claimed that", "rumor that", "rumors that",
+		"rumour that", "rumours that", "allegation that", "allegations that",
+	}
+)
+
+// ratingPhrases lists the rating names longest first, so that "mostly false"
+// is found before "false"
+var ratingPhrases = func() []string {
+	phrases := make([]string, 0, len(ratingNames))
+	for phrase := range ratingNames {
+		phrases = append(phrases, phrase)
+	}
+	sort.Slice(phrases, func(i, j int) bool {
+		if len(phrases[i]) != len(phrases[j]) {
+			return len(phrases[i]) > len(phrases[j])
+		}
+		return phrases[i] < phrases[j]
+	})
+	return phrases
+}()
+
+// truthiness places a rating between 0 (false) and 1 (true). The rating's
+// name is preferred, since reviewers often leave the numeric scale at its
+// default; it reports false for unverifiable and unknown ratings. A negated
+// name counts as its opposite, so "Not correct" is false.
+func (r Rating) truthiness() (float64, bool) {
+	name := words(r.Name)
+	for _, phrase := range unverifiableNames {
+		if strings.Contains(name, " "+phrase+" ") {
+			return 0, false
+		}
+	}
+	for _, phrase := range ratingPhrases {
+		i := strings.Index(name, " "+phrase+" ")
+		if i < 0 {
+			continue
+		}
+		t := ratingNames[phrase]
+		switch negated, softened := negation(strings.Fields(name[:i])); {
+		case softened:
+			t = 0.5
+		case negated:
+			t = 1 - t
+		}
+		return t, true
+	}
+	if r.Value != nil && r.best() != r.worst() {
+		t := (*r.Value - r.worst()) / (r.best() - r.worst())
+		return min(max(t, 0), 1), true
+	}
+	return 0, false
+}
+
+// negation reports whether the words before a rating name negate it, and
+// whether a degree word softens the negation
+func negation(before []string) (negated, softened bool) {
+	n := len(before)
+	for n > 0 && negationStresses[before[n-1]] {
+		n--
+	}
+	degree := n > 0 && negationDegrees[before[n-1]]
+	if degree {
+		n--
+	}
+	if n > 1 && copulas[before[n-1]] && negators[before[n-2]] {
+		n--
+	}
+	negated = n > 0 && negators[before[n-1]]
+	return negated, negated && degree
+}
+
+func isSeparator(r rune) bool {
+	return !('a' <= r && r <= 'z') && !('0' <= r && r <= '9') && r < 0x80
+}
+
+// Checker is a FactChecker that matches sentences against claims reviewed
+// by fact-checkers. A sentence matches a claim when they share enough rare
+// word bigrams and mention the same names and numbers; sentences matching
+// claims rated false are flagged as FACTUAL_ERROR and those matching
+// half-true or misleading claims as MISLEADING, with the rating, the
+// reviewer and the review's URL in the details. Sentences that negate the
+// claim or report it as debunked are not flagged, and those attributing it
+// to others are flagged with lower confidence.
+//
+// Claims the article already carries get the verdict of the matching
+// review. Reviews in another language than the article are ignored.
+type Checker struct {
+	reviews   []Review
+	index     *index
+	threshold float64
+}
+
+// Ensure Checker implements the FactChecker interface
+var _ secondary.FactChecker = (*Checker)(nil)
+
+// NewChecker creates a checker for the reviews in the database. Reviews
+// added to the database afterwards are not seen.
+func NewChecker(db *Database, threshold float64) *Checker {
+	reviews := append([]Review(nil), db.Reviews...)
+	return &Checker{
+		reviews:   reviews,
+		index:     newIndex(reviews),
+		threshold: threshold,
+	}
+}
+
+// NewCheckerFromFile creates a checker for the database file at path
+func NewCheckerFromFile(path string, threshold float64) (*Checker, error) {
+	db, err := LoadDatabase(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewChecker(db, threshold), nil
+}
+
+// CheckFacts matches the article's claims and sentences with reviewed
+// claims. Claims that were already checked keep their verdict.
+func (c *Checker) CheckFacts(ctx context.Context, article *domain.Article) ([]domain.Flag, error) {
+	language := secondary.LanguageFromContext(ctx)
+
+	var flags []domain.Flag
+	claimed := make(map[string]bool, len(article.Claims))
+	for i := range article.Claims {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		claim := &article.Claims[i]
+		claimed[claim.Text] = true
+
+		review, score, ok := c.match(claim.Text, claim.Entities, language)
+		if !ok || refutes(claim.Text, review.Claim) {
+			continue
+		}
+		truthiness, rated := review.Rating.truthiness()
+		if flag, ok := c.flag(claim.Text, review, truthiness, rated, score); ok {
+			flags = append(flags, flag)
+		}
+		if claim.Verdict != domain.ClaimVerdictUnchecked && claim.Verdict != "" {
+			continue
+		}
+		claim.SetVerdict(verdict(truthiness, rated), describe(review), CheckerName)
+	}
+
+	for _, s := range tokenize.Sentences(article.AnalyzedText()) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if claimed[s.Text] {
+			continue
+		}
+		review, score, ok := c.match(s.Text, nil, language)
+		if !ok {
+			continue
+		}
+		truthiness, rated := review.Rating.truthiness()
+		if flag, ok := c.flag(s.Text, review, truthiness, rated, score); ok {
+			flags = append(flags, flag)
+		}
+	}
+	return flags, nil
+}
+
+// GetSourceReputation returns ErrUnknownSource; claim reviews rate claims,
+// not the sources that repeat them
+func (c *Checker) GetSourceReputation(ctx context.Context, source string) (float64, error) {
+	return 0, secondary.ErrUnknownSource
+}
+
+// match returns the reviewed claim that best matches the text
+func (c *Checker) match(text string, entities []domain.Entity, language string) (Review, float64, bool) {
+	m, ok := c.index.best(newFeatures(text, entities), c.threshold, func(i int) bool {
+		return language == "" || c.reviews[i].Language == "" || c.reviews[i].Language == language
+	})
+	if !ok {
+		return Review{}, 0, false
+	}
+	return c.reviews[m.review], m.score, true
+}
+
+// flag reports a sentence repeating a claim rated false or misleading. The
+// confidence is the match score, lowered when the sentence only reports the
+// claim; sentences refuting the claim are not flagged.
+func (c *Checker) flag(text string, review Review, truthiness float64, rated bool, score float64) (domain.Flag, bool) {
+	if !rated || truthiness >= trueFrom || refutes(text, review.Claim) {
+		return domain.Flag{}, false
+	}
+	flagType := domain.FlagTypeMisleading
+	if truthiness <= falseBelow {
+		flagType = domain.FlagTypeFactualError
+	}
+	confidence := min(score, 1)
+	if reports(text, review.Claim) {
+		confidence *= reportedClaimWeight
+	}
+	return domain.Flag{
+		Type:       flagType,
+		Confidence: confidence,
+		Details:    fmt.Sprintf("%q matches a reviewed claim: %s", text, describe(review)),
+		DetectedBy: CheckerName,
+	}, true
+}
+
+// refutes reports whether the text negates the reviewed claim or presents
+// it as refuted. Only negators and refutation phrases the claim does not
+// itself contain count.
+func refutes(text, claim string) bool {
+	textWords, claimWords := words(text), words(claim)
+	if countNegators(textWords) > countNegators(claimWords) {
+		return true
+	}
+	return morePhrases(textWords, claimWords, refutationPhrases)
+}
+
+// reports reports whether the text attributes the reviewed claim to others
+// rather than asserting it
+func reports(text, claim string) bool {
+	return morePhrases(words(text), words(claim), reportingPhrases)
+}
+
+// words returns the lower-cased words of text, separated by single spaces
+// and padded with a space at either end
+func words(text string) string {
+	return " " + strings.Join(strings.FieldsFunc(strings.ToLower(text), isSeparator), " ") + " "
+}
+
+func countNegators(words string) int {
+	n := 0
+	for _, w := range strings.Fields(words) {
+		if negators[w] {
+			n++
+		}
+	}
+	return n
+}
+
+// morePhrases reports whether any of the phrases occurs more often in text
+// than in claim
+func morePhrases(text, claim string, phrases []string) bool {
+	for _, phrase := range phrases {
+		if strings.Count(text, " "+phrase+" ") > strings.Count(claim, " "+phrase+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+func verdict(truthiness float64, rated bool) domain.ClaimVerdict {
+	switch {
+	case !rated:
+		return domain.ClaimVerdictUnverifiable
+	case truthiness <= falseBelow:
+		return domain.ClaimVerdictFalse
+	case truthiness < trueFrom:
+		return domain.ClaimVerdictMixed
+	}
+	return domain.ClaimVerdictTrue
+}
+
+// describe cites a review, e.g. `rated "Pants on Fire" by PolitiFact for
+// "the claim" (https://…)`
+func describe(review Review) string {
+	publisher := review.Publisher
+	if publisher == "" {
+		publisher = "an unnamed fact-checker"
+	}
+	description := fmt.Sprintf("rated %q by %s for %q", review.Rating.String(), publisher, review.Claim)
+	if review.URL != "" {
+		description += " (" + review.URL + ")"
+	}
+	return description
+}
@@ -0,0 +1,192 @@
+// Package claimreview checks articles against claims that professional
+// fact-checkers have reviewed, as published in schema.org ClaimReview markup.
+package claimreview
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	// FormatName identifies claim review database files
+	FormatName = "reality-filter/claimreview"
+	// FormatVersion is the database file version written by this package.
+	// Readers accept any file with the same version.
+	FormatVersion = 1
+)
+
+// Review is a fact-checker's review of a claim
+type Review struct {
+	Claim       string    `json:"claim"`
+	ClaimAuthor string    `json:"claimAuthor,omitempty"`
+	ClaimDate   time.Time `json:"claimDate"`
+	Rating      Rating    `json:"rating"`
+	Publisher   string    `json:"publisher"`
+	URL         string    `json:"url"`
+	ReviewedAt  time.Time `json:"reviewedAt"`
+	Language    string    `json:"language,omitempty"` // ISO 639-1 code, empty if unknown
+}
+
+// key identifies a review: its URL, or its publisher and claim when it has
+// none
+func (r Review) key() string {
+	if r.URL != "" {
+		return r.URL
+	}
+	return strings.ToLower(r.Publisher) + "\x00" + strings.ToLower(r.Claim)
+}
+
+// Rating is a reviewer's verdict, as text and, if the reviewer gave one, as a
+// value on a numeric scale
+type Rating struct {
+	Name  string   `json:"name"` // e.g. "Pants on Fire", "Mostly true"
+	Value *float64 `json:"value,omitempty"`
+	Best  float64  `json:"best,omitempty"`
+	Worst float64  `json:"worst,omitempty"`
+}
+
+// String returns the rating's name, or its value on the scale
+func (r Rating) String() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	if r.Value != nil {
+		return fmt.Sprintf("%g/%g", *r.Value, r.best())
+	}
+	return ""
+}
+
+// best and worst apply the schema.org default scale of 1 to 5
+func (r Rating) best() float64 {
+	if r.Best == 0 && r.Worst == 0 {
+		return 5
+	}
+	return r.Best
+}
+
+func (r Rating) worst() float64 {
+	if r.Best == 0 && r.Worst == 0 {
+		return 1
+	}
+	return r.Worst
+}
+
+// Database is a local store of claim reviews. Reviews are identified by
+// their URL, so importing a review again replaces the stored one.
+type Database struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	Reviews   []Review  `json:"reviews"`
+
+	byKey map[string]int
+}
+
+// NewDatabase creates an empty database
+func NewDatabase() *Database {
+	return &Database{
+		Format:  FormatName,
+		Version: FormatVersion,
+		byKey:   make(map[string]int),
+	}
+}
+
+// Add stores reviews, replacing those with the same URL, and returns the
+// number of new ones. Reviews without a claim or a rating are rejected.
+func (db *Database) Add(reviews ...Review) (int, error) {
+	added := 0
+	for _, r := range reviews {
+		r.Claim = strings.TrimSpace(r.Claim)
+		if r.Claim == "" {
+			return added, errors.New("review has no claim")
+		}
+		if r.Rating.String() == "" {
+			return added, fmt.Errorf("review of %q has no rating", r.Claim)
+		}
+
+		if i, ok := db.byKey[r.key()]; ok {
+			db.Reviews[i] = r
+			continue
+		}
+		db.byKey[r.key()] = len(db.Reviews)
+		db.Reviews = append(db.Reviews, r)
+		added++
+	}
+	db.UpdatedAt = time.Now().UTC()
+	return added, nil
+}
+
+// Len returns the number of reviews
+func (db *Database) Len() int {
+	return len(db.Reviews)
+}
+
+// LoadDatabase reads a gzip-compressed database file
+func LoadDatabase(path string) (*Database, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open claim review database: %w", err)
+	}
+	defer f.Close()
+
+	db, err := ReadDatabase(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read claim review database %s: %w", path, err)
+	}
+	return db, nil
+}
+
+// ReadDatabase decodes a gzip-compressed database and checks its format
+// version
+func ReadDatabase(r io.Reader) (*Database, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	var db Database
+	if err := json.NewDecoder(gz).Decode(&db); err != nil {
+		return nil, err
+	}
+	if db.Format != FormatName {
+		return nil, fmt.Errorf("unknown database format %q", db.Format)
+	}
+	if db.Version != FormatVersion {
+		return nil, fmt.Errorf("unsupported database version %d, expected %d", db.Version, FormatVersion)
+	}
+	db.byKey = make(map[string]int, len(db.Reviews))
+	for i, r := range db.Reviews {
+		db.byKey[r.key()] = i
+	}
+	return &db, nil
+}
+
+// Save writes the database to a gzip-compressed file
+func (db *Database) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create claim review database file: %w", err)
+	}
+	if err := db.Write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write claim review database: %w", err)
+	}
+	return f.Close()
+}
+
+// Write encodes the database as gzip-compressed JSON
+func (db *Database) Write(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	if err := json.NewEncoder(gz).Encode(db); err != nil {
+		gz.Close()
+		return err
+	}
+	return gz.Close()
+}
@@ -0,0 +1,206 @@
+package claimreview
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReadJSONLD reads the ClaimReview nodes of a JSON-LD document: a single
+// review, an array, an object with a "@graph", or a DataFeed whose elements
+// contain reviews. Reviews may be nested anywhere, for example in a web
+// page's "mainEntity". Reviews without a claim or a rating are skipped, as
+// are dates that cannot be parsed.
+//
+// As in the knowledge base, the "@context" is not expanded and properties
+// are read by their local names.
+func ReadJSONLD(r io.Reader) ([]Review, error) {
+	var document interface{}
+	if err := json.NewDecoder(r).Decode(&document); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	var nodes []map[string]interface{}
+	collectReviews(document, &nodes)
+
+	reviews := make([]Review, 0, len(nodes))
+	for _, node := range nodes {
+		if review, ok := readReview(node); ok {
+			reviews = append(reviews, review)
+		}
+	}
+	return reviews, nil
+}
+
+// collectReviews gathers the ClaimReview nodes of the document
+func collectReviews(value interface{}, nodes *[]map[string]interface{}) {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			collectReviews(item, nodes)
+		}
+	case map[string]interface{}:
+		if hasType(v, "ClaimReview") {
+			*nodes = append(*nodes, v)
+			return
+		}
+		for key, child := range v {
+			if key == "@graph" || !strings.HasPrefix(key, "@") {
+				collectReviews(child, nodes)
+			}
+		}
+	}
+}
+
+func readReview(node map[string]interface{}) (Review, bool) {
+	claim := property(node, "itemReviewed")
+	review := Review{
+		Claim:       text(property(node, "claimReviewed")),
+		ClaimAuthor: name(property(claim, "author")),
+		ClaimDate:   date(property(claim, "datePublished")),
+		Rating:      readRating(property(node, "reviewRating")),
+		Publisher:   name(property(node, "author")),
+		URL:         text(property(node, "url")),
+		ReviewedAt:  date(property(node, "datePublished")),
+		Language:    language(property(node, "inLanguage")),
+	}
+	if review.Claim == "" {
+		review.Claim = text(property(claim, "name"))
+	}
+	if review.Publisher == "" {
+		review.Publisher = name(property(node, "publisher"))
+	}
+	if review.URL == "" {
+		if id, _ := node["@id"].(string); strings.HasPrefix(id, "http") {
+			review.URL = id
+		}
+	}
+	if review.ClaimDate.IsZero() {
+		if appearance, ok := property(claim, "firstAppearance").(map[string]interface{}); ok {
+			review.ClaimDate = date(property(appearance, "datePublished"))
+		}
+	}
+	return review, review.Claim != "" && review.Rating.String() != ""
+}
+
+func readRating(value interface{}) Rating {
+	node, ok := first(value).(map[string]interface{})
+	if !ok {
+		return Rating{Name: text(value)}
+	}
+	rating := Rating{Name: text(property(node, "alternateName"))}
+	if rating.Name == "" {
+		rating.Name = text(property(node, "name"))
+	}
+	if v, ok := number(property(node, "ratingValue")); ok {
+		rating.Value = &v
+		rating.Best, _ = number(property(node, "bestRating"))
+		rating.Worst, _ = number(property(node, "worstRating"))
+	}
+	return rating
+}
+
+// property returns the value of a node's property, given by local name, or
+// nil if the node has none
+func property(node interface{}, name string) interface{} {
+	m, ok := first(node).(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	for key, value := range m {
+		if localName(key) == name {
+			return value
+		}
+	}
+	return nil
+}
+
+// first returns the first element of an array, or the value itself
+func first(value interface{}) interface{} {
+	if list, ok := value.([]interface{}); ok {
+		if len(list) == 0 {
+			return nil
+		}
+		return list[0]
+	}
+	return value
+}
+
+// text returns a string literal or the "@value" of a value object
+func text(value interface{}) string {
+	value = first(value)
+	if m, ok := value.(map[string]interface{}); ok {
+		value = m["@value"]
+	}
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return ""
+}
+
+// name returns the name of an organization or person, given as a node or a
+// string
+func name(value interface{}) string {
+	if _, ok := first(value).(map[string]interface{}); ok {
+		return text(property(value, "name"))
+	}
+	return text(value)
+}
+
+func number(value interface{}) (float64, bool) {
+	v, err := strconv.ParseFloat(text(value), 64)
+	return v, err == nil
+}
+
+// date parses an RFC 3339 timestamp or a date, returning zero if it cannot
+func date(value interface{}) time.Time {
+	s := text(value)
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// language returns the ISO 639-1 code of a language given as a BCP 47 tag
+// or a Language node
+func language(value interface{}) string {
+	tag := text(value)
+	if _, ok := first(value).(map[string]interface{}); ok {
+		tag = text(property(value, "alternateName"))
+	}
+	tag, _, _ = strings.Cut(strings.ToLower(tag), "-")
+	if len(tag) != 2 {
+		return ""
+	}
+	return tag
+}
+
+func hasType(node map[string]interface{}, want string) bool {
+	switch t := node["@type"].(type) {
+	case string:
+		return localName(t) == want
+	case []interface{}:
+		for _, v := range t {
+			if s, ok := v.(string); ok && localName(s) == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// localName strips the prefix or namespace IRI from a property name
+func localName(key string) string {
+	if i := strings.LastIndexAny(key, "/#:"); i >= 0 && !strings.HasPrefix(key, "@") {
+		return key[i+1:]
+	}
+	return key
+}
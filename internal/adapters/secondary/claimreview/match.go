@@ -0,0 +1,170 @@
+package claimreview
+
+import (
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+const (
+	// shingleWeight is the share of the match score given by the shingle
+	// similarity; the rest comes from the overlap of names and numbers
+	shingleWeight = 0.7
+	// minShingleSimilarity keeps sentences that merely name the same people
+	// or figures from matching a claim
+	minShingleSimilarity = 0.35
+	// minSharedShingles is the number of shingles a sentence must share with
+	// a claim to be compared with it at all
+	minSharedShingles = 2
+)
+
+// features are what a sentence or claim is compared by: its word bigrams
+// and the names and numbers it mentions
+type features struct {
+	shingles map[string]bool
+	terms    map[string]bool
+}
+
+// newFeatures extracts the features of text. Capitalized words other than
+// the first are taken as names, together with the words of the entities.
+func newFeatures(text string, entities []domain.Entity) features {
+	f := features{
+		shingles: make(map[string]bool),
+		terms:    make(map[string]bool),
+	}
+
+	var words []string
+	for i, t := range tokenize.Words(text) {
+		w := tokenize.Normalize(t.Text)
+		if w == "" {
+			continue
+		}
+		words = append(words, w)
+
+		switch r, _ := utf8.DecodeRuneInString(t.Text); {
+		case strings.ContainsFunc(w, unicode.IsDigit):
+			f.terms[strings.ReplaceAll(w, ",", "")] = true
+		case i > 0 && unicode.IsUpper(r):
+			f.terms[w] = true
+		}
+	}
+	for _, e := range entities {
+		for _, t := range tokenize.Words(e.Value) {
+			if w := tokenize.Normalize(t.Text); w != "" {
+				f.terms[w] = true
+			}
+		}
+	}
+
+	if len(words) == 1 {
+		f.shingles[words[0]] = true
+	}
+	for i := 1; i < len(words); i++ {
+		f.shingles[words[i-1]+" "+words[i]] = true
+	}
+	return f
+}
+
+// index finds the reviewed claims most similar to a sentence. Shingles are
+// weighted by their inverse document frequency among the claims, so that
+// bigrams such as "of the" count for little.
+type index struct {
+	claims   []features
+	norms    []float64 // Euclidean norm of every claim's shingle weights
+	postings map[string][]int
+}
+
+func newIndex(reviews []Review) *index {
+	ix := &index{
+		claims:   make([]features, len(reviews)),
+		norms:    make([]float64, len(reviews)),
+		postings: make(map[string][]int),
+	}
+	for i, r := range reviews {
+		ix.claims[i] = newFeatures(r.Claim, nil)
+		for s := range ix.claims[i].shingles {
+			ix.postings[s] = append(ix.postings[s], i)
+		}
+	}
+	for i, claim := range ix.claims {
+		ix.norms[i] = ix.norm(claim.shingles)
+	}
+	return ix
+}
+
+// weight returns the inverse document frequency of a shingle
+func (ix *index) weight(shingle string) float64 {
+	return math.Log(1 + float64(len(ix.claims))/float64(1+len(ix.postings[shingle])))
+}
+
+func (ix *index) norm(shingles map[string]bool) float64 {
+	var sum float64
+	for s := range shingles {
+		w := ix.weight(s)
+		sum += w * w
+	}
+	return math.Sqrt(sum)
+}
+
+// match is a reviewed claim similar to a sentence
+type match struct {
+	review int
+	score  float64
+}
+
+// best returns the most similar accepted claim, if any scores at least
+// threshold. The score is the weighted cosine similarity of the shingles
+// combined with the Jaccard similarity of the names and numbers.
+func (ix *index) best(f features, threshold float64, accept func(review int) bool) (match, bool) {
+	shared := make(map[int][]string)
+	for s := range f.shingles {
+		for _, i := range ix.postings[s] {
+			shared[i] = append(shared[i], s)
+		}
+	}
+
+	norm := ix.norm(f.shingles)
+	var best match
+	for i, shingles := range shared {
+		if !accept(i) || (len(shingles) < minSharedShingles && len(shingles) < len(ix.claims[i].shingles)) {
+			continue
+		}
+		var dot float64
+		for _, s := range shingles {
+			w := ix.weight(s)
+			dot += w * w
+		}
+		similarity := dot / (norm * ix.norms[i])
+		if similarity < minShingleSimilarity {
+			continue
+		}
+
+		score := similarity
+		if overlap, ok := jaccard(f.terms, ix.claims[i].terms); ok {
+			score = shingleWeight*similarity + (1-shingleWeight)*overlap
+		}
+		if score > best.score || (score == best.score && i < best.review) {
+			best = match{review: i, score: score}
+		}
+	}
+	return best, best.score > 0 && best.score >= threshold
+}
+
+// jaccard returns the Jaccard similarity of two sets, reporting false when
+// both are empty
+func jaccard(a, b map[string]bool) (float64, bool) {
+	if len(a) == 0 && len(b) == 0 {
+		return 0, false
+	}
+	shared := 0
+	for k := range a {
+		if b[k] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared), true
+}
@@ -0,0 +1,223 @@
+package claims
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/internal/core/ports/secondary"
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+// DefaultThreshold is the check-worthiness score at which a sentence is
+// extracted as a claim
+const DefaultThreshold = 0.5
+
+// Weights are the coefficients of the logistic check-worthiness model. A
+// sentence's score is sigmoid(Bias + sum of fired weights).
+type Weights struct {
+	Bias        float64
+	Number      float64
+	Entity      float64 // per entity mentioned, up to three
+	Comparative float64
+	Attribution float64
+	Question    float64
+	Opinion     float64
+	Short       float64 // sentences of fewer than four words
+}
+
+// DefaultWeights returns weights under which a statistic, or an attributed
+// statement about a named entity, is check-worthy on its own while bare
+// entity mentions and opinions are not
+func DefaultWeights() Weights {
+	return Weights{
+		Bias:        -2.0,
+		Number:      2.2,
+		Entity:      0.5,
+		Comparative: 1.0,
+		Attribution: 1.6,
+		Question:    -2.0,
+		Opinion:     -1.5,
+		Short:       -1.5,
+	}
+}
+
+// cues holds the language-specific word lists of the model
+type cues struct {
+	numberWords  map[string]bool
+	comparatives map[string]bool
+	attribution  []string
+	opinion      []string
+}
+
+var (
+	digitPattern       = regexp.MustCompile(`\d`)
+	comparativePattern = regexp.MustCompile(`(?i)\b\w+er than\b|\b(?:more|less|fewer) than\b|\bas \w+ as\b`)
+
+	languageCues = map[string]*cues{
+		"en": {
+			numberWords: wordSet(
+				"percent", "percentage", "half", "third", "quarter",
+				"dozen", "dozens", "hundred", "hundreds", "thousand", "thousands",
+				"million", "millions", "billion", "billions", "trillion",
+				"twice", "double", "doubled", "tripled", "majority", "minority",
+				"one", "two", "three", "four", "five", "six", "seven", "eight",
+				"nine", "ten", "eleven", "twelve", "twenty", "fifty",
+			),
+			comparatives: wordSet(
+				"most", "least", "largest", "biggest", "smallest", "highest",
+				"lowest", "fastest", "slowest", "best", "worst", "first", "only",
+				"record", "increased", "increase", "decreased", "decrease", "rose",
+				"risen", "fell", "fallen", "dropped", "grew", "shrank", "surged",
+				"plunged", "doubled", "halved", "unprecedented",
+			),
+			attribution: []string{
+				"said", "says", "told", "according to", "reported", "reports",
+				"announced", "claimed", "claims", "stated", "confirmed", "estimated",
+				"estimates", "found", "showed", "shows", "revealed", "concluded",
+				"data from", "figures from", "study", "survey", "census", "poll",
+			},
+			opinion: []string{
+				"i think", "i believe", "i feel", "in my opinion", "in our view",
+				"we believe", "i hope", "should", "ought to", "let's", "let us",
+			},
+		},
+	}
+)
+
+// Extractor implements secondary.ClaimExtractor with a weighted
+// check-worthiness model over each sentence
+type Extractor struct {
+	weights   Weights
+	threshold float64
+}
+
+// Ensure Extractor implements secondary.ClaimExtractor
+var _ secondary.ClaimExtractor = (*Extractor)(nil)
+
+// NewExtractor creates a claim extractor with the default weights that keeps
+// sentences whose check-worthiness reaches threshold
+func NewExtractor(threshold float64) *Extractor {
+	return NewExtractorWithWeights(DefaultWeights(), threshold)
+}
+
+// NewExtractorWithWeights creates a claim extractor with custom weights
+func NewExtractorWithWeights(weights Weights, threshold float64) *Extractor {
+	return &Extractor{weights: weights, threshold: threshold}
+}
+
+// ExtractClaims scores every sentence of the text and returns the
+// check-worthy ones in document order. Word-list cues are only applied for
+// languages that have them; numbers and entity mentions count in every
+// language.
+func (e *Extractor) ExtractClaims(ctx context.Context, text string, entities []domain.Entity) ([]domain.Claim, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c := languageCues[secondary.LanguageFromContext(ctx)]
+
+	var claims []domain.Claim
+	for _, sentence := range tokenize.Sentences(text) {
+		start, end := tokenize.RuneOffsets(text, sentence.Start, sentence.End)
+		span := domain.Span{Start: start, End: end}
+		mentioned := entitiesIn(entities, span)
+
+		score := e.score(sentence.Text, mentioned, c)
+		if score < e.threshold {
+			continue
+		}
+		claims = append(claims, domain.NewClaim(sentence.Text, span, score, mentioned))
+	}
+	return claims, nil
+}
+
+// score returns the check-worthiness of a sentence
+func (e *Extractor) score(sentence string, entities []domain.Entity, c *cues) float64 {
+	words := tokenize.Words(sentence)
+	normalized := make([]string, 0, len(words))
+	for _, w := range words {
+		if n := tokenize.Normalize(w.Text); n != "" {
+			normalized = append(normalized, n)
+		}
+	}
+	lower := " " + strings.Join(normalized, " ") + " "
+
+	z := e.weights.Bias
+	if digitPattern.MatchString(sentence) || (c != nil && containsWord(normalized, c.numberWords)) {
+		z += e.weights.Number
+	}
+	z += e.weights.Entity * math.Min(float64(len(entities)), 3)
+	if c != nil {
+		if comparativePattern.MatchString(sentence) || containsWord(normalized, c.comparatives) {
+			z += e.weights.Comparative
+		}
+		if containsPhrase(lower, c.attribution) {
+			z += e.weights.Attribution
+		}
+		if containsPhrase(lower, c.opinion) {
+			z += e.weights.Opinion
+		}
+	}
+	if strings.HasSuffix(strings.TrimRight(sentence, `"'”’) `), "?") {
+		z += e.weights.Question
+	}
+	if len(normalized) < 4 {
+		z += e.weights.Short
+	}
+	return 1 / (1 + math.Exp(-z))
+}
+
+// entitiesIn returns the entities with at least one mention inside span.
+// Only the mentions inside the span are kept.
+func entitiesIn(entities []domain.Entity, span domain.Span) []domain.Entity {
+	var found []domain.Entity
+	for _, entity := range entities {
+		var mentions []domain.Span
+		for _, m := range entity.Mentions {
+			if m.Start >= span.Start && m.End <= span.End {
+				mentions = append(mentions, m)
+			}
+		}
+		if len(mentions) == 0 {
+			continue
+		}
+		found = append(found, domain.Entity{
+			Type:     entity.Type,
+			Value:    entity.Value,
+			Count:    len(mentions),
+			Mentions: mentions,
+		})
+	}
+	return found
+}
+
+func containsWord(words []string, set map[string]bool) bool {
+	for _, w := range words {
+		if set[w] {
+			return true
+		}
+	}
+	return false
+}
+
+// containsPhrase reports whether any phrase occurs in the space-padded,
+// normalized sentence
+func containsPhrase(padded string, phrases []string) bool {
+	for _, p := range phrases {
+		if strings.Contains(padded, " "+p+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+func wordSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
@@ -0,0 +1,74 @@
+package classifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/internal/core/ports/secondary"
+)
+
+// DetectorName identifies flags raised by the trained classifier
+const DetectorName = "text_classifier"
+
+// Analyzer implements secondary.ContentAnalyzer using a trained model
+type Analyzer struct {
+	model *Model
+}
+
+// Ensure Analyzer implements secondary.ContentAnalyzer
+var _ secondary.ContentAnalyzer = (*Analyzer)(nil)
+
+// NewAnalyzer creates an analyzer for a trained model
+func NewAnalyzer(model *Model) *Analyzer {
+	return &Analyzer{model: model}
+}
+
+// NewAnalyzerFromFile loads a model file and creates an analyzer for it
+func NewAnalyzerFromFile(path string) (*Analyzer, error) {
+	model, err := LoadModel(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewAnalyzer(model), nil
+}
+
+// DetectBias returns a flag for every label whose calibrated confidence
+// reaches its threshold
+func (a *Analyzer) DetectBias(ctx context.Context, text string) ([]domain.Flag, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	v := extractFeatures(text, a.model.HashBits, a.model.MaxOrder)
+	if len(v) == 0 {
+		return nil, nil
+	}
+
+	var flags []domain.Flag
+	for i := range a.model.Labels {
+		label := &a.model.Labels[i]
+		confidence := label.confidence(v)
+		if confidence < label.Threshold {
+			continue
+		}
+		flags = append(flags, domain.Flag{
+			Type:       label.FlagType,
+			Confidence: confidence,
+			Details: fmt.Sprintf("classifier trained %s on %d reviewed examples predicts %s with calibrated confidence %.2f",
+				a.model.TrainedAt.Format("2006-01-02"), label.Metrics.Positives+label.Metrics.Negatives, label.FlagType, confidence),
+			DetectedBy: DetectorName,
+		})
+	}
+	return flags, nil
+}
+
+// AnalyzeSentiment is not supported by the classifier
+func (a *Analyzer) AnalyzeSentiment(ctx context.Context, text string) (float64, error) {
+	return 0.5, nil
+}
+
+// ExtractEntities is not supported by the classifier
+func (a *Analyzer) ExtractEntities(ctx context.Context, text string) ([]domain.Entity, error) {
+	return nil, nil
+}
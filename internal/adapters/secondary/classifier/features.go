@@ -0,0 +1,61 @@
+package classifier
+
+import (
+	"hash/fnv"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+// feature is a single non-zero entry of a sparse feature vector
+type feature struct {
+	index uint32
+	value float64
+}
+
+// vector is a sparse feature vector sorted by index
+type vector []feature
+
+// extractFeatures hashes the word n-grams of the text, up to maxOrder words
+// long, into a space of 2^hashBits dimensions. Values are log-scaled term
+// frequencies normalized to unit length.
+func extractFeatures(text string, hashBits uint, maxOrder int) vector {
+	tokens := tokenize.Words(text)
+	words := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		if w := tokenize.Normalize(t.Text); w != "" {
+			words = append(words, w)
+		}
+	}
+
+	mask := uint32(1)<<hashBits - 1
+	counts := make(map[uint32]float64)
+	for n := 1; n <= maxOrder; n++ {
+		for i := 0; i+n <= len(words); i++ {
+			counts[hashFeature(strings.Join(words[i:i+n], " "))&mask]++
+		}
+	}
+
+	v := make(vector, 0, len(counts))
+	var norm float64
+	for index, c := range counts {
+		value := math.Log1p(c)
+		v = append(v, feature{index: index, value: value})
+		norm += value * value
+	}
+	norm = math.Sqrt(norm)
+	for i := range v {
+		v[i].value /= norm
+	}
+	sort.Slice(v, func(a, b int) bool { return v[a].index < v[b].index })
+	return v
+}
+
+// hashFeature returns the 32-bit FNV-1a hash of a feature string
+func hashFeature(s string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(s))
+	return h.Sum32()
+}
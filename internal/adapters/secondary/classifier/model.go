@@ -0,0 +1,151 @@
+package classifier
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"time"
+
+	"github.com/reality-filter/internal/core/domain"
+)
+
+const (
+	// FormatName identifies classifier model files
+	FormatName = "reality-filter/classifier"
+	// FormatVersion is the model file version written by this package.
+	// Readers accept any file with the same version.
+	FormatVersion = 1
+)
+
+// Model is a set of one-vs-rest logistic regression classifiers, one per flag
+// type, over hashed word n-gram features
+type Model struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	TrainedAt time.Time `json:"trainedAt"`
+	HashBits  uint      `json:"hashBits"`
+	MaxOrder  int       `json:"maxOrder"`
+	Labels    []Label   `json:"labels"`
+}
+
+// Label is the classifier for a single flag type
+type Label struct {
+	FlagType domain.FlagType `json:"flagType"`
+	Bias     float64         `json:"bias"`
+	// Indices and Weights hold the non-zero feature weights
+	Indices []uint32  `json:"indices"`
+	Weights []float64 `json:"weights"`
+	// Calibration holds Platt scaling parameters fitted on held-out data:
+	// confidence = sigmoid(A*margin + B)
+	Calibration Calibration `json:"calibration"`
+	// Threshold is the calibrated confidence at which a flag is emitted
+	Threshold float64 `json:"threshold"`
+	Metrics   Metrics `json:"metrics"`
+
+	weights map[uint32]float64
+}
+
+// Calibration holds Platt scaling parameters
+type Calibration struct {
+	A float64 `json:"a"`
+	B float64 `json:"b"`
+}
+
+// Metrics describes the training data and held-out performance of a label
+type Metrics struct {
+	Positives int     `json:"positives"`
+	Negatives int     `json:"negatives"`
+	Accuracy  float64 `json:"accuracy"`
+	LogLoss   float64 `json:"logLoss"`
+}
+
+// LoadModel reads a gzip-compressed model file
+func LoadModel(path string) (*Model, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open model: %w", err)
+	}
+	defer f.Close()
+
+	model, err := ReadModel(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read model %s: %w", path, err)
+	}
+	return model, nil
+}
+
+// ReadModel decodes a gzip-compressed model and checks its format version
+func ReadModel(r io.Reader) (*Model, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	var model Model
+	if err := json.NewDecoder(gz).Decode(&model); err != nil {
+		return nil, err
+	}
+	if model.Format != FormatName {
+		return nil, fmt.Errorf("unknown model format %q", model.Format)
+	}
+	if model.Version != FormatVersion {
+		return nil, fmt.Errorf("unsupported model version %d, expected %d", model.Version, FormatVersion)
+	}
+	for i := range model.Labels {
+		label := &model.Labels[i]
+		if len(label.Indices) != len(label.Weights) {
+			return nil, fmt.Errorf("label %s: %d indices but %d weights", label.FlagType, len(label.Indices), len(label.Weights))
+		}
+		label.weights = make(map[uint32]float64, len(label.Indices))
+		for k, index := range label.Indices {
+			label.weights[index] = label.Weights[k]
+		}
+	}
+	return &model, nil
+}
+
+// Save writes the model to a gzip-compressed file
+func (m *Model) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create model file: %w", err)
+	}
+	if err := m.Write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write model: %w", err)
+	}
+	return f.Close()
+}
+
+// Write encodes the model as gzip-compressed JSON
+func (m *Model) Write(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	if err := json.NewEncoder(gz).Encode(m); err != nil {
+		gz.Close()
+		return err
+	}
+	return gz.Close()
+}
+
+// margin returns the raw logistic regression score of a feature vector
+func (l *Label) margin(v vector) float64 {
+	z := l.Bias
+	for _, f := range v {
+		z += l.weights[f.index] * f.value
+	}
+	return z
+}
+
+// confidence returns the calibrated probability that the vector belongs to
+// the label
+func (l *Label) confidence(v vector) float64 {
+	return sigmoid(l.Calibration.A*l.margin(v) + l.Calibration.B)
+}
+
+func sigmoid(z float64) float64 {
+	return 1 / (1 + math.Exp(-z))
+}
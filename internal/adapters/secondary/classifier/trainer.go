@@ -0,0 +1,256 @@
+package classifier
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/reality-filter/internal/core/domain"
+)
+
+// Example is a labeled training document
+type Example struct {
+	Text string
+	// FlagTypes lists the flag types the document is a positive example for.
+	// Documents without flag types are negative examples for every label.
+	FlagTypes []domain.FlagType
+}
+
+// TrainerConfig controls model training
+type TrainerConfig struct {
+	HashBits uint
+	MaxOrder int
+	Epochs   int
+	// LearningRate is the initial SGD step size
+	LearningRate float64
+	// L2 is the regularization strength
+	L2 float64
+	// HoldOut is the fraction of examples reserved for calibration
+	HoldOut float64
+	// MinPositives is the number of positive examples a flag type needs
+	// before a classifier is trained for it
+	MinPositives int
+	// Threshold is the calibrated confidence at which flags are emitted
+	Threshold float64
+	Seed      int64
+}
+
+// DefaultTrainerConfig returns settings suited to a few thousand articles
+func DefaultTrainerConfig() TrainerConfig {
+	return TrainerConfig{
+		HashBits:     18,
+		MaxOrder:     2,
+		Epochs:       10,
+		LearningRate: 0.5,
+		L2:           1e-5,
+		HoldOut:      0.2,
+		MinPositives: 5,
+		Threshold:    0.7,
+		Seed:         1,
+	}
+}
+
+// Train fits one classifier per flag type that has enough positive examples
+func Train(examples []Example, cfg TrainerConfig) (*Model, error) {
+	if len(examples) == 0 {
+		return nil, fmt.Errorf("no training examples")
+	}
+
+	vectors := make([]vector, len(examples))
+	for i, e := range examples {
+		vectors[i] = extractFeatures(e.Text, cfg.HashBits, cfg.MaxOrder)
+	}
+
+	// Shuffle once so every label uses the same split
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	order := rng.Perm(len(examples))
+	split := len(order) - int(float64(len(order))*cfg.HoldOut)
+	train, held := order[:split], order[split:]
+
+	flagTypes := make(map[domain.FlagType]int)
+	for _, e := range examples {
+		for _, t := range uniqueFlagTypes(e.FlagTypes) {
+			flagTypes[t]++
+		}
+	}
+	types := make([]domain.FlagType, 0, len(flagTypes))
+	for t, n := range flagTypes {
+		if n >= cfg.MinPositives {
+			types = append(types, t)
+		}
+	}
+	sort.Slice(types, func(a, b int) bool { return types[a] < types[b] })
+	if len(types) == 0 {
+		return nil, fmt.Errorf("no flag type has at least %d positive examples", cfg.MinPositives)
+	}
+
+	model := &Model{
+		Format:    FormatName,
+		Version:   FormatVersion,
+		TrainedAt: time.Now().UTC(),
+		HashBits:  cfg.HashBits,
+		MaxOrder:  cfg.MaxOrder,
+	}
+	for _, t := range types {
+		labels := make([]bool, len(examples))
+		for i, e := range examples {
+			labels[i] = hasFlagType(e.FlagTypes, t)
+		}
+		model.Labels = append(model.Labels, trainLabel(t, vectors, labels, train, held, cfg, rng))
+	}
+	return model, nil
+}
+
+// trainLabel fits a class-balanced logistic regression with SGD on the
+// training split and calibrates it on the held-out split
+func trainLabel(flagType domain.FlagType, vectors []vector, labels []bool, train, held []int, cfg TrainerConfig, rng *rand.Rand) Label {
+	positives := 0
+	for _, i := range train {
+		if labels[i] {
+			positives++
+		}
+	}
+	negatives := len(train) - positives
+	posWeight, negWeight := 1.0, 1.0
+	if positives > 0 && negatives > 0 {
+		posWeight = float64(len(train)) / (2 * float64(positives))
+		negWeight = float64(len(train)) / (2 * float64(negatives))
+	}
+
+	weights := make(map[uint32]float64)
+	bias := 0.0
+	step := 0
+	order := append([]int(nil), train...)
+	for epoch := 0; epoch < cfg.Epochs; epoch++ {
+		rng.Shuffle(len(order), func(a, b int) { order[a], order[b] = order[b], order[a] })
+		for _, i := range order {
+			step++
+			rate := cfg.LearningRate / (1 + cfg.LearningRate*cfg.L2*float64(step))
+
+			z := bias
+			for _, f := range vectors[i] {
+				z += weights[f.index] * f.value
+			}
+			target, weight := 0.0, negWeight
+			if labels[i] {
+				target, weight = 1.0, posWeight
+			}
+			gradient := (sigmoid(z) - target) * weight
+
+			for _, f := range vectors[i] {
+				w := weights[f.index]
+				weights[f.index] = w - rate*(gradient*f.value+cfg.L2*w)
+			}
+			bias -= rate * gradient
+		}
+	}
+
+	label := Label{FlagType: flagType, Bias: bias, Threshold: cfg.Threshold, weights: weights}
+	indices := make([]uint32, 0, len(weights))
+	for index, w := range weights {
+		if w != 0 {
+			indices = append(indices, index)
+		}
+	}
+	sort.Slice(indices, func(a, b int) bool { return indices[a] < indices[b] })
+	label.Indices = indices
+	label.Weights = make([]float64, len(indices))
+	for k, index := range indices {
+		label.Weights[k] = weights[index]
+	}
+
+	margins := make([]float64, len(held))
+	heldLabels := make([]bool, len(held))
+	for k, i := range held {
+		margins[k] = label.margin(vectors[i])
+		heldLabels[k] = labels[i]
+	}
+	label.Calibration = fitPlatt(margins, heldLabels)
+	label.Metrics = evaluate(&label, margins, heldLabels)
+	label.Metrics.Positives = positives
+	label.Metrics.Negatives = negatives
+	return label
+}
+
+// fitPlatt fits Platt scaling parameters by gradient descent on the log loss,
+// using Platt's smoothed targets. With too little held-out data, or only one
+// class, the identity calibration is returned.
+func fitPlatt(margins []float64, labels []bool) Calibration {
+	positives := 0
+	for _, l := range labels {
+		if l {
+			positives++
+		}
+	}
+	negatives := len(labels) - positives
+	if positives < 2 || negatives < 2 {
+		return Calibration{A: 1}
+	}
+
+	hi := (float64(positives) + 1) / (float64(positives) + 2)
+	lo := 1 / (float64(negatives) + 2)
+
+	a, b := 1.0, 0.0
+	for iter := 0; iter < 2000; iter++ {
+		var ga, gb float64
+		for k, m := range margins {
+			target := lo
+			if labels[k] {
+				target = hi
+			}
+			d := sigmoid(a*m+b) - target
+			ga += d * m
+			gb += d
+		}
+		n := float64(len(margins))
+		a -= 0.1 * ga / n
+		b -= 0.1 * gb / n
+	}
+	return Calibration{A: a, B: b}
+}
+
+// evaluate computes held-out accuracy and log loss of calibrated confidences
+func evaluate(label *Label, margins []float64, labels []bool) Metrics {
+	if len(margins) == 0 {
+		return Metrics{}
+	}
+	var correct int
+	var loss float64
+	for k, m := range margins {
+		p := sigmoid(label.Calibration.A*m + label.Calibration.B)
+		p = math.Min(math.Max(p, 1e-9), 1-1e-9)
+		if labels[k] {
+			loss -= math.Log(p)
+		} else {
+			loss -= math.Log(1 - p)
+		}
+		if (p >= 0.5) == labels[k] {
+			correct++
+		}
+	}
+	n := float64(len(margins))
+	return Metrics{Accuracy: float64(correct) / n, LogLoss: loss / n}
+}
+
+func hasFlagType(types []domain.FlagType, t domain.FlagType) bool {
+	for _, x := range types {
+		if x == t {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueFlagTypes(types []domain.FlagType) []domain.FlagType {
+	seen := make(map[domain.FlagType]bool)
+	var unique []domain.FlagType
+	for _, t := range types {
+		if !seen[t] {
+			seen[t] = true
+			unique = append(unique, t)
+		}
+	}
+	return unique
+}
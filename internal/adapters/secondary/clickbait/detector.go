@@ -0,0 +1,244 @@
+package clickbait
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/internal/core/ports/secondary"
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+const (
+	// DetectorName identifies flags raised by the clickbait detector
+	DetectorName = "clickbait_detector"
+	// DefaultThreshold is the clickbait probability at which a headline is
+	// flagged
+	DefaultThreshold = 0.6
+)
+
+// Signal names reported in flag details
+const (
+	SignalCuriosityGap   = "curiosity gap"
+	SignalForwardRef     = "forward reference"
+	SignalListicle       = "listicle"
+	SignalLeadingNumeral = "leading numeral"
+	SignalPunctuation    = "excessive punctuation"
+	SignalAllCaps        = "all-caps words"
+	SignalSecondPerson   = "second-person address"
+	SignalHyperbole      = "hyperbole"
+	SignalQuestion       = "question headline"
+)
+
+// Weights are the coefficients of the logistic clickbait model. A headline's
+// probability of being clickbait is sigmoid(Bias + sum of fired weights).
+type Weights struct {
+	Bias          float64
+	CuriosityGap  float64
+	ForwardRef    float64
+	Listicle      float64
+	LeadingNumber float64
+	Punctuation   float64
+	AllCaps       float64
+	SecondPerson  float64
+	Hyperbole     float64
+	Question      float64
+}
+
+// DefaultWeights returns weights tuned so that a single weak signal stays
+// below the flagging threshold while any strong signal, or two weak ones,
+// crosses it. The curiosity gap and listicle patterns are the strong
+// signals; the rest are weak.
+func DefaultWeights() Weights {
+	return Weights{
+		Bias:          -3.0,
+		CuriosityGap:  3.8,
+		ForwardRef:    2.2,
+		Listicle:      3.6,
+		LeadingNumber: 1.8,
+		Punctuation:   2.4,
+		AllCaps:       2.3,
+		SecondPerson:  2.0,
+		Hyperbole:     2.1,
+		Question:      1.7,
+	}
+}
+
+var (
+	curiosityGapPhrases = []string{
+		"you won't believe", "you will never believe", "won't believe what",
+		"what happened next", "what happens next", "this is why", "here's why",
+		"here is why", "the reason why", "the real reason", "will blow your mind",
+		"blew my mind", "you need to know", "you need to see", "can't believe",
+		"cannot believe", "one weird trick", "this one trick", "doctors hate",
+		"what happens when", "will make you", "everything you need to know",
+		"the truth about", "nobody is talking about", "no one is talking about",
+		"they don't want you to know", "is not what you think", "isn't what you think",
+		"you'll never guess", "you won't guess", "will change your life",
+		"will restore your faith", "left speechless", "goes viral", "broke the internet",
+		"what they found", "what she said", "what he said", "you should know",
+	}
+
+	hyperboleWords = map[string]bool{
+		"shocking": true, "unbelievable": true, "incredible": true, "insane": true,
+		"epic": true, "mind-blowing": true, "jaw-dropping": true, "amazing": true,
+		"stunning": true, "outrageous": true, "ever": true, "literally": true,
+		"genius": true, "heartbreaking": true, "hilarious": true, "terrifying": true,
+		"bizarre": true, "unreal": true, "secret": true, "secrets": true,
+	}
+
+	secondPersonWords = map[string]bool{
+		"you": true, "your": true, "you're": true, "you'll": true, "yourself": true,
+	}
+
+	forwardRefPattern = regexp.MustCompile(`(?i)^(?:this|these|here's|here is|here are|that|why this|what this)\b`)
+	listiclePattern   = regexp.MustCompile(`(?i)^\s*(\d+|ten|five|seven|nine|twelve)\s+(?:\w+\s+){0,2}(?:things|reasons|ways|facts|signs|tips|tricks|times|photos|pictures|people|celebrities|foods|places|secrets|mistakes|habits|lessons|questions|moments|hacks|rules)\b`)
+	leadingNumeral    = regexp.MustCompile(`^\s*\d+`)
+	punctuationRun    = regexp.MustCompile(`[!?]{2,}`)
+)
+
+// Detector implements a weighted headline clickbait model
+type Detector struct {
+	weights   Weights
+	threshold float64
+}
+
+// Ensure Detector implements secondary.TitleAnalyzer
+var _ secondary.TitleAnalyzer = (*Detector)(nil)
+
+// NewDetector creates a clickbait detector with the default weights that
+// flags headlines whose clickbait probability reaches threshold
+func NewDetector(threshold float64) *Detector {
+	return NewDetectorWithWeights(DefaultWeights(), threshold)
+}
+
+// NewDetectorWithWeights creates a clickbait detector with custom weights
+func NewDetectorWithWeights(weights Weights, threshold float64) *Detector {
+	return &Detector{weights: weights, threshold: threshold}
+}
+
+// signal is a pattern that fired on a headline
+type signal struct {
+	name     string
+	weight   float64
+	evidence string
+}
+
+// AnalyzeTitle returns a CLICKBAIT flag when the headline's clickbait
+// probability reaches the detector's threshold. The flag details list every
+// pattern that fired and what matched.
+func (d *Detector) AnalyzeTitle(ctx context.Context, title string) ([]domain.Flag, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, nil
+	}
+
+	signals := d.signals(title)
+	score := d.weights.Bias
+	for _, s := range signals {
+		score += s.weight
+	}
+	probability := 1 / (1 + math.Exp(-score))
+	if probability < d.threshold {
+		return nil, nil
+	}
+
+	explanations := make([]string, len(signals))
+	for i, s := range signals {
+		explanations[i] = fmt.Sprintf("%s (%s)", s.name, s.evidence)
+	}
+
+	return []domain.Flag{{
+		Type:       domain.FlagTypeClickbait,
+		Confidence: probability,
+		Details:    fmt.Sprintf("clickbait probability %.2f: %s", probability, strings.Join(explanations, "; ")),
+		DetectedBy: DetectorName,
+	}}, nil
+}
+
+// signals evaluates every headline pattern and returns those that fired
+func (d *Detector) signals(title string) []signal {
+	var signals []signal
+	lower := strings.ToLower(strings.ReplaceAll(title, "’", "'"))
+
+	for _, phrase := range curiosityGapPhrases {
+		if strings.Contains(lower, phrase) {
+			signals = append(signals, signal{SignalCuriosityGap, d.weights.CuriosityGap, fmt.Sprintf("%q", phrase)})
+			break
+		}
+	}
+
+	if m := forwardRefPattern.FindString(title); m != "" {
+		signals = append(signals, signal{SignalForwardRef, d.weights.ForwardRef, fmt.Sprintf("starts with %q", m)})
+	}
+
+	if m := listiclePattern.FindString(title); m != "" {
+		signals = append(signals, signal{SignalListicle, d.weights.Listicle, fmt.Sprintf("%q", strings.TrimSpace(m))})
+	} else if m := leadingNumeral.FindString(title); m != "" {
+		signals = append(signals, signal{SignalLeadingNumeral, d.weights.LeadingNumber, fmt.Sprintf("starts with %q", strings.TrimSpace(m))})
+	}
+
+	marks := strings.Count(title, "!") + len(punctuationRun.FindAllString(title, -1))
+	if marks >= 2 {
+		signals = append(signals, signal{SignalPunctuation, d.weights.Punctuation, fmt.Sprintf("%d emphatic marks", marks)})
+	}
+
+	words := tokenize.Words(title)
+	var caps []string
+	for _, w := range words {
+		if isShouted(w.Text) {
+			caps = append(caps, w.Text)
+		}
+	}
+	// A single short all-caps word is usually an acronym; require either
+	// several shouted words or one long one
+	if len(caps) >= 2 || (len(caps) == 1 && len([]rune(caps[0])) >= 6) {
+		signals = append(signals, signal{SignalAllCaps, d.weights.AllCaps, strings.Join(caps, ", ")})
+	}
+
+	var pronouns, hyperbole []string
+	for _, w := range words {
+		word := tokenize.Normalize(w.Text)
+		if secondPersonWords[word] {
+			pronouns = append(pronouns, word)
+		}
+		if hyperboleWords[word] {
+			hyperbole = append(hyperbole, word)
+		}
+	}
+	if len(pronouns) > 0 {
+		signals = append(signals, signal{SignalSecondPerson, d.weights.SecondPerson, strings.Join(pronouns, ", ")})
+	}
+	if len(hyperbole) > 0 {
+		signals = append(signals, signal{SignalHyperbole, d.weights.Hyperbole, strings.Join(hyperbole, ", ")})
+	}
+
+	if strings.HasSuffix(title, "?") {
+		signals = append(signals, signal{SignalQuestion, d.weights.Question, "ends with '?'"})
+	}
+
+	return signals
+}
+
+// isShouted reports whether a word of three or more letters is written
+// entirely in upper case
+func isShouted(word string) bool {
+	letters := 0
+	for _, r := range word {
+		if unicode.IsLetter(r) {
+			if !unicode.IsUpper(r) {
+				return false
+			}
+			letters++
+		}
+	}
+	return letters >= 3
+}
@@ -0,0 +1,349 @@
+package composite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/internal/core/ports/secondary"
+)
+
+// Names of the ContentAnalyzer methods, as reported in Call and Stats
+const (
+	MethodAnalyzeSentiment = "AnalyzeSentiment"
+	MethodExtractEntities  = "ExtractEntities"
+	MethodDetectBias       = "DetectBias"
+)
+
+// neutralSentiment is returned when no child contributes to the sentiment
+const neutralSentiment = 0.5
+
+// Child is a named analyzer taking part in the composite
+type Child struct {
+	Name     string
+	Analyzer secondary.ContentAnalyzer
+	// SentimentWeight is the child's share of the combined sentiment score.
+	// Children with a zero weight are not asked for sentiment at all, which
+	// keeps detectors that only stub AnalyzeSentiment out of the average.
+	SentimentWeight float64
+}
+
+// Analyzer is a ContentAnalyzer that fans every call out to its children
+// concurrently and merges their results. A child that fails is recorded and
+// left out of the result; the call itself only fails when every child does.
+type Analyzer struct {
+	*recorder
+	children []Child
+}
+
+// Ensure Analyzer implements the ContentAnalyzer interface
+var _ secondary.ContentAnalyzer = (*Analyzer)(nil)
+
+// NewAnalyzer creates a composite over the given children. Child names must be
+// unique and non-empty, and sentiment weights must not be negative.
+func NewAnalyzer(children []Child, opts ...Option) (*Analyzer, error) {
+	if len(children) == 0 {
+		return nil, errors.New("composite analyzer needs at least one child")
+	}
+
+	names := make([]string, len(children))
+	for i, child := range children {
+		if child.Analyzer == nil {
+			return nil, fmt.Errorf("child %q has no analyzer", child.Name)
+		}
+		if child.SentimentWeight < 0 {
+			return nil, fmt.Errorf("child %q has a negative sentiment weight", child.Name)
+		}
+		names[i] = child.Name
+	}
+
+	recorder, err := newRecorder(names, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Analyzer{
+		recorder: recorder,
+		children: append([]Child(nil), children...),
+	}, nil
+}
+
+// AnalyzeSentiment returns the weighted mean of the sentiment scores of the
+// children with a positive weight, or a neutral score if there are none
+func (a *Analyzer) AnalyzeSentiment(ctx context.Context, text string) (float64, error) {
+	var weighted []Child
+	for _, child := range a.children {
+		if child.SentimentWeight > 0 {
+			weighted = append(weighted, child)
+		}
+	}
+	if len(weighted) == 0 {
+		return neutralSentiment, nil
+	}
+
+	results, err := fanOut(ctx, a, MethodAnalyzeSentiment, weighted,
+		func(ctx context.Context, child Child) (float64, error) {
+			return child.Analyzer.AnalyzeSentiment(ctx, text)
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	var sum, total float64
+	for i, result := range results {
+		if !result.ok {
+			continue
+		}
+		sum += result.value * weighted[i].SentimentWeight
+		total += weighted[i].SentimentWeight
+	}
+	return sum / total, nil
+}
+
+// ExtractEntities returns the union of the children's entities. Entities of
+// the same type whose values match case-insensitively are merged, keeping
+// every distinct mention.
+func (a *Analyzer) ExtractEntities(ctx context.Context, text string) ([]domain.Entity, error) {
+	results, err := fanOut(ctx, a, MethodExtractEntities, a.children,
+		func(ctx context.Context, child Child) ([]domain.Entity, error) {
+			return child.Analyzer.ExtractEntities(ctx, text)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	var lists [][]domain.Entity
+	for _, result := range results {
+		if result.ok {
+			lists = append(lists, result.value)
+		}
+	}
+	return mergeEntities(lists), nil
+}
+
+// DetectBias returns the flags raised by the children. Flags of the same type
+// are merged into one whose confidence is the noisy-OR of the children's
+// strongest flags of that type.
+func (a *Analyzer) DetectBias(ctx context.Context, text string) ([]domain.Flag, error) {
+	results, err := fanOut(ctx, a, MethodDetectBias, a.children,
+		func(ctx context.Context, child Child) ([]domain.Flag, error) {
+			return child.Analyzer.DetectBias(ctx, text)
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	var lists [][]domain.Flag
+	for i, result := range results {
+		if !result.ok {
+			continue
+		}
+		flags := make([]domain.Flag, len(result.value))
+		for j, flag := range result.value {
+			if flag.DetectedBy == "" {
+				flag.DetectedBy = a.children[i].Name
+			}
+			flags[j] = flag
+		}
+		lists = append(lists, flags)
+	}
+	return mergeFlags(lists), nil
+}
+
+// outcome is the result of one child call
+type outcome[T any] struct {
+	value T
+	ok    bool
+}
+
+// fanOut calls every child concurrently and returns their outcomes in child
+// order. It fails only if the context is done or every child failed.
+func fanOut[T any](ctx context.Context, a *Analyzer, method string, children []Child,
+	call func(context.Context, Child) (T, error)) ([]outcome[T], error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	results := make([]outcome[T], len(children))
+	errs := make([]error, len(children))
+
+	var wg sync.WaitGroup
+	for i, child := range children {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			start := time.Now()
+			value, err := safeCall(ctx, child, call)
+			a.record(Call{
+				Child:    child.Name,
+				Method:   method,
+				Duration: time.Since(start),
+				Err:      err,
+			})
+
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", child.Name, err)
+				return
+			}
+			results[i] = outcome[T]{value: value, ok: true}
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		if result.ok {
+			return results, nil
+		}
+	}
+	return nil, fmt.Errorf("all analyzers failed to %s: %w", method, errors.Join(errs...))
+}
+
+// safeCall invokes a child and turns a panic into an error, so that one
+// misbehaving child cannot take down the others
+func safeCall[C, T any](ctx context.Context, child C,
+	call func(context.Context, C) (T, error)) (value T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return call(ctx, child)
+}
+
+// mergeEntities unions entity lists, keeping entities in order of first
+// appearance
+func mergeEntities(lists [][]domain.Entity) []domain.Entity {
+	var merged []domain.Entity
+	index := make(map[string]int)
+
+	for _, entities := range lists {
+		for _, entity := range entities {
+			key := string(entity.Type) + "\x00" + strings.ToLower(entity.Value)
+			i, exists := index[key]
+			if !exists {
+				index[key] = len(merged)
+				entity.Mentions = append([]domain.Span(nil), entity.Mentions...)
+				merged = append(merged, entity)
+				continue
+			}
+
+			target := &merged[i]
+			if target.Normalized == "" {
+				target.Normalized = entity.Normalized
+			}
+			if entity.Count > target.Count {
+				target.Count = entity.Count
+			}
+			target.Mentions = append(target.Mentions, entity.Mentions...)
+		}
+	}
+
+	for i := range merged {
+		merged[i].Mentions = uniqueSpans(merged[i].Mentions)
+		if len(merged[i].Mentions) > merged[i].Count {
+			merged[i].Count = len(merged[i].Mentions)
+		}
+	}
+	return merged
+}
+
+// uniqueSpans sorts spans by position and drops duplicates
+func uniqueSpans(spans []domain.Span) []domain.Span {
+	if len(spans) < 2 {
+		return spans
+	}
+	sort.Slice(spans, func(i, j int) bool {
+		if spans[i].Start != spans[j].Start {
+			return spans[i].Start < spans[j].Start
+		}
+		return spans[i].End < spans[j].End
+	})
+
+	unique := spans[:1]
+	for _, span := range spans[1:] {
+		if span != unique[len(unique)-1] {
+			unique = append(unique, span)
+		}
+	}
+	return unique
+}
+
+// mergeFlags combines the flags of the same type from the children's flag
+// lists, keeping types in order of first appearance. Several flags of one
+// type from the same child, such as one per category of bias, are one piece
+// of evidence: the child counts with its strongest flag, and only flags of
+// different children are combined by noisy-OR. The details of every flag are
+// kept. A type raised only once is passed through unchanged.
+func mergeFlags(lists [][]domain.Flag) []domain.Flag {
+	var order []domain.FlagType
+	groups := make(map[domain.FlagType][]domain.Flag)
+	strongest := make(map[domain.FlagType][]float64) // by child, for each type
+	for _, flags := range lists {
+		child := make(map[domain.FlagType]float64)
+		for _, flag := range flags {
+			if _, exists := groups[flag.Type]; !exists {
+				order = append(order, flag.Type)
+			}
+			groups[flag.Type] = append(groups[flag.Type], flag)
+			child[flag.Type] = max(child[flag.Type], clamp(flag.Confidence))
+		}
+		for flagType, confidence := range child {
+			strongest[flagType] = append(strongest[flagType], confidence)
+		}
+	}
+
+	merged := make([]domain.Flag, 0, len(order))
+	for _, flagType := range order {
+		group := groups[flagType]
+		if len(group) == 1 {
+			merged = append(merged, group[0])
+			continue
+		}
+
+		miss := 1.0
+		for _, confidence := range strongest[flagType] {
+			miss *= 1 - confidence
+		}
+		var details, detectors []string
+		seen := make(map[string]bool)
+		var detectedAt time.Time
+		for _, flag := range group {
+			details = append(details, fmt.Sprintf("[%s] %s", flag.DetectedBy, flag.Details))
+			if !seen[flag.DetectedBy] {
+				seen[flag.DetectedBy] = true
+				detectors = append(detectors, flag.DetectedBy)
+			}
+			if flag.DetectedAt.After(detectedAt) {
+				detectedAt = flag.DetectedAt
+			}
+		}
+
+		merged = append(merged, domain.Flag{
+			Type:       flagType,
+			Confidence: 1 - miss,
+			Details:    strings.Join(details, "; "),
+			DetectedAt: detectedAt,
+			DetectedBy: strings.Join(detectors, ","),
+		})
+	}
+	return merged
+}
+
+// clamp limits a confidence to [0, 1]
+func clamp(confidence float64) float64 {
+	if confidence < 0 {
+		return 0
+	}
+	if confidence > 1 {
+		return 1
+	}
+	return confidence
+}
@@ -0,0 +1,159 @@
+package composite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/internal/core/ports/secondary"
+)
+
+// Names of the FactChecker methods, as reported in Call and Stats
+const (
+	MethodCheckFacts          = "CheckFacts"
+	MethodGetSourceReputation = "GetSourceReputation"
+)
+
+// FactCheckerChild is a fact checker combined by a FactChecker
+type FactCheckerChild struct {
+	Name    string
+	Checker secondary.FactChecker
+	// ReputationWeight is the child's weight in the source reputation. Children
+	// with weight zero are not asked for reputations.
+	ReputationWeight float64
+}
+
+// FactChecker is a FactChecker that runs several fact checkers and combines
+// their results: flags are concatenated and source reputations are the
+// weighted mean of the children's. Children that do not know a source are
+// left out of its mean.
+//
+// Children check an article one after another, in order, because they record
+// verdicts on the article's claims: a claim keeps the verdict of the first
+// child that checked it. A failing child is skipped, and a call only fails
+// when every child fails.
+type FactChecker struct {
+	*recorder
+	children []FactCheckerChild
+}
+
+// Ensure FactChecker implements the FactChecker interface
+var _ secondary.FactChecker = (*FactChecker)(nil)
+
+// NewFactChecker creates a fact checker over the children. Names must be
+// unique, as they label the statistics and the flags of children that do not
+// set DetectedBy.
+func NewFactChecker(children []FactCheckerChild, opts ...Option) (*FactChecker, error) {
+	if len(children) == 0 {
+		return nil, errors.New("at least one child fact checker is required")
+	}
+
+	names := make([]string, len(children))
+	for i, child := range children {
+		if child.Checker == nil {
+			return nil, fmt.Errorf("child %q has no fact checker", child.Name)
+		}
+		if child.ReputationWeight < 0 {
+			return nil, fmt.Errorf("child %q has a negative reputation weight", child.Name)
+		}
+		names[i] = child.Name
+	}
+
+	recorder, err := newRecorder(names, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &FactChecker{
+		recorder: recorder,
+		children: append([]FactCheckerChild(nil), children...),
+	}, nil
+}
+
+// CheckFacts runs every child on the article
+func (f *FactChecker) CheckFacts(ctx context.Context, article *domain.Article) ([]domain.Flag, error) {
+	var flags []domain.Flag
+	var errs []error
+	for _, child := range f.children {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		start := time.Now()
+		childFlags, err := safeCall(ctx, child, func(ctx context.Context, child FactCheckerChild) ([]domain.Flag, error) {
+			return child.Checker.CheckFacts(ctx, article)
+		})
+		f.record(Call{
+			Child:    child.Name,
+			Method:   MethodCheckFacts,
+			Duration: time.Since(start),
+			Err:      err,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", child.Name, err))
+			continue
+		}
+
+		for _, flag := range childFlags {
+			if flag.DetectedBy == "" {
+				flag.DetectedBy = child.Name
+			}
+			flags = append(flags, flag)
+		}
+	}
+
+	if len(errs) == len(f.children) {
+		return nil, fmt.Errorf("all fact checkers failed to %s: %w", MethodCheckFacts, errors.Join(errs...))
+	}
+	return flags, nil
+}
+
+// GetSourceReputation returns the weighted mean reputation over the children
+// with a positive reputation weight that know the source, or
+// ErrUnknownSource if none does
+func (f *FactChecker) GetSourceReputation(ctx context.Context, source string) (float64, error) {
+	var sum, total float64
+	var errs []error
+	for _, child := range f.children {
+		if child.ReputationWeight == 0 {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		start := time.Now()
+		reputation, err := safeCall(ctx, child, func(ctx context.Context, child FactCheckerChild) (float64, error) {
+			return child.Checker.GetSourceReputation(ctx, source)
+		})
+		unknown := errors.Is(err, secondary.ErrUnknownSource)
+		if unknown {
+			err = nil
+		}
+		f.record(Call{
+			Child:    child.Name,
+			Method:   MethodGetSourceReputation,
+			Duration: time.Since(start),
+			Err:      err,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", child.Name, err))
+			continue
+		}
+		if unknown {
+			continue
+		}
+
+		sum += child.ReputationWeight * clamp(reputation)
+		total += child.ReputationWeight
+	}
+
+	switch {
+	case total > 0:
+		return sum / total, nil
+	case len(errs) > 0:
+		return 0, fmt.Errorf("all fact checkers failed to %s: %w", MethodGetSourceReputation, errors.Join(errs...))
+	}
+	return 0, secondary.ErrUnknownSource
+}
@@ -0,0 +1,117 @@
+package composite
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Call describes a single call made to a child
+type Call struct {
+	Child    string
+	Method   string
+	Duration time.Duration
+	Err      error
+}
+
+// Stats summarises the calls made to one child since the composite was created
+type Stats struct {
+	Child         string
+	Calls         int
+	Errors        int
+	TotalDuration time.Duration
+	MaxDuration   time.Duration
+	LastError     string
+	LastErrorAt   time.Time
+}
+
+// AverageDuration returns the mean duration of the child's calls
+func (s Stats) AverageDuration() time.Duration {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.TotalDuration / time.Duration(s.Calls)
+}
+
+// options holds the settings shared by the composites
+type options struct {
+	observe func(Call)
+}
+
+// Option configures a composite
+type Option func(*options)
+
+// WithObserver registers a function that is called after every child call,
+// for example to log slow or failing children. It may be called concurrently.
+func WithObserver(observe func(Call)) Option {
+	return func(o *options) {
+		o.observe = observe
+	}
+}
+
+// recorder keeps the per-child call statistics of a composite
+type recorder struct {
+	names   []string
+	observe func(Call)
+
+	mu    sync.Mutex
+	stats map[string]*Stats
+}
+
+// newRecorder creates a recorder for children with the given names, which
+// must be unique and non-empty
+func newRecorder(names []string, opts []Option) (*recorder, error) {
+	r := &recorder{
+		names: names,
+		stats: make(map[string]*Stats, len(names)),
+	}
+	for i, name := range names {
+		if name == "" {
+			return nil, fmt.Errorf("child %d has no name", i)
+		}
+		if _, exists := r.stats[name]; exists {
+			return nil, fmt.Errorf("duplicate child name %q", name)
+		}
+		r.stats[name] = &Stats{Child: name}
+	}
+
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	r.observe = o.observe
+	return r, nil
+}
+
+// Stats returns a snapshot of the per-child call statistics, in child order
+func (r *recorder) Stats() []Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := make([]Stats, len(r.names))
+	for i, name := range r.names {
+		stats[i] = *r.stats[name]
+	}
+	return stats
+}
+
+// record updates the child's statistics and notifies the observer
+func (r *recorder) record(call Call) {
+	r.mu.Lock()
+	stats := r.stats[call.Child]
+	stats.Calls++
+	stats.TotalDuration += call.Duration
+	if call.Duration > stats.MaxDuration {
+		stats.MaxDuration = call.Duration
+	}
+	if call.Err != nil {
+		stats.Errors++
+		stats.LastError = call.Err.Error()
+		stats.LastErrorAt = time.Now()
+	}
+	r.mu.Unlock()
+
+	if r.observe != nil {
+		r.observe(call)
+	}
+}
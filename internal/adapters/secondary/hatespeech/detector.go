@@ -0,0 +1,303 @@
+package hatespeech
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/internal/core/ports/secondary"
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+//go:embed terms/default.txt
+var defaultTerms []byte
+
+const (
+	// DetectorName identifies flags raised by the hate-speech detector
+	DetectorName = "hate_speech_detector"
+	// DefaultThreshold is the category confidence at which a flag is raised
+	DefaultThreshold = 0.5
+)
+
+// reportingCues introduce or follow a clause that reports or condemns
+// someone else's words rather than uses them
+var reportingCues = map[string]bool{
+	"said": true, "says": true, "called": true, "calling": true, "described": true,
+	"referred": true, "quoted": true, "accused": true, "condemned": true,
+	"denounced": true, "criticized": true, "criticised": true, "slur": true,
+	"slurs": true, "chanted": true, "chanting": true, "wrote": true, "posted": true,
+	"tweeted": true, "shouted": true, "alleged": true, "allegedly": true,
+}
+
+// quotePairs are the opening and closing quotation marks recognised
+var quotePairs = map[rune]rune{
+	'"': '"', '“': '”', '„': '“', '«': '»', '‘': '’',
+}
+
+// Detector implements term-list based hate-speech detection that is robust to
+// common obfuscations
+type Detector struct {
+	terms     *TermList
+	threshold float64
+}
+
+// Ensure Detector implements secondary.ContentAnalyzer
+var _ secondary.ContentAnalyzer = (*Detector)(nil)
+
+// NewDetector creates a hate-speech detector using the built-in term list
+// extended with the term files at the given paths
+func NewDetector(threshold float64, termPaths ...string) (*Detector, error) {
+	terms := NewTermList()
+	if err := terms.Parse(bytes.NewReader(defaultTerms)); err != nil {
+		return nil, fmt.Errorf("failed to load default hate-speech terms: %w", err)
+	}
+
+	for _, path := range termPaths {
+		if path == "" {
+			continue
+		}
+		extra, err := LoadTermFile(path)
+		if err != nil {
+			return nil, err
+		}
+		terms.Merge(extra)
+	}
+
+	return NewDetectorWithTerms(terms, threshold), nil
+}
+
+// NewDetectorWithTerms creates a hate-speech detector using only the given
+// term list
+func NewDetectorWithTerms(terms *TermList, threshold float64) *Detector {
+	return &Detector{terms: terms, threshold: threshold}
+}
+
+// hit is a term found in the text
+type hit struct {
+	term     term
+	start    int
+	end      int
+	reported bool
+}
+
+// DetectBias returns one HATE_SPEECH flag per term category with enough
+// unquoted matches, at least one of them of medium or high severity.
+// Matches inside quotation marks or in clauses that report someone else's
+// words are listed in the details but do not add to the confidence.
+func (d *Detector) DetectBias(ctx context.Context, text string) ([]domain.Flag, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	hits := d.findTerms(text)
+	if len(hits) == 0 {
+		return nil, nil
+	}
+	markReported(text, hits)
+
+	byCategory := make(map[string][]hit)
+	for _, h := range hits {
+		byCategory[h.term.category] = append(byCategory[h.term.category], h)
+	}
+	categories := make([]string, 0, len(byCategory))
+	for c := range byCategory {
+		categories = append(categories, c)
+	}
+	sort.Strings(categories)
+
+	var flags []domain.Flag
+	for _, category := range categories {
+		categoryHits := byCategory[category]
+
+		// Noisy-OR over the unquoted matches
+		notHate := 1.0
+		var used, reported []string
+		maxSeverity := SeverityLow
+		for _, h := range categoryHits {
+			if h.reported {
+				reported = append(reported, h.term.phrase)
+				continue
+			}
+			notHate *= 1 - probability[h.term.severity]
+			used = append(used, h.term.phrase)
+			if probability[h.term.severity] > probability[maxSeverity] {
+				maxSeverity = h.term.severity
+			}
+		}
+		confidence := 1 - notHate
+		if len(used) == 0 || maxSeverity == SeverityLow || confidence < d.threshold {
+			continue
+		}
+
+		details := fmt.Sprintf("category %s (max severity %s): %s",
+			strings.ReplaceAll(category, "_", " "), maxSeverity, summarize(used))
+		if len(reported) > 0 {
+			details += fmt.Sprintf("; %d match(es) in quoted or reported speech ignored", len(reported))
+		}
+		flags = append(flags, domain.Flag{
+			Type:       domain.FlagTypeHateSpeech,
+			Confidence: confidence,
+			Details:    details,
+			DetectedBy: DetectorName,
+		})
+	}
+	return flags, nil
+}
+
+// AnalyzeSentiment is not supported by the hate-speech detector
+func (d *Detector) AnalyzeSentiment(ctx context.Context, text string) (float64, error) {
+	return 0.5, nil
+}
+
+// ExtractEntities is not supported by the hate-speech detector
+func (d *Detector) ExtractEntities(ctx context.Context, text string) ([]domain.Entity, error) {
+	return nil, nil
+}
+
+// findTerms returns the longest term match starting at each normalized word
+func (d *Detector) findTerms(text string) []hit {
+	words := normalizeText(text)
+	normalized := make([]string, len(words))
+	for i, w := range words {
+		normalized[i] = w.text
+	}
+
+	var hits []hit
+	for i := 0; i < len(words); {
+		matched := 1
+		for n := min(d.terms.maxLen, len(words)-i); n > 0; n-- {
+			if t, ok := d.terms.terms[joinWords(normalized[i:i+n])]; ok {
+				hits = append(hits, hit{term: t, start: words[i].start, end: words[i+n-1].end})
+				matched = n
+				break
+			}
+		}
+		i += matched
+	}
+	return hits
+}
+
+// markReported flags hits that appear inside quotation marks or in a
+// reported clause: after a reporting cue, as in "he said they were vermin",
+// before an attribution, as in "they are vermin, he said", or right before
+// a cue, as in "the vermin slur"
+func markReported(text string, hits []hit) {
+	quotes := quoteSpans(text)
+	sentences := tokenize.Sentences(text)
+
+	for i := range hits {
+		for _, q := range quotes {
+			if hits[i].start >= q[0] && hits[i].end <= q[1] {
+				hits[i].reported = true
+				break
+			}
+		}
+		if hits[i].reported {
+			continue
+		}
+		for _, s := range sentences {
+			if hits[i].start < s.Start || hits[i].end > s.End {
+				continue
+			}
+			for _, w := range tokenize.Words(s.Text) {
+				if !reportingCues[tokenize.Normalize(w.Text)] {
+					continue
+				}
+				if inReportedClause(text, hits[i], s.Start+w.Start, s.Start+w.End) {
+					hits[i].reported = true
+					break
+				}
+			}
+			break
+		}
+	}
+}
+
+// maxAttributionWords bounds the words between the comma and the cue of an
+// attribution that follows the reported clause, as in ", the minister said"
+const maxAttributionWords = 3
+
+// inReportedClause reports whether a hit is part of the clause a reporting
+// cue at the given byte range refers to. Clauses end at semicolons.
+func inReportedClause(text string, h hit, cueStart, cueEnd int) bool {
+	if h.start >= cueEnd {
+		return !strings.Contains(text[cueEnd:h.start], ";")
+	}
+	if h.end > cueStart {
+		return false
+	}
+	between := text[h.end:cueStart]
+	if strings.Contains(between, ";") {
+		return false
+	}
+	if len(tokenize.Words(between)) == 0 {
+		return true
+	}
+	comma := strings.LastIndex(between, ",")
+	if comma < 0 {
+		return false
+	}
+	attribution := tokenize.Words(between[comma:])
+	if len(attribution) > maxAttributionWords {
+		return false
+	}
+	for _, w := range attribution {
+		if conjunctions[tokenize.Normalize(w.Text)] {
+			// A clause of its own, as in "..., and I said it before"
+			return false
+		}
+	}
+	return true
+}
+
+// conjunctions join clauses of the writer's own
+var conjunctions = map[string]bool{
+	"and": true, "but": true, "or": true, "so": true, "as": true, "because": true, "while": true,
+}
+
+// quoteSpans returns the byte ranges enclosed in quotation marks
+func quoteSpans(text string) [][2]int {
+	var spans [][2]int
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		closer, ok := quotePairs[r]
+		if !ok {
+			i += size
+			continue
+		}
+		end := strings.IndexRune(text[i+size:], closer)
+		if end < 0 {
+			i += size
+			continue
+		}
+		closeAt := i + size + end
+		spans = append(spans, [2]int{i, closeAt + utf8.RuneLen(closer)})
+		i = closeAt + utf8.RuneLen(closer)
+	}
+	return spans
+}
+
+// summarize lists distinct phrases with their counts
+func summarize(phrases []string) string {
+	counts := make(map[string]int)
+	var order []string
+	for _, p := range phrases {
+		if counts[p] == 0 {
+			order = append(order, p)
+		}
+		counts[p]++
+	}
+	parts := make([]string, len(order))
+	for i, p := range order {
+		parts[i] = fmt.Sprintf("%q", p)
+		if counts[p] > 1 {
+			parts[i] += fmt.Sprintf(" x%d", counts[p])
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,172 @@
+package hatespeech
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// homoglyphs maps look-alike characters from other scripts to Latin letters
+var homoglyphs = map[rune]rune{
+	// Cyrillic
+	'а': 'a', 'в': 'b', 'е': 'e', 'ё': 'e', 'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o',
+	'р': 'p', 'с': 'c', 'т': 't', 'у': 'y', 'х': 'x', 'і': 'i', 'ј': 'j', 'ѕ': 's',
+	'ԁ': 'd', 'ɡ': 'g', 'ո': 'n', 'ս': 'u',
+	// Greek
+	'α': 'a', 'β': 'b', 'ε': 'e', 'η': 'n', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o',
+	'ρ': 'p', 'τ': 't', 'υ': 'u', 'χ': 'x',
+	// Latin look-alikes
+	'ı': 'i', 'ł': 'l', 'ø': 'o', 'ß': 's', 'ſ': 's',
+}
+
+// leet maps digits and symbols commonly substituted for letters
+var leet = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b', '9': 'g',
+	'@': 'a', '$': 's', '!': 'i', '|': 'l', '+': 't', '€': 'e',
+}
+
+// separators are characters inserted between letters to dodge filters
+const separators = ".-_*~'`\"·•"
+
+// word is a normalized word with the byte range it came from
+type word struct {
+	text  string
+	start int
+	end   int
+}
+
+// normalizeText splits text into words and undoes common obfuscations:
+// homoglyphs and full-width forms, leetspeak, punctuation inserted between
+// letters ("v.e.r.m.i.n"), letters spelled out with spaces ("v e r m i n")
+// and long runs of a repeated letter
+func normalizeText(text string) []word {
+	var words []word
+	start := -1
+	flush := func(end int) {
+		if start >= 0 {
+			if w := normalizeWord(text[start:end]); w != "" {
+				words = append(words, word{text: w, start: start, end: end})
+			}
+			start = -1
+		}
+	}
+
+	for i, r := range text {
+		if unicode.IsSpace(r) || breaksWord(text, i, r) {
+			flush(i)
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	flush(len(text))
+
+	return joinSpelledOut(words)
+}
+
+// normalizeWord maps a raw word to plain lower-case Latin letters
+func normalizeWord(raw string) string {
+	hasLetter := false
+	for _, r := range raw {
+		if unicode.IsLetter(r) {
+			hasLetter = true
+			break
+		}
+	}
+
+	var b strings.Builder
+	var prev rune
+	repeat := 0
+	for _, r := range strings.ToLower(raw) {
+		// Full-width ASCII variants
+		if r >= 0xFF01 && r <= 0xFF5E {
+			r -= 0xFEE0
+		}
+		if mapped, ok := homoglyphs[r]; ok {
+			r = mapped
+		}
+		if hasLetter {
+			if mapped, ok := leet[r]; ok {
+				r = mapped
+			}
+		}
+		if strings.ContainsRune(separators, r) || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			continue
+		}
+		if r == prev {
+			repeat++
+			if repeat >= 2 {
+				continue
+			}
+		} else {
+			repeat = 0
+		}
+		prev = r
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// joinSpelledOut merges runs of three or more single-letter words, which is
+// how "v e r m i n" evades word matching
+func joinSpelledOut(words []word) []word {
+	var joined []word
+	for i := 0; i < len(words); {
+		j := i
+		for j < len(words) && len([]rune(words[j].text)) == 1 {
+			j++
+		}
+		if j-i >= 3 {
+			var b strings.Builder
+			for _, w := range words[i:j] {
+				b.WriteString(w.text)
+			}
+			joined = append(joined, word{text: b.String(), start: words[i].start, end: words[j-1].end})
+			i = j
+			continue
+		}
+		joined = append(joined, words[i])
+		i++
+	}
+	return joined
+}
+
+// normalizeWords normalizes a term list phrase into words
+func normalizeWords(phrase string) []string {
+	var words []string
+	for _, w := range normalizeText(phrase) {
+		words = append(words, w.text)
+	}
+	return words
+}
+
+func joinWords(words []string) string {
+	return strings.Join(words, " ")
+}
+
+// breaksWord reports whether the rune r at byte i ends the current word.
+// Separators and leetspeak symbols only count as part of a word when they
+// sit between two word characters, as in "v.e.r.m.i.n" or "h@te"; other
+// punctuation always ends a word.
+func breaksWord(text string, i int, r rune) bool {
+	if !unicode.IsPunct(r) && !unicode.IsSymbol(r) {
+		return false
+	}
+	if !strings.ContainsRune(separators, r) {
+		if _, ok := leet[r]; !ok {
+			return true
+		}
+	}
+	prev, _ := utf8.DecodeLastRuneInString(text[:i])
+	next, _ := utf8.DecodeRuneInString(text[i+utf8.RuneLen(r):])
+	return !isWordChar(prev) || !(unicode.IsLetter(next) || unicode.IsDigit(next))
+}
+
+func isWordChar(r rune) bool {
+	if unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune(separators, r) {
+		return true
+	}
+	_, ok := leet[r]
+	return ok
+}
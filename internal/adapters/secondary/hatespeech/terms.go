@@ -0,0 +1,118 @@
+package hatespeech
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Severity grades how harmful a term is
+type Severity string
+
+const (
+	SeverityLow    Severity = "low"
+	SeverityMedium Severity = "medium"
+	SeverityHigh   Severity = "high"
+)
+
+// probability is the chance that a single unquoted match of a term with the
+// given severity is hate speech. With the default threshold one high-severity
+// match is enough to flag, while medium terms need corroboration. Low terms,
+// words such as "animals" with everyday meanings, only add to a category
+// that also has a medium or high match.
+var probability = map[Severity]float64{
+	SeverityLow:    0.25,
+	SeverityMedium: 0.45,
+	SeverityHigh:   0.85,
+}
+
+// TermList holds hate-speech terms grouped by category
+type TermList struct {
+	terms  map[string]term
+	maxLen int
+}
+
+// term is a single entry of the term list
+type term struct {
+	phrase   string
+	category string
+	severity Severity
+}
+
+// NewTermList creates an empty term list
+func NewTermList() *TermList {
+	return &TermList{terms: make(map[string]term)}
+}
+
+// LoadTermFile reads a term list from a file on disk
+func LoadTermFile(path string) (*TermList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open hate-speech terms: %w", err)
+	}
+	defer f.Close()
+
+	terms := NewTermList()
+	if err := terms.Parse(f); err != nil {
+		return nil, fmt.Errorf("failed to parse hate-speech terms %s: %w", path, err)
+	}
+	return terms, nil
+}
+
+// Parse reads terms in the sectioned text format, where each section names a
+// category and each entry is a phrase and a severity separated by a tab
+func (l *TermList) Parse(r io.Reader) error {
+	category := ""
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			category = strings.ToLower(strings.Trim(line, "[]"))
+			continue
+		}
+		if category == "" {
+			return fmt.Errorf("line %d: entry outside of a category", lineNo)
+		}
+
+		idx := strings.LastIndex(line, "\t")
+		if idx < 0 {
+			return fmt.Errorf("line %d: expected phrase and severity", lineNo)
+		}
+		severity := Severity(strings.ToLower(strings.TrimSpace(line[idx+1:])))
+		if _, ok := probability[severity]; !ok {
+			return fmt.Errorf("line %d: unknown severity %q", lineNo, severity)
+		}
+		l.Add(category, strings.TrimSpace(line[:idx]), severity)
+	}
+	return scanner.Err()
+}
+
+// Add registers a term, replacing any existing entry for the same phrase
+func (l *TermList) Add(category, phrase string, severity Severity) {
+	words := normalizeWords(phrase)
+	if len(words) == 0 {
+		return
+	}
+	key := joinWords(words)
+	l.terms[key] = term{phrase: phrase, category: category, severity: severity}
+	if len(words) > l.maxLen {
+		l.maxLen = len(words)
+	}
+}
+
+// Merge copies every entry of other into the term list
+func (l *TermList) Merge(other *TermList) {
+	for key, t := range other.terms {
+		l.terms[key] = t
+	}
+	if other.maxLen > l.maxLen {
+		l.maxLen = other.maxLen
+	}
+}
@@ -0,0 +1,436 @@
+package headline
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/internal/core/ports/secondary"
+	"github.com/reality-filter/pkg/sanitize"
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+const (
+	// CheckerName identifies flags raised by the headline checker
+	CheckerName = "headline_checker"
+	// DefaultThreshold is the misleading probability at which a headline is
+	// flagged
+	DefaultThreshold = 0.5
+)
+
+// Probabilities that a single mismatch means the headline is misleading.
+// They are combined with a noisy-OR. A missing entity or figure alone stays
+// below the default threshold, since a headline may name what the body only
+// implies; it takes a second mismatch to flag.
+const (
+	missingEntityProbability = 0.35
+	missingNumberProbability = 0.4
+	negationProbability      = 0.7
+	// maxCoverageProbability is reached when none of the headline's key
+	// terms appear in the body
+	maxCoverageProbability = 0.6
+)
+
+const (
+	// minKeyTerms is the number of key terms a headline needs before its
+	// coverage is judged
+	minKeyTerms = 3
+	// minCoverage is the share of headline key terms the body must contain
+	minCoverage = 0.5
+	// leadSentences is how many body sentences are searched for the
+	// statement a headline summarises
+	leadSentences = 5
+	// maxLabelWords bounds the words of a label before a colon that opens a
+	// headline, as in "Budget 2025: council approves spending plan"
+	maxLabelWords = 3
+)
+
+// EntityExtractor finds named entities in a headline
+type EntityExtractor interface {
+	ExtractEntities(ctx context.Context, text string) ([]domain.Entity, error)
+}
+
+var (
+	numberPattern = regexp.MustCompile(`\d+(?:[.,]\d+)*\s*(?:%|percent\b|per cent\b)?`)
+
+	negations = map[string]bool{
+		"not": true, "no": true, "never": true, "none": true, "nobody": true,
+		"nothing": true, "neither": true, "nor": true, "without": true,
+		"isn't": true, "aren't": true, "wasn't": true, "weren't": true,
+		"won't": true, "wouldn't": true, "don't": true, "doesn't": true,
+		"didn't": true, "can't": true, "cannot": true, "couldn't": true,
+		"shouldn't": true, "hasn't": true, "haven't": true, "hadn't": true,
+		"denies": true, "denied": true, "rejects": true, "rejected": true,
+	}
+
+	stopwords = map[string]bool{
+		"a": true, "an": true, "the": true, "and": true, "or": true, "but": true,
+		"of": true, "to": true, "in": true, "on": true, "at": true, "for": true,
+		"with": true, "by": true, "from": true, "as": true, "is": true, "are": true,
+		"was": true, "were": true, "be": true, "been": true, "it": true, "its": true,
+		"this": true, "that": true, "these": true, "those": true, "after": true,
+		"before": true, "over": true, "into": true, "about": true, "up": true,
+		"out": true, "new": true, "says": true, "said": true, "how": true,
+		"why": true, "what": true, "who": true, "will": true, "could": true,
+		"would": true, "can": true, "may": true, "has": true, "have": true,
+		"had": true, "his": true, "her": true, "their": true, "our": true,
+		"your": true, "you": true, "we": true, "they": true, "he": true, "she": true,
+	}
+)
+
+// Checker implements secondary.ConsistencyChecker by comparing an article's
+// headline with its body
+type Checker struct {
+	entities  EntityExtractor
+	threshold float64
+}
+
+// Ensure Checker implements secondary.ConsistencyChecker
+var _ secondary.ConsistencyChecker = (*Checker)(nil)
+
+// NewChecker creates a headline checker that finds headline entities with
+// the given extractor and flags headlines whose misleading probability
+// reaches threshold
+func NewChecker(entities EntityExtractor, threshold float64) *Checker {
+	return &Checker{entities: entities, threshold: threshold}
+}
+
+// mismatch is a headline assertion the body does not support
+type mismatch struct {
+	probability float64
+	reason      string
+}
+
+// CheckConsistency returns a MISLEADING flag when the headline names people,
+// organizations or figures the body never mentions, shares too few key
+// terms with the body, or negates the body statement it summarises. Key-term
+// and negation checks are only applied to English articles.
+func (c *Checker) CheckConsistency(ctx context.Context, article *domain.Article) ([]domain.Flag, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	title := strings.TrimSpace(sanitize.Sanitize(article.Title).Text)
+	body := article.AnalyzedText()
+	if title == "" || strings.TrimSpace(body) == "" {
+		return nil, nil
+	}
+
+	var mismatches []mismatch
+
+	claim := withoutLabel(title)
+	titleEntities, err := c.entities.ExtractEntities(ctx, claim)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract headline entities: %w", err)
+	}
+	mismatches = append(mismatches, missingEntities(titleEntities, article.MetaData.Entities, body)...)
+	mismatches = append(mismatches, missingNumbers(claim, body)...)
+
+	if language := article.MetaData.Language; language == "" || language == "en" {
+		titleTerms := keyTerms(title)
+		if m, ok := lowCoverage(titleTerms, body); ok {
+			mismatches = append(mismatches, m)
+		}
+		if m, ok := negationConflict(title, titleTerms, body); ok {
+			mismatches = append(mismatches, m)
+		}
+	}
+
+	if len(mismatches) == 0 {
+		return nil, nil
+	}
+
+	notMisleading := 1.0
+	reasons := make([]string, len(mismatches))
+	for i, m := range mismatches {
+		notMisleading *= 1 - m.probability
+		reasons[i] = m.reason
+	}
+	confidence := 1 - notMisleading
+	if confidence < c.threshold {
+		return nil, nil
+	}
+
+	return []domain.Flag{{
+		Type:       domain.FlagTypeMisleading,
+		Confidence: confidence,
+		Details: fmt.Sprintf("headline not supported by body (probability %.2f): %s",
+			confidence, strings.Join(reasons, "; ")),
+		DetectedBy: CheckerName,
+	}}, nil
+}
+
+// missingEntities reports headline people, organizations, places and
+// products that the body never mentions, by name or by any part of the name
+func missingEntities(titleEntities, bodyEntities []domain.Entity, body string) []mismatch {
+	known := make(map[string]bool)
+	for _, e := range bodyEntities {
+		known[strings.ToLower(e.Value)] = true
+	}
+	lowerBody := strings.ToLower(body)
+
+	var mismatches []mismatch
+	for _, e := range titleEntities {
+		if e.Type == domain.EntityTypeDate || known[strings.ToLower(e.Value)] {
+			continue
+		}
+		if mentionsAnyPart(lowerBody, e.Value) {
+			continue
+		}
+		mismatches = append(mismatches, mismatch{
+			probability: missingEntityProbability,
+			reason:      fmt.Sprintf("headline names %s %q, which the body never mentions", strings.ToLower(string(e.Type)), e.Value),
+		})
+	}
+	return mismatches
+}
+
+// mentionsAnyPart reports whether the body contains the name or one of its
+// capitalized words, so "Warren" in the body supports "Elizabeth Warren"
+func mentionsAnyPart(lowerBody, name string) bool {
+	if strings.Contains(lowerBody, strings.ToLower(name)) {
+		return true
+	}
+	for _, w := range tokenize.Words(name) {
+		if len(w.Text) > 2 && !stopwords[strings.ToLower(w.Text)] && containsWord(lowerBody, strings.ToLower(w.Text)) {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutLabel drops a short label that opens a headline before a colon,
+// such as "Budget 2025:" or "Live:"
+func withoutLabel(title string) string {
+	label, rest, ok := strings.Cut(title, ":")
+	if !ok || strings.TrimSpace(rest) == "" || len(tokenize.Words(label)) > maxLabelWords {
+		return title
+	}
+	return rest
+}
+
+// isYear reports whether a figure is a bare year, which headlines use to
+// date a story rather than to state a figure
+func isYear(value string, percent bool) bool {
+	if percent || len(value) != 4 {
+		return false
+	}
+	year, err := strconv.Atoi(value)
+	return err == nil && year >= 1900 && year <= 2099
+}
+
+// missingNumbers reports headline figures other than years that do not
+// appear in the body, listing the body's figures of the same kind when there
+// are any
+func missingNumbers(title, body string) []mismatch {
+	bodyNumbers := make(map[string]bool)
+	var bodyPercents, bodyPlain []string
+	for _, n := range numberPattern.FindAllString(body, -1) {
+		value, percent := normalizeNumber(n)
+		bodyNumbers[value+fmt.Sprint(percent)] = true
+		if percent {
+			bodyPercents = appendUnique(bodyPercents, strings.TrimSpace(n))
+		} else {
+			bodyPlain = appendUnique(bodyPlain, strings.TrimSpace(n))
+		}
+	}
+
+	var mismatches []mismatch
+	for _, n := range numberPattern.FindAllString(title, -1) {
+		value, percent := normalizeNumber(n)
+		if bodyNumbers[value+fmt.Sprint(percent)] || isYear(value, percent) {
+			continue
+		}
+		figure, others := strings.TrimSpace(n), bodyPlain
+		if percent {
+			others = bodyPercents
+		}
+		reason := fmt.Sprintf("headline figure %s does not appear in the body", figure)
+		if len(others) > 0 {
+			if len(others) > 5 {
+				others = others[:5]
+			}
+			reason += fmt.Sprintf(", which cites %s", strings.Join(others, ", "))
+		}
+		mismatches = append(mismatches, mismatch{probability: missingNumberProbability, reason: reason})
+	}
+	return mismatches
+}
+
+// normalizeNumber strips thousands separators and a trailing zero fraction
+// and reports whether the figure is a percentage
+func normalizeNumber(n string) (string, bool) {
+	percent := strings.ContainsAny(n, "%p")
+	value := strings.TrimSpace(strings.TrimRight(n, "%percent "))
+	value = strings.ReplaceAll(value, ",", "")
+	if i := strings.IndexByte(value, '.'); i >= 0 && strings.Trim(value[i+1:], "0") == "" {
+		value = value[:i]
+	}
+	return value, percent
+}
+
+// keyTerm is a stemmed content word of the headline
+type keyTerm struct {
+	word string
+	stem string
+}
+
+// keyTerms returns the content words of a headline
+func keyTerms(title string) []keyTerm {
+	var terms []keyTerm
+	seen := make(map[string]bool)
+	for _, t := range tokenize.Words(title) {
+		w := tokenize.Normalize(t.Text)
+		if len([]rune(w)) < 3 || stopwords[w] || negations[w] || isDigits(w) {
+			continue
+		}
+		stem := stemWord(w)
+		if seen[stem] {
+			continue
+		}
+		seen[stem] = true
+		terms = append(terms, keyTerm{word: w, stem: stem})
+	}
+	return terms
+}
+
+// lowCoverage reports a headline whose key terms mostly do not occur in the
+// body
+func lowCoverage(terms []keyTerm, body string) (mismatch, bool) {
+	if len(terms) < minKeyTerms {
+		return mismatch{}, false
+	}
+	stems := stemSet(body)
+	var missing []string
+	for _, t := range terms {
+		if !stems[t.stem] {
+			missing = append(missing, t.word)
+		}
+	}
+	coverage := 1 - float64(len(missing))/float64(len(terms))
+	if coverage >= minCoverage {
+		return mismatch{}, false
+	}
+	return mismatch{
+		probability: maxCoverageProbability * (1 - coverage/minCoverage),
+		reason: fmt.Sprintf("only %.0f%% of headline key terms appear in the body (missing: %s)",
+			coverage*100, strings.Join(missing, ", ")),
+	}, true
+}
+
+// negationConflict finds the lead sentence that best matches the headline
+// and reports a conflict when exactly one of them is negated
+func negationConflict(title string, terms []keyTerm, body string) (mismatch, bool) {
+	if len(terms) < 2 {
+		return mismatch{}, false
+	}
+
+	sentences := tokenize.Sentences(body)
+	if len(sentences) > leadSentences {
+		sentences = sentences[:leadSentences]
+	}
+
+	best, bestShared := "", 0
+	for _, s := range sentences {
+		stems := stemSet(s.Text)
+		shared := 0
+		for _, t := range terms {
+			if stems[t.stem] {
+				shared++
+			}
+		}
+		if shared > bestShared {
+			best, bestShared = s.Text, shared
+		}
+	}
+	// The sentence must share most of the headline's key terms to count as
+	// the statement it summarises
+	if float64(bestShared) < 0.6*float64(len(terms)) {
+		return mismatch{}, false
+	}
+
+	if isNegated(title) == isNegated(best) {
+		return mismatch{}, false
+	}
+	return mismatch{
+		probability: negationProbability,
+		reason:      fmt.Sprintf("headline and body disagree on negation: %q", best),
+	}, true
+}
+
+// isNegated reports whether a clause has an odd number of negations
+func isNegated(text string) bool {
+	count := 0
+	for _, t := range tokenize.Words(text) {
+		w := strings.ToLower(t.Text)
+		w = strings.ReplaceAll(w, "’", "'")
+		if negations[w] || strings.HasSuffix(w, "n't") {
+			count++
+		}
+	}
+	return count%2 == 1
+}
+
+// stemSet returns the stems of every word in text
+func stemSet(text string) map[string]bool {
+	stems := make(map[string]bool)
+	for _, t := range tokenize.Words(text) {
+		if w := tokenize.Normalize(t.Text); w != "" {
+			stems[stemWord(w)] = true
+		}
+	}
+	return stems
+}
+
+// stemWord strips common English inflections so "cuts", "cutting" and "cut"
+// match
+func stemWord(w string) string {
+	for _, suffix := range []string{"ing", "ies", "es", "ed", "s"} {
+		if strings.HasSuffix(w, suffix) && len(w)-len(suffix) >= 3 {
+			w = strings.TrimSuffix(w, suffix)
+			if suffix == "ies" {
+				w += "y"
+			}
+			break
+		}
+	}
+	// "cutting" -> "cutt" -> "cut"
+	if n := len(w); n >= 4 && w[n-1] == w[n-2] && !strings.ContainsRune("aeiouls", rune(w[n-1])) {
+		w = w[:n-1]
+	}
+	return w
+}
+
+// containsWord reports whether word occurs in text delimited by non-word
+// characters
+func containsWord(text, word string) bool {
+	for _, t := range tokenize.Words(text) {
+		if tokenize.Normalize(t.Text) == word {
+			return true
+		}
+	}
+	return false
+}
+
+func isDigits(w string) bool {
+	for _, r := range w {
+		if r < '0' || r > '9' {
+			if r != '.' && r != ',' {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func appendUnique(values []string, v string) []string {
+	for _, x := range values {
+		if x == v {
+			return values
+		}
+	}
+	return append(values, v)
+}
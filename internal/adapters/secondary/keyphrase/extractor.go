@@ -0,0 +1,253 @@
+package keyphrase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/internal/core/ports/secondary"
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+const (
+	// DefaultMaxTopics is the number of keyphrases kept per article
+	DefaultMaxTopics = 10
+
+	// maxPhraseWords bounds the length of candidate phrases; longer n-grams
+	// are mostly clauses rather than topics
+	maxPhraseWords = 3
+	// minOccurrences is the number of times a phrase must occur in the
+	// headline and body to be a topic
+	minOccurrences = 2
+)
+
+// Extractor finds keyphrases with RAKE and weighs them by TF-IDF against the
+// document frequencies of all analyzed articles. RAKE splits the text into
+// runs of content words at stopwords and punctuation, and scores each word by
+// its degree over its frequency, which favours words that occur inside longer
+// runs. The IDF weight demotes phrases common to the whole corpus, such as the
+// name of the publication or a recurring section heading.
+type Extractor struct {
+	store     secondary.DocumentFrequencyStore
+	maxTopics int
+}
+
+// Ensure Extractor implements the KeyphraseExtractor interface
+var _ secondary.KeyphraseExtractor = (*Extractor)(nil)
+
+// NewExtractor creates a keyphrase extractor. Without a document frequency
+// store, phrases are ranked by their RAKE scores alone.
+func NewExtractor(store secondary.DocumentFrequencyStore, maxTopics int) *Extractor {
+	return &Extractor{
+		store:     store,
+		maxTopics: maxTopics,
+	}
+}
+
+// candidate is a distinct candidate phrase of a text
+type candidate struct {
+	words       []string
+	occurrences int
+	score       float64
+}
+
+// ExtractKeyphrases returns up to maxTopics keyphrases of text, with scores
+// relative to the best one, and counts every candidate phrase of the text in
+// the document frequency store. Texts in languages without a stopword list yield
+// no keyphrases.
+func (e *Extractor) ExtractKeyphrases(ctx context.Context, documentID, text string) ([]domain.Topic, error) {
+	language := secondary.LanguageFromContext(ctx)
+	if language == "" {
+		language = "en"
+	}
+	stop, ok := stopwords[language]
+	if !ok {
+		return nil, nil
+	}
+
+	candidates := extractCandidates(text, stop)
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	// A phrase mentioned once is rarely what an article is about, and a
+	// phrase that only ever occurs inside a longer one adds nothing to it.
+	// Only the remaining phrases are ranked, but every phrase the text
+	// contains is counted, so that a phrase mentioned once in most articles
+	// is still known to be common.
+	subsumed := findSubsumed(candidates)
+	phrases := make([]string, 0, len(candidates))
+	ranked := make([]string, 0, len(candidates))
+	for phrase, c := range candidates {
+		phrases = append(phrases, phrase)
+		if c.occurrences >= minOccurrences && !subsumed[phrase] {
+			ranked = append(ranked, phrase)
+		}
+	}
+
+	if e.store != nil {
+		frequencies, documents, err := e.store.DocumentFrequencies(ctx, ranked)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get document frequencies: %w", err)
+		}
+		for _, phrase := range ranked {
+			candidates[phrase].score *= math.Log(float64(1+documents)/float64(1+frequencies[phrase])) + 1
+		}
+		if err := e.store.AddDocument(ctx, documentID, phrases); err != nil {
+			return nil, fmt.Errorf("failed to add document frequencies: %w", err)
+		}
+	}
+	if len(ranked) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := candidates[ranked[i]].score, candidates[ranked[j]].score
+		if a != b {
+			return a > b
+		}
+		return ranked[i] < ranked[j]
+	})
+	if len(ranked) > e.maxTopics {
+		ranked = ranked[:e.maxTopics]
+	}
+
+	best := candidates[ranked[0]].score
+	topics := make([]domain.Topic, len(ranked))
+	for i, phrase := range ranked {
+		topics[i] = domain.Topic{
+			Phrase: phrase,
+			Score:  math.Round(candidates[phrase].score/best*100) / 100,
+		}
+	}
+	return topics, nil
+}
+
+// extractCandidates splits text into RAKE runs of content words and returns
+// every n-gram of up to maxPhraseWords words within a run as a candidate. A
+// candidate scores its occurrences times the mean RAKE score of its words:
+// a word's degree, the total length of the runs it occurs in, over its
+// frequency.
+func extractCandidates(text string, stop map[string]bool) map[string]*candidate {
+	var runs [][]string
+	frequency := make(map[string]int)
+	degree := make(map[string]int)
+	for _, sentence := range tokenize.Sentences(text) {
+		for _, run := range phraseRuns(sentence.Text, stop) {
+			runs = append(runs, run)
+			for _, w := range run {
+				frequency[w]++
+				degree[w] += len(run)
+			}
+		}
+	}
+
+	found := make(map[string]*candidate)
+	for _, run := range runs {
+		for n := 1; n <= maxPhraseWords && n <= len(run); n++ {
+			for i := 0; i+n <= len(run); i++ {
+				phrase := strings.Join(run[i:i+n], " ")
+				if c, ok := found[phrase]; ok {
+					c.occurrences++
+					continue
+				}
+				found[phrase] = &candidate{words: run[i : i+n], occurrences: 1}
+			}
+		}
+	}
+
+	for _, c := range found {
+		var rake float64
+		for _, w := range c.words {
+			rake += float64(degree[w]) / float64(frequency[w])
+		}
+		c.score = float64(c.occurrences) * rake / float64(len(c.words))
+	}
+	return found
+}
+
+// findSubsumed returns the candidates that occur exactly as often as a
+// candidate one word longer that contains them
+func findSubsumed(candidates map[string]*candidate) map[string]bool {
+	result := make(map[string]bool)
+	for _, c := range candidates {
+		n := len(c.words)
+		if n < 2 {
+			continue
+		}
+		for _, sub := range [][]string{c.words[:n-1], c.words[1:]} {
+			phrase := strings.Join(sub, " ")
+			if candidates[phrase].occurrences == c.occurrences {
+				result[phrase] = true
+			}
+		}
+	}
+	return result
+}
+
+// phraseRuns splits a sentence into runs of content words, breaking at
+// stopwords, numbers, single letters and any punctuation between words
+func phraseRuns(sentence string, stop map[string]bool) [][]string {
+	var runs [][]string
+	var run []string
+	flush := func() {
+		if len(run) > 0 {
+			runs = append(runs, run)
+			run = nil
+		}
+	}
+
+	previousEnd := 0
+	for _, token := range tokenize.Words(sentence) {
+		if strings.TrimSpace(sentence[previousEnd:token.Start]) != "" {
+			flush()
+		}
+		previousEnd = token.End
+
+		word := elide(tokenize.Normalize(token.Text))
+		if stop[word] || !isContentWord(word) {
+			flush()
+			continue
+		}
+		run = append(run, word)
+	}
+	flush()
+	return runs
+}
+
+// elisions are the French and Italian articles and pronouns that are
+// written joined to the following word, as in "l'économie"
+var elisions = wordSet("c", "d", "j", "l", "m", "n", "qu", "s", "t")
+
+// elide strips an elided article or pronoun from the start of a word
+func elide(word string) string {
+	for _, apostrophe := range []string{"'", "’"} {
+		if i := strings.Index(word, apostrophe); i > 0 && elisions[word[:i]] {
+			return word[i+len(apostrophe):]
+		}
+	}
+	return word
+}
+
+// isContentWord reports whether a word can be part of a keyphrase: it has
+// at least two runes and at least one letter, and is not ideographic
+func isContentWord(word string) bool {
+	runes := []rune(word)
+	if len(runes) < 2 {
+		return false
+	}
+	letter := false
+	for _, r := range runes {
+		if tokenize.IsIdeographic(r) {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			letter = true
+		}
+	}
+	return letter
+}
@@ -0,0 +1,81 @@
+package keyphrase
+
+// stopwords holds, per ISO 639-1 language, the function words and the
+// common verbs and adverbs of news copy at which candidate phrases are split
+var stopwords = map[string]map[string]bool{
+	"en": wordSet(
+		"a", "about", "above", "after", "again", "against", "all", "almost", "also", "although",
+		"am", "among", "an", "and", "another", "any", "are", "around", "as", "at",
+		"be", "became", "because", "become", "been", "before", "being", "below", "between", "both",
+		"but", "by", "can", "could", "did", "do", "does", "doing", "done", "down",
+		"during", "each", "either", "even", "ever", "every", "few", "for", "from", "further",
+		"get", "gets", "got", "had", "has", "have", "having", "he", "her", "here",
+		"hers", "herself", "him", "himself", "his", "how", "however", "i", "if", "in",
+		"into", "is", "it", "its", "itself", "just", "last", "least", "less", "like",
+		"made", "make", "makes", "many", "may", "me", "might", "more", "most", "much",
+		"must", "my", "myself", "near", "neither", "new", "next", "no", "nor", "not",
+		"now", "of", "off", "often", "on", "once", "one", "only", "or", "other",
+		"others", "our", "ours", "ourselves", "out", "over", "own", "per", "perhaps", "put",
+		"rather", "really", "said", "same", "say", "says", "see", "seen", "several", "she",
+		"should", "since", "so", "some", "still", "such", "take", "taken", "than", "that",
+		"the", "their", "theirs", "them", "themselves", "then", "there", "these", "they", "this",
+		"those", "though", "through", "thus", "to", "told", "too", "toward", "towards", "under",
+		"until", "up", "upon", "us", "very", "was", "we", "well", "were", "what",
+		"whatever", "when", "where", "whether", "which", "while", "who", "whom", "whose", "why",
+		"will", "with", "within", "without", "would", "yet", "you", "your", "yours", "yourself",
+		"according", "added", "asked", "called", "including", "know", "known", "mr", "mrs", "ms",
+		"year", "years", "week", "weeks", "day", "days", "today", "yesterday", "tomorrow",
+		"two", "three", "first", "second", "time", "times", "way", "thing", "things", "lot",
+		"don't", "doesn't", "didn't", "isn't", "aren't", "wasn't", "weren't", "won't", "can't", "it's",
+	),
+	"de": wordSet(
+		"aber", "alle", "allem", "allen", "aller", "als", "also", "am", "an", "auch",
+		"auf", "aus", "bei", "bereits", "bis", "bisher", "da", "damit", "dann", "das",
+		"dass", "dem", "den", "denn", "der", "des", "die", "dies", "diese", "diesem",
+		"diesen", "dieser", "doch", "dort", "du", "durch", "ein", "eine", "einem", "einen",
+		"einer", "eines", "er", "es", "etwa", "für", "gegen", "gibt", "hat", "hatte",
+		"haben", "hier", "ich", "ihm", "ihn", "ihr", "ihre", "im", "immer", "in",
+		"ins", "ist", "jedoch", "jetzt", "kann", "kein", "keine", "können", "man", "mehr",
+		"mit", "muss", "nach", "nicht", "noch", "nun", "nur", "ob", "oder", "ohne",
+		"sagte", "sagt", "sehr", "sei", "seien", "sein", "seine", "seit", "sich", "sie",
+		"sind", "so", "soll", "sollen", "sowie", "um", "und", "uns", "unter", "vom",
+		"von", "vor", "war", "waren", "was", "weil", "wenn", "wer", "werden", "wie",
+		"wieder", "will", "wir", "wird", "wurde", "wurden", "zu", "zum", "zur", "zwischen",
+		"jahr", "jahren", "heute", "gestern", "neue", "neuen", "laut", "zwei", "drei", "ersten",
+	),
+	"es": wordSet(
+		"a", "al", "algo", "algunos", "ante", "antes", "aquí", "así", "aunque", "bajo",
+		"bien", "cada", "como", "con", "contra", "cual", "cuando", "de", "del", "desde",
+		"donde", "dos", "durante", "e", "el", "ella", "ellas", "ellos", "en", "entre",
+		"era", "es", "esa", "ese", "eso", "esta", "está", "están", "este", "esto",
+		"estos", "fue", "fueron", "ha", "había", "han", "hasta", "hay", "la", "las",
+		"le", "les", "lo", "los", "más", "me", "mientras", "muy", "ni", "no",
+		"nos", "o", "otra", "otras", "otro", "otros", "para", "pero", "poco", "por",
+		"porque", "puede", "pueden", "que", "qué", "quien", "se", "sea", "según", "ser",
+		"si", "sí", "sido", "sin", "sino", "sobre", "son", "su", "sus", "también",
+		"tanto", "tiene", "tienen", "todo", "todos", "tras", "un", "una", "uno", "unos",
+		"y", "ya", "dijo", "afirmó", "año", "años", "hoy", "ayer", "nuevo", "nueva",
+	),
+	"fr": wordSet(
+		"à", "afin", "ai", "ainsi", "alors", "au", "aussi", "autre", "autres", "aux",
+		"avait", "avant", "avec", "avoir", "bien", "ce", "cela", "celle", "celui", "ces",
+		"cet", "cette", "chaque", "comme", "d'un", "d'une", "dans", "de", "depuis", "des",
+		"deux", "doit", "donc", "dont", "du", "elle", "elles", "en", "encore", "entre",
+		"est", "et", "été", "être", "eu", "fait", "faire", "il", "ils", "je",
+		"l'on", "la", "le", "les", "leur", "leurs", "lors", "lui", "mais", "même",
+		"moins", "ne", "ni", "nous", "on", "ont", "ou", "où", "par", "parce",
+		"pas", "peut", "plus", "pour", "près", "qu'il", "quand", "que", "qui", "sa",
+		"sans", "se", "selon", "ses", "si", "son", "sont", "sous", "sur", "tous",
+		"tout", "toute", "toutes", "très", "un", "une", "vers", "y", "a", "c'est",
+		"dit", "déclaré", "an", "ans", "année", "aujourd'hui", "hier", "nouveau", "nouvelle", "trois",
+	),
+}
+
+// wordSet builds a lookup table from a word list
+func wordSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
@@ -0,0 +1,446 @@
+package knowledgebase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/internal/core/ports/secondary"
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+const (
+	// CheckerName identifies flags and verdicts from the knowledge base
+	CheckerName = "knowledge_base"
+	// DefaultTolerance is the relative difference within which a claimed
+	// quantity agrees with the knowledge base
+	DefaultTolerance = 0.1
+
+	// Confidence of a contradiction, before weighting by the fact's own
+	// confidence. A wrong quantity may also be a different measure of the
+	// same thing, and an outdated value may be reported as history.
+	contradictionConfidence = 0.9
+	outdatedConfidence      = 0.8
+	quantityConfidence      = 0.75
+)
+
+// Checker is a FactChecker that matches sentences against a knowledge base.
+// A sentence is checked for every subject it names and every predicate of
+// that subject it mentions. It agrees with the knowledge base when it states
+// the object that holds at the time it refers to, and contradicts it when it
+// states a different object of the same predicate, a quantity outside the
+// tolerance, or an object that only held at another time. A sentence states
+// an object when the object is linked to the predicate and subject by a
+// copula, as in "the capital of France is Paris", or in apposition, as in
+// "Paris, the capital of France"; amounts of change, as in "grew by 2
+// million", are not taken for quantities.
+//
+// Claims the article already carries get a TRUE verdict with the supporting
+// fact as evidence, or a FALSE verdict; contradictions are also flagged as
+// FACTUAL_ERROR. Negation and tense are recognized in English only.
+type Checker struct {
+	kb        *KnowledgeBase
+	tolerance float64
+}
+
+// Ensure Checker implements the FactChecker interface
+var _ secondary.FactChecker = (*Checker)(nil)
+
+// NewChecker creates a checker for the knowledge base
+func NewChecker(kb *KnowledgeBase, tolerance float64) *Checker {
+	return &Checker{
+		kb:        kb,
+		tolerance: tolerance,
+	}
+}
+
+// NewCheckerFromPath creates a checker for the knowledge base stored at
+// path, a file or a directory of files
+func NewCheckerFromPath(path string, tolerance float64) (*Checker, error) {
+	kb, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewChecker(kb, tolerance), nil
+}
+
+// outcome is the result of checking one sentence against one fact
+type outcome int
+
+const (
+	supported outcome = iota
+	contradicted
+	outdated
+)
+
+// finding is the evidence for or against a sentence
+type finding struct {
+	outcome    outcome
+	fact       Fact
+	claimed    string // the conflicting value the sentence contains
+	negated    bool   // the sentence denies the fact
+	current    []Fact // for outdated values, the facts that held instead
+	confidence float64
+}
+
+// CheckFacts checks the article's claims, or, for sentences that are not
+// claims, flags contradictions only. Claims that were already reviewed keep
+// their verdict.
+func (c *Checker) CheckFacts(ctx context.Context, article *domain.Article) ([]domain.Flag, error) {
+	published := article.CreatedAt
+	if published.IsZero() {
+		published = time.Now()
+	}
+
+	var flags []domain.Flag
+	claimed := make(map[string]bool, len(article.Claims))
+	for i := range article.Claims {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		claim := &article.Claims[i]
+		claimed[claim.Text] = true
+
+		from, to := referencePeriod(claim.Text, claim.Entities, published)
+		findings := c.check(newSentence(claim.Text), from, to)
+		if len(findings) == 0 {
+			continue
+		}
+
+		contradictions := filterFindings(findings, false)
+		if len(contradictions) > 0 {
+			flags = append(flags, contradictionFlag(claim.Text, contradictions))
+		}
+		if claim.Verdict != domain.ClaimVerdictUnchecked && claim.Verdict != "" {
+			continue
+		}
+		switch support := filterFindings(findings, true); {
+		case len(contradictions) == 0:
+			claim.SetVerdict(domain.ClaimVerdictTrue, describeAll(support), CheckerName)
+		case len(support) == 0:
+			claim.SetVerdict(domain.ClaimVerdictFalse, describeAll(contradictions), CheckerName)
+		default:
+			claim.SetVerdict(domain.ClaimVerdictMixed, describeAll(findings), CheckerName)
+		}
+	}
+
+	for _, s := range tokenize.Sentences(article.AnalyzedText()) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if claimed[s.Text] {
+			continue
+		}
+		from, to := referencePeriod(s.Text, nil, published)
+		if contradictions := filterFindings(c.check(newSentence(s.Text), from, to), false); len(contradictions) > 0 {
+			flags = append(flags, contradictionFlag(s.Text, contradictions))
+		}
+	}
+	return flags, nil
+}
+
+// GetSourceReputation returns the source's reputation fact that holds now,
+// or ErrUnknownSource
+func (c *Checker) GetSourceReputation(ctx context.Context, source string) (float64, error) {
+	now := time.Now()
+	for _, f := range c.kb.Facts(source) {
+		if f.Predicate != PredicateReputation || !f.validDuring(now, now.Add(time.Nanosecond)) {
+			continue
+		}
+		if q, ok := parseQuantity(f.Object); ok && !q.percent && q.value >= 0 && q.value <= 1 {
+			return q.value, nil
+		}
+	}
+	return 0, secondary.ErrUnknownSource
+}
+
+// check compares a sentence with the facts about every subject it names
+func (c *Checker) check(s sentence, from, to time.Time) []finding {
+	mentions := c.kb.mentions(s)
+	if len(mentions) == 0 {
+		return nil
+	}
+	negated := s.negated()
+
+	var findings []finding
+	seen := make(map[string]bool)
+	for _, m := range mentions {
+		if seen[m.subject] {
+			continue
+		}
+		seen[m.subject] = true
+
+		byPredicate := make(map[string][]Fact)
+		for _, f := range c.kb.facts[m.subject] {
+			byPredicate[f.Predicate] = append(byPredicate[f.Predicate], f)
+		}
+		predicates := make([]string, 0, len(byPredicate))
+		for p := range byPredicate {
+			predicates = append(predicates, p)
+		}
+		sort.Strings(predicates)
+
+		for _, predicate := range predicates {
+			label, ok := c.labelRange(s, predicate, mentions)
+			if !ok {
+				continue
+			}
+			f, ok := c.checkPredicate(s, label, byPredicate[predicate], m.subject, mentions, from, to)
+			if !ok {
+				continue
+			}
+			if negated {
+				// Denying a fact contradicts it; denying anything else
+				// says nothing about the fact
+				if f.outcome != supported {
+					continue
+				}
+				f.outcome = contradicted
+				f.negated = true
+				f.confidence = contradictionConfidence * f.fact.Confidence
+			}
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+// labelRange returns the words with which the sentence mentions the
+// predicate, outside the subject names
+func (c *Checker) labelRange(s sentence, predicate string, mentions []mention) (wordRange, bool) {
+	for _, phrase := range c.kb.labels[predicate] {
+		for _, i := range s.find(phrase) {
+			if !overlapsAny(i, i+len(phrase), mentions) {
+				return wordRange{start: i, end: i + len(phrase)}, true
+			}
+		}
+	}
+	return wordRange{}, false
+}
+
+// checkPredicate compares the sentence with the facts of one predicate of a
+// subject, mentioned by the label words. It reports false when the sentence
+// states no value for it.
+func (c *Checker) checkPredicate(s sentence, label wordRange, facts []Fact, subject string, mentions []mention, from, to time.Time) (finding, bool) {
+	var current, other []Fact
+	for _, f := range facts {
+		if f.validDuring(from, to) {
+			current = append(current, f)
+		} else {
+			other = append(other, f)
+		}
+	}
+	if len(current) == 0 {
+		return finding{}, false
+	}
+
+	if known, ok := parseQuantity(current[0].Object); ok {
+		claimed, ok := nearestNumber(s, s.starts[label.start], known)
+		if !ok {
+			return finding{}, false
+		}
+		for _, f := range current {
+			if q, ok := parseQuantity(f.Object); ok && sameQuantity(claimed, q, c.tolerance) {
+				return finding{outcome: supported, fact: f}, true
+			}
+		}
+		return finding{
+			outcome:    contradicted,
+			fact:       current[0],
+			claimed:    claimed.text,
+			confidence: quantityConfidence * current[0].Confidence,
+		}, true
+	}
+
+	claim := claimRange(label, subject, mentions)
+	for _, f := range current {
+		if c.statesObject(s, f.Object, subject, mentions, claim) {
+			return finding{outcome: supported, fact: f}, true
+		}
+	}
+	for _, f := range other {
+		if c.statesObject(s, f.Object, subject, mentions, claim) {
+			return finding{
+				outcome:    outdated,
+				fact:       f,
+				claimed:    f.Object,
+				current:    current,
+				confidence: outdatedConfidence * current[0].Confidence,
+			}, true
+		}
+	}
+
+	// Any other object of the predicate, e.g. another country's capital
+	predicate := current[0].Predicate
+	keys := make([]string, 0, len(c.kb.objects[predicate]))
+	for key := range c.kb.objects[predicate] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		object := c.kb.objects[predicate][key]
+		if c.statesObject(s, object, subject, mentions, claim) {
+			return finding{
+				outcome:    contradicted,
+				fact:       current[0],
+				claimed:    object,
+				confidence: contradictionConfidence * current[0].Confidence,
+			}, true
+		}
+	}
+	return finding{}, false
+}
+
+// claimRange returns the words naming the predicate and the mention of the
+// subject closest to them, e.g. "capital of France"
+func claimRange(label wordRange, subject string, mentions []mention) wordRange {
+	claim := label
+	distance := -1
+	for _, m := range mentions {
+		if m.subject != subject {
+			continue
+		}
+		d := max(m.start-label.end, label.start-m.end)
+		if distance < 0 || d < distance {
+			distance = d
+			claim = wordRange{start: min(label.start, m.start), end: max(label.end, m.end)}
+		}
+	}
+	return claim
+}
+
+// statesObject reports whether the sentence names the object, outside the
+// mentions of the subject itself, as the value of the claim words. Objects
+// that are subjects of the knowledge base are also recognized by their
+// aliases.
+func (c *Checker) statesObject(s sentence, object, subject string, mentions []mention, claim wordRange) bool {
+	key := nameKey(object)
+	var own []mention
+	for _, m := range mentions {
+		if m.subject == subject {
+			own = append(own, m)
+		}
+	}
+	phrase := strings.Fields(key)
+	for _, i := range s.find(phrase) {
+		if !overlapsAny(i, i+len(phrase), own) && s.linked(claim, wordRange{start: i, end: i + len(phrase)}) {
+			return true
+		}
+	}
+	if target, ok := c.kb.names[key]; ok && target != subject {
+		for _, m := range mentions {
+			if m.subject == target && s.linked(claim, wordRange{start: m.start, end: m.end}) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// referencePeriod returns the period a sentence refers to: the first date it
+// mentions, everything up to publication when it is in the past tense, or
+// the publication time
+func referencePeriod(text string, entities []domain.Entity, published time.Time) (time.Time, time.Time) {
+	for _, e := range entities {
+		if e.Type != domain.EntityTypeDate || e.Normalized == "" {
+			continue
+		}
+		if from, to, ok := parsePeriod(e.Normalized); ok {
+			return from, to
+		}
+	}
+	if newSentence(text).past() {
+		return time.Time{}, published
+	}
+	return published, published.Add(time.Nanosecond)
+}
+
+// parsePeriod parses the normalized form of a DATE entity: a day, month or
+// year, or a decade such as "2010/2019"
+func parsePeriod(normalized string) (time.Time, time.Time, bool) {
+	if first, last, ok := strings.Cut(normalized, "/"); ok {
+		from, _, err1 := parseDate(first)
+		_, to, err2 := parseDate(last)
+		return from, to, err1 == nil && err2 == nil
+	}
+	from, to, err := parseDate(normalized)
+	return from, to, err == nil
+}
+
+// filterFindings returns the supporting findings, or the contradicting ones
+func filterFindings(findings []finding, support bool) []finding {
+	var result []finding
+	for _, f := range findings {
+		if (f.outcome == supported) == support {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// contradictionFlag reports the contradictions of one sentence
+func contradictionFlag(text string, contradictions []finding) domain.Flag {
+	confidence := 0.0
+	for _, f := range contradictions {
+		confidence = max(confidence, f.confidence)
+	}
+	return domain.Flag{
+		Type:       domain.FlagTypeFactualError,
+		Confidence: confidence,
+		Details:    fmt.Sprintf("%q contradicts the knowledge base: %s", text, describeAll(contradictions)),
+		DetectedBy: CheckerName,
+	}
+}
+
+func describeAll(findings []finding) string {
+	parts := make([]string, len(findings))
+	for i, f := range findings {
+		parts[i] = f.describe()
+	}
+	return strings.Join(parts, "; ")
+}
+
+// describe states the fact behind a finding
+func (f finding) describe() string {
+	switch f.outcome {
+	case outdated:
+		current := make([]string, len(f.current))
+		for i, c := range f.current {
+			current[i] = describeFact(c)
+		}
+		return fmt.Sprintf("%s is out of date: %s; at the time referred to, %s", f.claimed, describeFact(f.fact), strings.Join(current, "; "))
+	case contradicted:
+		if f.negated {
+			return describeFact(f.fact) + ", which it denies"
+		}
+		return fmt.Sprintf("%s, not %s", describeFact(f.fact), f.claimed)
+	}
+	return describeFact(f.fact)
+}
+
+// describeFact formats a fact with its validity and source, e.g. "capital of
+// France is Paris (source: CIA World Factbook)"
+func describeFact(f Fact) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s of %s is %s", strings.Join(label(f.Predicate), " "), f.Subject, f.Object)
+
+	var notes []string
+	switch {
+	case !f.ValidFrom.IsZero() && !f.ValidTo.IsZero():
+		notes = append(notes, fmt.Sprintf("from %s until %s", f.ValidFrom.Format(time.DateOnly), f.ValidTo.Format(time.DateOnly)))
+	case !f.ValidFrom.IsZero():
+		notes = append(notes, "since "+f.ValidFrom.Format(time.DateOnly))
+	case !f.ValidTo.IsZero():
+		notes = append(notes, "until "+f.ValidTo.Format(time.DateOnly))
+	}
+	if f.Source != "" {
+		notes = append(notes, "source: "+f.Source)
+	}
+	if len(notes) > 0 {
+		fmt.Fprintf(&b, " (%s)", strings.Join(notes, ", "))
+	}
+	return b.String()
+}
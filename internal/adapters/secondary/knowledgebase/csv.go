@@ -0,0 +1,99 @@
+package knowledgebase
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ReadCSV reads facts from CSV with a header row. The subject, predicate and
+// object columns are required; valid_from, valid_to, source and confidence
+// are optional. Dates are written as YYYY, YYYY-MM or YYYY-MM-DD, and
+// valid_to includes the whole year, month or day it names.
+func ReadCSV(r io.Reader) ([]Fact, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"subject", "predicate", "object"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing %s column", required)
+		}
+	}
+
+	var facts []Fact
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return facts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		fact := Fact{
+			Subject:   field("subject"),
+			Predicate: field("predicate"),
+			Object:    field("object"),
+			Source:    field("source"),
+		}
+		if fact.ValidFrom, _, err = parseDate(field("valid_from")); err != nil {
+			return nil, fmt.Errorf("line %d: invalid valid_from: %w", line, err)
+		}
+		if _, fact.ValidTo, err = parseDate(field("valid_to")); err != nil {
+			return nil, fmt.Errorf("line %d: invalid valid_to: %w", line, err)
+		}
+		if c := field("confidence"); c != "" {
+			if fact.Confidence, err = strconv.ParseFloat(c, 64); err != nil {
+				return nil, fmt.Errorf("line %d: invalid confidence: %w", line, err)
+			}
+		}
+		facts = append(facts, fact)
+	}
+}
+
+// parseDate parses a date of year, month or day precision, or an RFC 3339
+// timestamp, and returns the start of the period it names and the start of
+// the following one. An empty string yields zero times.
+func parseDate(s string) (time.Time, time.Time, error) {
+	if s == "" {
+		return time.Time{}, time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, s); err == nil {
+		return t, t.AddDate(0, 0, 1), nil
+	}
+	if t, err := time.Parse("2006-01", s); err == nil {
+		return t, t.AddDate(0, 1, 0), nil
+	}
+	if t, err := time.Parse("2006", s); err == nil {
+		return t, t.AddDate(1, 0, 0), nil
+	}
+	return time.Time{}, time.Time{}, errors.New("expected YYYY, YYYY-MM, YYYY-MM-DD or an RFC 3339 timestamp, got " + strconv.Quote(s))
+}
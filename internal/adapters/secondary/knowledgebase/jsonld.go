@@ -0,0 +1,189 @@
+package knowledgebase
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// JSON-LD keys with a fixed meaning. Keys are compared by their local name,
+// so "schema:name" and "http://schema.org/name" are both "name".
+var (
+	nameKeys     = []string{"name", "label"}
+	aliasKeys    = map[string]bool{"alternateName": true, "altLabel": true}
+	ignoredKeys  = map[string]bool{"sameAs": true, "url": true, "description": true, "image": true}
+	validFrom    = []string{"validFrom", "startDate"}
+	validThrough = []string{"validThrough", "endDate"}
+	sourceKeys   = []string{"source", "citation", "isBasedOn"}
+)
+
+// ReadJSONLD reads facts from a JSON-LD document: a node, an array of nodes
+// or an object with a "@graph". Every property of a named node is a
+// predicate. Values may be literals, value objects with "@value", references
+// to other nodes by "@id", or nested nodes. Value objects and nested nodes
+// may carry schema.org validFrom/validThrough (or startDate/endDate) and a
+// source.
+//
+// The document's "@context" is not expanded: properties are read by their
+// local names and no remote contexts are fetched.
+func ReadJSONLD(r io.Reader) ([]Fact, error) {
+	var document interface{}
+	if err := json.NewDecoder(r).Decode(&document); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	var nodes []map[string]interface{}
+	collectNodes(document, &nodes)
+
+	names := make(map[string]string)
+	for _, node := range nodes {
+		if id, ok := node["@id"].(string); ok {
+			if name := nodeName(node); name != "" {
+				names[id] = name
+			}
+		}
+	}
+
+	var facts []Fact
+	for _, node := range nodes {
+		subject := nodeName(node)
+		if subject == "" {
+			continue
+		}
+		for key, value := range node {
+			predicate := localName(key)
+			if strings.HasPrefix(key, "@") || slices.Contains(nameKeys, predicate) || ignoredKeys[predicate] ||
+				slices.Contains(validFrom, predicate) || slices.Contains(validThrough, predicate) || slices.Contains(sourceKeys, predicate) {
+				continue
+			}
+			if aliasKeys[predicate] {
+				predicate = PredicateAlias
+			}
+			for _, v := range asList(value) {
+				fact, ok, err := valueFact(v, names)
+				if err != nil {
+					return nil, fmt.Errorf("%s %s: %w", subject, predicate, err)
+				}
+				if !ok {
+					continue
+				}
+				fact.Subject = subject
+				fact.Predicate = predicate
+				facts = append(facts, fact)
+			}
+		}
+	}
+	return facts, nil
+}
+
+// collectNodes gathers every node object of the document, including nested
+// ones
+func collectNodes(value interface{}, nodes *[]map[string]interface{}) {
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			collectNodes(item, nodes)
+		}
+	case map[string]interface{}:
+		if graph, ok := v["@graph"]; ok {
+			collectNodes(graph, nodes)
+			return
+		}
+		if _, ok := v["@value"]; ok {
+			return
+		}
+		*nodes = append(*nodes, v)
+		for key, child := range v {
+			if !strings.HasPrefix(key, "@") {
+				collectNodes(child, nodes)
+			}
+		}
+	}
+}
+
+// valueFact reads the object, validity and source of a property value. It
+// reports false for values that cannot be objects, such as references to
+// unnamed nodes.
+func valueFact(value interface{}, names map[string]string) (Fact, bool, error) {
+	var fact Fact
+	switch v := value.(type) {
+	case map[string]interface{}:
+		switch {
+		case v["@value"] != nil:
+			fact.Object = literal(v["@value"])
+		case nodeName(v) != "":
+			fact.Object = nodeName(v)
+		default:
+			id, _ := v["@id"].(string)
+			fact.Object = names[id]
+		}
+		var err error
+		if fact.ValidFrom, _, err = parseDate(stringProperty(v, validFrom)); err != nil {
+			return Fact{}, false, err
+		}
+		if _, fact.ValidTo, err = parseDate(stringProperty(v, validThrough)); err != nil {
+			return Fact{}, false, err
+		}
+		fact.Source = stringProperty(v, sourceKeys)
+	default:
+		fact.Object = literal(v)
+	}
+	return fact, fact.Object != "", nil
+}
+
+// nodeName returns the first name or label of a node
+func nodeName(node map[string]interface{}) string {
+	return stringProperty(node, nameKeys)
+}
+
+// stringProperty returns the first string value of the first of the
+// properties, given by local name, that the node has
+func stringProperty(node map[string]interface{}, keys []string) string {
+	for _, want := range keys {
+		for key, value := range node {
+			if localName(key) != want {
+				continue
+			}
+			for _, v := range asList(value) {
+				if m, ok := v.(map[string]interface{}); ok {
+					v = m["@value"]
+				}
+				if s := literal(v); s != "" {
+					return s
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// literal formats a JSON scalar
+func literal(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
+
+// localName strips the prefix or namespace IRI from a property name
+func localName(key string) string {
+	if i := strings.LastIndexAny(key, "/#:"); i >= 0 && !strings.HasPrefix(key, "@") {
+		return key[i+1:]
+	}
+	return key
+}
+
+func asList(value interface{}) []interface{} {
+	if list, ok := value.([]interface{}); ok {
+		return list
+	}
+	return []interface{}{value}
+}
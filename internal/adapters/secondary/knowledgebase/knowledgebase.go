@@ -0,0 +1,246 @@
+// Package knowledgebase checks articles against a local curated knowledge
+// base of subject-predicate-object facts.
+package knowledgebase
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+const (
+	// PredicateAlias declares the object an alternative name of the subject
+	// instead of a fact about it
+	PredicateAlias = "alias"
+	// PredicateReputation holds the reputation of a news source, 0-1
+	PredicateReputation = "reputation"
+)
+
+// Fact is a statement about a subject that holds during a validity interval
+type Fact struct {
+	Subject    string
+	Predicate  string
+	Object     string
+	ValidFrom  time.Time // zero when the fact has always held
+	ValidTo    time.Time // exclusive; zero when the fact still holds
+	Source     string    // where the fact was curated from
+	Confidence float64   // 0-1
+}
+
+// validDuring reports whether the fact holds at some time in [from, to)
+func (f Fact) validDuring(from, to time.Time) bool {
+	return (f.ValidFrom.IsZero() || f.ValidFrom.Before(to)) && (f.ValidTo.IsZero() || from.Before(f.ValidTo))
+}
+
+// KnowledgeBase is an in-memory store of facts indexed by subject. Subjects,
+// objects and aliases are matched case-insensitively and ignoring a leading
+// "the".
+type KnowledgeBase struct {
+	facts   map[string][]Fact            // by subject key
+	names   map[string]string            // subject and alias keys to subject keys
+	byFirst map[string][][]string        // name keys by their first word, for scanning text
+	labels  map[string][][]string        // predicate to the phrases that express it
+	objects map[string]map[string]string // predicate to the keys and names of all its objects
+}
+
+// New creates an empty knowledge base
+func New() *KnowledgeBase {
+	return &KnowledgeBase{
+		facts:   make(map[string][]Fact),
+		names:   make(map[string]string),
+		byFirst: make(map[string][][]string),
+		labels:  make(map[string][][]string),
+		objects: make(map[string]map[string]string),
+	}
+}
+
+// Add stores facts. A predicate may list synonyms separated by "|", such as
+// "head_of_state|president"; the first is the predicate's name and all of
+// them are phrases that express it in text, with underscores and camel case
+// read as spaces. Facts with the alias predicate register alternative names.
+func (kb *KnowledgeBase) Add(facts ...Fact) error {
+	for _, f := range facts {
+		subject := nameKey(f.Subject)
+		if subject == "" || strings.TrimSpace(f.Predicate) == "" || strings.TrimSpace(f.Object) == "" {
+			return fmt.Errorf("incomplete fact %q %q %q", f.Subject, f.Predicate, f.Object)
+		}
+		if !f.ValidFrom.IsZero() && !f.ValidTo.IsZero() && !f.ValidFrom.Before(f.ValidTo) {
+			return fmt.Errorf("fact %q %q %q is valid from %s, after its end %s",
+				f.Subject, f.Predicate, f.Object, f.ValidFrom.Format(time.DateOnly), f.ValidTo.Format(time.DateOnly))
+		}
+		if f.Confidence == 0 {
+			f.Confidence = 1
+		}
+		if f.Confidence < 0 || f.Confidence > 1 {
+			return fmt.Errorf("fact %q %q %q has confidence %v outside 0-1", f.Subject, f.Predicate, f.Object, f.Confidence)
+		}
+
+		predicates := strings.Split(f.Predicate, "|")
+		f.Predicate = strings.TrimSpace(predicates[0])
+		kb.addName(subject, subject)
+
+		if f.Predicate == PredicateAlias {
+			kb.addName(nameKey(f.Object), subject)
+			continue
+		}
+		for _, p := range predicates {
+			kb.addLabel(f.Predicate, label(p))
+		}
+		if kb.objects[f.Predicate] == nil {
+			kb.objects[f.Predicate] = make(map[string]string)
+		}
+		kb.objects[f.Predicate][nameKey(f.Object)] = strings.TrimSpace(f.Object)
+		kb.facts[subject] = append(kb.facts[subject], f)
+	}
+	return nil
+}
+
+// Len returns the number of facts, not counting aliases
+func (kb *KnowledgeBase) Len() int {
+	n := 0
+	for _, facts := range kb.facts {
+		n += len(facts)
+	}
+	return n
+}
+
+// Facts returns the facts about a subject, given by name or alias
+func (kb *KnowledgeBase) Facts(subject string) []Fact {
+	return kb.facts[kb.names[nameKey(subject)]]
+}
+
+func (kb *KnowledgeBase) addName(name, subject string) {
+	if name == "" {
+		return
+	}
+	if _, ok := kb.names[name]; ok {
+		return
+	}
+	kb.names[name] = subject
+	words := strings.Fields(name)
+	kb.byFirst[words[0]] = append(kb.byFirst[words[0]], words)
+}
+
+func (kb *KnowledgeBase) addLabel(predicate string, phrase []string) {
+	if len(phrase) == 0 {
+		return
+	}
+	for _, existing := range kb.labels[predicate] {
+		if strings.Join(existing, " ") == strings.Join(phrase, " ") {
+			return
+		}
+	}
+	kb.labels[predicate] = append(kb.labels[predicate], phrase)
+}
+
+// Load reads a knowledge base from a CSV or JSON-LD file, or from every such
+// file in a directory
+func Load(path string) (*KnowledgeBase, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open knowledge base: %w", err)
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read knowledge base directory: %w", err)
+		}
+		files = files[:0]
+		for _, e := range entries {
+			if !e.IsDir() && isKnowledgeBaseFile(e.Name()) {
+				files = append(files, filepath.Join(path, e.Name()))
+			}
+		}
+		sort.Strings(files)
+	}
+
+	kb := New()
+	for _, file := range files {
+		if err := kb.LoadFile(file); err != nil {
+			return nil, err
+		}
+	}
+	return kb, nil
+}
+
+// LoadFile adds the facts of a CSV (.csv) or JSON-LD (.jsonld, .json) file
+func (kb *KnowledgeBase) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open knowledge base file: %w", err)
+	}
+	defer f.Close()
+
+	var facts []Fact
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		facts, err = ReadCSV(f)
+	case ".jsonld", ".json":
+		facts, err = ReadJSONLD(f)
+	default:
+		return fmt.Errorf("unsupported knowledge base file %s", path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := kb.Add(facts...); err != nil {
+		return fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	return nil
+}
+
+func isKnowledgeBaseFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv", ".jsonld", ".json":
+		return !strings.HasPrefix(name, ".")
+	}
+	return false
+}
+
+// nameKey normalizes a name for lookup: lower case words, without a leading
+// "the"
+func nameKey(name string) string {
+	words := normalizedWords(name)
+	if len(words) > 1 && words[0] == "the" {
+		words = words[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// label turns a predicate name such as "head_of_state" or "headOfState"
+// into the words that express it in text
+func label(predicate string) []string {
+	var b strings.Builder
+	var previous rune
+	for _, r := range strings.TrimSpace(predicate) {
+		switch {
+		case r == '_' || r == '-':
+			r = ' '
+		case unicode.IsUpper(r) && unicode.IsLower(previous):
+			b.WriteRune(' ')
+		}
+		b.WriteRune(r)
+		previous = r
+	}
+	return normalizedWords(b.String())
+}
+
+// normalizedWords returns the lower-cased words of text
+func normalizedWords(text string) []string {
+	tokens := tokenize.Words(text)
+	words := make([]string, 0, len(tokens))
+	for _, t := range tokens {
+		if w := tokenize.Normalize(t.Text); w != "" {
+			words = append(words, w)
+		}
+	}
+	return words
+}
@@ -0,0 +1,282 @@
+package knowledgebase
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+// sentence is a piece of text split into normalized words
+type sentence struct {
+	text   string
+	words  []string
+	starts []int // byte offset of every word in text
+}
+
+// newSentence splits text into lower-cased words without possessive
+// endings, so that "France's" matches "France"
+func newSentence(text string) sentence {
+	s := sentence{text: text}
+	for _, t := range tokenize.Words(text) {
+		w := tokenize.Normalize(t.Text)
+		for _, suffix := range []string{"'s", "’s"} {
+			w = strings.TrimSuffix(w, suffix)
+		}
+		if w != "" {
+			s.words = append(s.words, w)
+			s.starts = append(s.starts, t.Start)
+		}
+	}
+	return s
+}
+
+// find returns the word indexes at which the phrase occurs
+func (s sentence) find(phrase []string) []int {
+	var found []int
+	for i := 0; i+len(phrase) <= len(s.words); i++ {
+		if matchAt(s.words, i, phrase) {
+			found = append(found, i)
+		}
+	}
+	return found
+}
+
+// wordRange is a range of words of a sentence, end exclusive
+type wordRange struct {
+	start, end int
+}
+
+// linkWords may stand between a claim and its value: copulas and auxiliaries,
+// relative pronouns, articles, negations and adverbs of time
+var linkWords = map[string]bool{
+	"is": true, "are": true, "was": true, "were": true, "be": true, "been": true,
+	"remains": true, "remained": true, "becomes": true, "became": true,
+	"has": true, "have": true, "had": true, "will": true, "would": true, "used": true, "to": true,
+	"who": true, "which": true, "that": true, "the": true, "a": true, "an": true,
+	"not": true, "no": true, "never": true, "longer": true,
+	"still": true, "now": true, "currently": true, "also": true, "officially": true, "once": true, "formerly": true,
+}
+
+// linked reports whether a value is linked to a claim: next to it, as in
+// "France's capital, Paris", or separated only by link words, as in "the
+// capital of France is Paris" or "Berlin is the capital of Germany". Commas
+// are not words, so appositions count as next to the claim.
+func (s sentence) linked(claim, value wordRange) bool {
+	var between []string
+	switch {
+	case value.start >= claim.end:
+		between = s.words[claim.end:value.start]
+	case value.end <= claim.start:
+		between = s.words[value.end:claim.start]
+	default:
+		return false
+	}
+	for _, w := range between {
+		if !linkWords[w] {
+			return false
+		}
+	}
+	return true
+}
+
+// negated reports whether the sentence contains a negation
+func (s sentence) negated() bool {
+	for i, w := range s.words {
+		switch {
+		case w == "not" || w == "never" || w == "neither" || w == "nor" || strings.HasSuffix(w, "n't"):
+			return true
+		case w == "no" && i+1 < len(s.words) && s.words[i+1] == "longer":
+			return true
+		}
+	}
+	return false
+}
+
+// past reports whether the sentence speaks of the past
+func (s sentence) past() bool {
+	for _, w := range s.words {
+		switch w {
+		case "was", "were", "former", "formerly", "previously", "then", "once", "used":
+			return true
+		}
+	}
+	return false
+}
+
+// mention is a range of words naming a subject
+type mention struct {
+	subject    string
+	start, end int
+}
+
+// mentions finds the longest subject names and aliases in the sentence
+func (kb *KnowledgeBase) mentions(s sentence) []mention {
+	var found []mention
+	for i := 0; i < len(s.words); {
+		longest := 0
+		var subject string
+		for _, name := range kb.byFirst[s.words[i]] {
+			if len(name) > longest && matchAt(s.words, i, name) {
+				longest = len(name)
+				subject = kb.names[strings.Join(name, " ")]
+			}
+		}
+		if longest == 0 {
+			i++
+			continue
+		}
+		found = append(found, mention{subject: subject, start: i, end: i + longest})
+		i += longest
+	}
+	return found
+}
+
+func matchAt(words []string, i int, phrase []string) bool {
+	if len(phrase) == 0 || i+len(phrase) > len(words) {
+		return false
+	}
+	for j, w := range phrase {
+		if words[i+j] != w {
+			return false
+		}
+	}
+	return true
+}
+
+func overlapsAny(start, end int, mentions []mention) bool {
+	for _, m := range mentions {
+		if start < m.end && m.start < end {
+			return true
+		}
+	}
+	return false
+}
+
+// number is a quantity written in text
+type number struct {
+	value   float64
+	percent bool
+	year    bool // a bare four-digit integer that may be a year
+	start   int  // byte offset in the text
+	text    string
+}
+
+var numberPattern = regexp.MustCompile(`(?i)(\d[\d,]*(?:\.\d+)?)(\s*%|\s*(?:percent|per cent|thousand|million|billion|trillion)\b|(?:bn|m|k)\b)?`)
+
+var scales = map[string]float64{
+	"thousand": 1e3, "k": 1e3,
+	"million": 1e6, "m": 1e6,
+	"billion": 1e9, "bn": 1e9,
+	"trillion": 1e12,
+}
+
+// numbers finds the quantities in text. Digits that are part of a word, as
+// in "G7", are skipped.
+func numbers(text string) []number {
+	var found []number
+	for _, loc := range numberPattern.FindAllStringSubmatchIndex(text, -1) {
+		if loc[0] > 0 {
+			if r, _ := utf8.DecodeLastRuneInString(text[:loc[0]]); unicode.IsLetter(r) {
+				continue
+			}
+		}
+		digits := strings.TrimRight(text[loc[2]:loc[3]], ",")
+		value, err := strconv.ParseFloat(strings.ReplaceAll(digits, ",", ""), 64)
+		if err != nil {
+			continue
+		}
+
+		n := number{value: value, start: loc[0], text: strings.TrimSpace(text[loc[0]:loc[1]])}
+		if loc[4] >= 0 {
+			suffix := strings.ToLower(strings.TrimSpace(text[loc[4]:loc[5]]))
+			switch suffix {
+			case "%", "percent", "per cent":
+				n.percent = true
+			default:
+				n.value *= scales[suffix]
+			}
+		} else {
+			n.year = len(digits) == 4 && value >= 1000 && value < 3000
+		}
+		found = append(found, n)
+	}
+	return found
+}
+
+// parseQuantity parses an object that is entirely a quantity, such as
+// "67,750,000", "67.75 million" or "2.1%"
+func parseQuantity(object string) (number, bool) {
+	object = strings.TrimSpace(object)
+	found := numbers(object)
+	if len(found) != 1 || found[0].start != 0 || len(found[0].text) != len(object) {
+		return number{}, false
+	}
+	return found[0], true
+}
+
+// nearestNumber returns the number in the sentence closest to the given byte
+// offset that is comparable with the known quantity. Amounts of change are
+// skipped.
+func nearestNumber(s sentence, offset int, known number) (number, bool) {
+	var best number
+	bestDistance := math.MaxInt
+	for _, n := range numbers(s.text) {
+		if n.percent != known.percent || (n.year && !known.year) || s.changeAmount(n) {
+			continue
+		}
+		distance := n.start - offset
+		if distance < 0 {
+			distance = -distance
+		}
+		if distance < bestDistance {
+			best, bestDistance = n, distance
+		}
+	}
+	return best, bestDistance != math.MaxInt
+}
+
+// changeWords introduce the amount by which a quantity changed
+var changeWords = map[string]bool{
+	"grew": true, "grown": true, "rose": true, "risen": true, "fell": true, "fallen": true,
+	"increased": true, "decreased": true, "dropped": true, "declined": true, "climbed": true,
+	"jumped": true, "surged": true, "shrank": true, "shrunk": true, "gained": true, "lost": true,
+	"grow": true, "grows": true, "rise": true, "rises": true, "fall": true, "falls": true,
+	"up": true, "down": true, "increase": true, "decrease": true, "drop": true, "decline": true,
+	"growth": true, "gain": true, "loss": true,
+}
+
+// changeFillers may stand between a change word and its amount, as in "grew
+// by about 2 million", "an increase of 2 million" or "fell from 70 million"
+var changeFillers = map[string]bool{
+	"by": true, "of": true, "from": true, "about": true, "around": true, "roughly": true,
+	"nearly": true, "almost": true, "some": true, "over": true, "more": true, "than": true,
+	"another": true, "a": true, "further": true,
+}
+
+// changeAmount reports whether a number is the amount by which a quantity
+// changed, as in "grew by 2 million" or "up 3%", or the value it changed
+// from, rather than the quantity itself
+func (s sentence) changeAmount(n number) bool {
+	i := sort.SearchInts(s.starts, n.start) - 1
+	for skipped := 0; i >= 0 && skipped <= 3; i, skipped = i-1, skipped+1 {
+		if changeWords[s.words[i]] {
+			return true
+		}
+		if !changeFillers[s.words[i]] {
+			return false
+		}
+	}
+	return false
+}
+
+// sameQuantity reports whether a claimed quantity agrees with a known one
+// within the relative tolerance
+func sameQuantity(claimed, known number, tolerance float64) bool {
+	return math.Abs(claimed.value-known.value) <= tolerance*math.Abs(known.value)
+}
@@ -0,0 +1,215 @@
+package langid
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/reality-filter/internal/core/ports/secondary"
+)
+
+//go:embed samples/*.txt
+var samples embed.FS
+
+const (
+	// Undetermined is the ISO 639 code returned when no language can be
+	// identified
+	Undetermined = "und"
+
+	// maxSampleRunes limits how much of a long text is used for detection
+	maxSampleRunes = 4000
+	// sharpness scales the per-n-gram log likelihood gap between languages
+	// when converting scores to probabilities
+	sharpness = 60.0
+)
+
+// scriptLanguages maps scripts used by a single bundled language directly to
+// that language
+var scriptLanguages = []struct {
+	table    *unicode.RangeTable
+	language string
+}{
+	{unicode.Hangul, "ko"},
+	{unicode.Hiragana, "ja"},
+	{unicode.Katakana, "ja"},
+	{unicode.Han, "zh"},
+	{unicode.Greek, "el"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"},
+	{unicode.Devanagari, "hi"},
+	{unicode.Thai, "th"},
+	{unicode.Armenian, "hy"},
+	{unicode.Georgian, "ka"},
+}
+
+// Detector identifies the language of a text using the script it is written
+// in and character n-gram profiles
+type Detector struct {
+	// profiles groups n-gram profiles by the script their language uses
+	profiles map[*unicode.RangeTable][]*Profile
+}
+
+// Ensure Detector implements secondary.LanguageDetector
+var _ secondary.LanguageDetector = (*Detector)(nil)
+
+// NewDetector creates a language detector with the bundled profiles. Extra
+// profiles are built from the "<code>.txt" sample files in profileDir, if
+// given, and replace bundled profiles for the same language.
+func NewDetector(profileDir string) (*Detector, error) {
+	d := &Detector{profiles: make(map[*unicode.RangeTable][]*Profile)}
+
+	entries, err := samples.ReadDir("samples")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundled language samples: %w", err)
+	}
+	for _, entry := range entries {
+		data, err := samples.ReadFile("samples/" + entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundled language sample: %w", err)
+		}
+		d.AddProfile(NewProfile(strings.TrimSuffix(entry.Name(), ".txt"), string(data)))
+	}
+
+	if profileDir == "" {
+		return d, nil
+	}
+	files, err := filepath.Glob(filepath.Join(profileDir, "*.txt"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list language samples: %w", err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read language sample: %w", err)
+		}
+		d.AddProfile(NewProfile(strings.TrimSuffix(filepath.Base(file), ".txt"), string(data)))
+	}
+	return d, nil
+}
+
+// AddProfile registers a language profile, replacing any existing profile for
+// the same language
+func (d *Detector) AddProfile(profile *Profile) {
+	for script, profiles := range d.profiles {
+		kept := profiles[:0]
+		for _, p := range profiles {
+			if p.Language != profile.Language {
+				kept = append(kept, p)
+			}
+		}
+		d.profiles[script] = kept
+	}
+	d.profiles[profile.script] = append(d.profiles[profile.script], profile)
+}
+
+// Languages returns the ISO 639-1 codes the detector can identify
+func (d *Detector) Languages() []string {
+	seen := make(map[string]bool)
+	for _, s := range scriptLanguages {
+		seen[s.language] = true
+	}
+	for _, profiles := range d.profiles {
+		for _, p := range profiles {
+			seen[p.Language] = true
+		}
+	}
+	languages := make([]string, 0, len(seen))
+	for l := range seen {
+		languages = append(languages, l)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// DetectLanguage returns the ISO 639-1 code of the text's language and a
+// confidence between 0 and 1. Texts without letters yield Undetermined.
+func (d *Detector) DetectLanguage(ctx context.Context, text string) (string, float64, error) {
+	if err := ctx.Err(); err != nil {
+		return Undetermined, 0, err
+	}
+
+	if runes := []rune(text); len(runes) > maxSampleRunes {
+		text = string(runes[:maxSampleRunes])
+	}
+
+	counts := make(map[*unicode.RangeTable]int)
+	letters := 0
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		counts[scriptOf(r)]++
+	}
+	if letters == 0 {
+		return Undetermined, 0, nil
+	}
+
+	// Japanese mixes kana with Han characters, so any kana decides for it
+	if kana := counts[unicode.Hiragana] + counts[unicode.Katakana]; kana > 0 && kana*10 >= letters {
+		return "ja", float64(kana+counts[unicode.Han]) / float64(letters), nil
+	}
+
+	script, best := (*unicode.RangeTable)(nil), 0
+	for s, c := range counts {
+		if c > best {
+			script, best = s, c
+		}
+	}
+	share := float64(best) / float64(letters)
+
+	for _, s := range scriptLanguages {
+		if s.table == script {
+			return s.language, share, nil
+		}
+	}
+
+	profiles := d.profiles[script]
+	if len(profiles) == 0 {
+		return Undetermined, 0, nil
+	}
+
+	grams, total := ngrams(text)
+	scores := make([]float64, len(profiles))
+	top := math.Inf(-1)
+	for i, p := range profiles {
+		scores[i] = p.score(grams, total)
+		top = math.Max(top, scores[i])
+	}
+
+	// Softmax over the average log likelihoods; short texts give flatter
+	// distributions because fewer n-grams separate the languages
+	weight := sharpness * math.Min(1, float64(total)/150)
+	var sum float64
+	bestIndex := 0
+	for i, s := range scores {
+		scores[i] = math.Exp((s - top) * weight)
+		sum += scores[i]
+		if s == top {
+			bestIndex = i
+		}
+	}
+	return profiles[bestIndex].Language, scores[bestIndex] / sum * share, nil
+}
+
+// scriptOf returns the Unicode script table of a letter
+func scriptOf(r rune) *unicode.RangeTable {
+	switch {
+	case unicode.Is(unicode.Latin, r):
+		return unicode.Latin
+	case unicode.Is(unicode.Cyrillic, r):
+		return unicode.Cyrillic
+	}
+	for _, s := range scriptLanguages {
+		if unicode.Is(s.table, r) {
+			return s.table
+		}
+	}
+	return nil
+}
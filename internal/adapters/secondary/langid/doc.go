@@ -0,0 +1,5 @@
+// Package langid implements offline language identification. Languages with a
+// script of their own are recognised by script; languages sharing the Latin
+// or Cyrillic alphabets are told apart with character n-gram profiles built
+// from the bundled samples at startup.
+package langid
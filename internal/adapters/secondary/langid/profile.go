@@ -0,0 +1,106 @@
+package langid
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+const (
+	// minOrder and maxOrder bound the character n-gram lengths
+	minOrder = 1
+	maxOrder = 3
+)
+
+// Profile is the character n-gram model of a single language
+type Profile struct {
+	Language string
+	// script is the writing system most of the training letters belong to
+	script *unicode.RangeTable
+	// logProb maps an n-gram to its smoothed log probability among n-grams
+	// of the same order
+	logProb map[string]float64
+	// unseen is the log probability given to n-grams of each order that were
+	// not observed in training
+	unseen [maxOrder + 1]float64
+}
+
+// NewProfile builds a profile for the language from sample text
+func NewProfile(language, sample string) *Profile {
+	var counts [maxOrder + 1]map[string]int
+	var totals [maxOrder + 1]int
+	for n := minOrder; n <= maxOrder; n++ {
+		counts[n] = make(map[string]int)
+	}
+	forEachNgram(sample, func(gram string, n int) {
+		counts[n][gram]++
+		totals[n]++
+	})
+
+	p := &Profile{Language: language, logProb: make(map[string]float64)}
+
+	scripts := make(map[*unicode.RangeTable]int)
+	best := 0
+	for gram, c := range counts[1] {
+		script := scriptOf([]rune(gram)[0])
+		scripts[script] += c
+		if scripts[script] > best {
+			p.script, best = script, scripts[script]
+		}
+	}
+
+	for n := minOrder; n <= maxOrder; n++ {
+		// Add-one smoothing over the observed vocabulary plus one slot for
+		// unseen n-grams
+		denominator := float64(totals[n] + len(counts[n]) + 1)
+		for gram, c := range counts[n] {
+			p.logProb[gram] = math.Log(float64(c+1) / denominator)
+		}
+		p.unseen[n] = math.Log(1 / denominator)
+	}
+	return p
+}
+
+// score returns the average log likelihood of the n-grams under the profile
+func (p *Profile) score(grams map[string]int, total int) float64 {
+	var sum float64
+	for gram, c := range grams {
+		lp, ok := p.logProb[gram]
+		if !ok {
+			lp = p.unseen[len([]rune(gram))]
+		}
+		sum += lp * float64(c)
+	}
+	return sum / float64(total)
+}
+
+// ngrams counts the character n-grams of the text
+func ngrams(text string) (map[string]int, int) {
+	grams := make(map[string]int)
+	total := 0
+	forEachNgram(text, func(gram string, _ int) {
+		grams[gram]++
+		total++
+	})
+	return grams, total
+}
+
+// forEachNgram calls fn for every character n-gram of every word in the text.
+// Words are lower-cased and padded with spaces so that n-grams capture word
+// beginnings and endings.
+func forEachNgram(text string, fn func(gram string, n int)) {
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	}) {
+		runes := []rune(" " + word + " ")
+		for n := minOrder; n <= maxOrder; n++ {
+			for i := 0; i+n <= len(runes); i++ {
+				gram := string(runes[i : i+n])
+				if gram == " " {
+					continue
+				}
+				fn(gram, n)
+			}
+		}
+	}
+}
@@ -55,12 +55,37 @@ func (r *ArticleRepository) FindByID(ctx context.Context, id string) (*domain.Ar
 
 // FindFlagged retrieves flagged articles with pagination
 func (r *ArticleRepository) FindFlagged(ctx context.Context, limit, offset int) ([]*domain.Article, error) {
+	return r.FindByStatus(ctx, domain.ArticleStatusFlagged, limit, offset)
+}
+
+// FindByStatus retrieves articles with the given status with pagination
+func (r *ArticleRepository) FindByStatus(ctx context.Context, status domain.ArticleStatus, limit, offset int) ([]*domain.Article, error) {
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset)).
+		SetSort(bson.D{{Key: "updatedat", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"status": status}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var articles []*domain.Article
+	if err = cursor.All(ctx, &articles); err != nil {
+		return nil, err
+	}
+	return articles, nil
+}
+
+// FindByAuthor retrieves articles by the given author with pagination
+func (r *ArticleRepository) FindByAuthor(ctx context.Context, author string, limit, offset int) ([]*domain.Article, error) {
 	opts := options.Find().
 		SetLimit(int64(limit)).
 		SetSkip(int64(offset)).
-		SetSort(bson.D{{Key: "updated_at", Value: -1}})
+		SetSort(bson.D{{Key: "updatedat", Value: -1}, {Key: "_id", Value: -1}})
 
-	cursor, err := r.collection.Find(ctx, bson.M{"status": domain.ArticleStatusFlagged}, opts)
+	cursor, err := r.collection.Find(ctx, bson.M{"author": author}, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -73,6 +98,90 @@ func (r *ArticleRepository) FindFlagged(ctx context.Context, limit, offset int)
 	return articles, nil
 }
 
+// CountTopics counts the articles created in [from, to) per topic phrase,
+// and the total number of articles created in that period
+func (r *ArticleRepository) CountTopics(ctx context.Context, from, to time.Time) (map[string]int, int, error) {
+	match := createdBetween(from, to)
+	total, err := r.collection.CountDocuments(ctx, match)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	counts, err := r.countBy(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$unwind", Value: "$metadata.topics"}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$metadata.topics.phrase"},
+			{Key: "count", Value: bson.M{"$sum": 1}},
+		}}},
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return counts, int(total), nil
+}
+
+// CountBySource counts the articles created in [from, to) per source
+func (r *ArticleRepository) CountBySource(ctx context.Context, from, to time.Time) (map[string]int, error) {
+	return r.countBy(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: createdBetween(from, to)}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$source"},
+			{Key: "count", Value: bson.M{"$sum": 1}},
+		}}},
+	})
+}
+
+// CountFlags counts the flags of each type raised on articles created in
+// [from, to)
+func (r *ArticleRepository) CountFlags(ctx context.Context, from, to time.Time) (map[domain.FlagType]int, error) {
+	counts, err := r.countBy(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: createdBetween(from, to)}},
+		{{Key: "$unwind", Value: "$flags"}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$flags.type"},
+			{Key: "count", Value: bson.M{"$sum": 1}},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	flags := make(map[domain.FlagType]int, len(counts))
+	for flagType, count := range counts {
+		flags[domain.FlagType(flagType)] = count
+	}
+	return flags, nil
+}
+
+// countBy runs an aggregation that groups documents into {_id, count} rows
+func (r *ArticleRepository) countBy(ctx context.Context, pipeline mongo.Pipeline) (map[string]int, error) {
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Key   string `bson:"_id"`
+		Count int    `bson:"count"`
+	}
+	if err = cursor.All(ctx, &rows); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Key] = row.Count
+	}
+	return counts, nil
+}
+
+// createdBetween matches articles created in [from, to)
+func createdBetween(from, to time.Time) bson.M {
+	return bson.M{"createdat": bson.M{"$gte": from, "$lt": to}}
+}
+
 // Update updates an existing article
 func (r *ArticleRepository) Update(ctx context.Context, article *domain.Article) error {
 	article.UpdatedAt = time.Now()
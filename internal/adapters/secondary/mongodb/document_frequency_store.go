@@ -0,0 +1,100 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// DocumentFrequencyStore implements the secondary.DocumentFrequencyStore
+// interface using MongoDB. Term counts are kept in one collection and the
+// IDs of the counted documents in another, which makes adding a document
+// idempotent.
+type DocumentFrequencyStore struct {
+	terms     *mongo.Collection
+	documents *mongo.Collection
+}
+
+// NewDocumentFrequencyStore creates a new MongoDB document frequency store
+func NewDocumentFrequencyStore(client *mongo.Client, database string) *DocumentFrequencyStore {
+	db := client.Database(database)
+	return &DocumentFrequencyStore{
+		terms:     db.Collection("document_frequencies"),
+		documents: db.Collection("document_frequency_documents"),
+	}
+}
+
+// AddDocument counts the distinct terms of a document unless the document
+// was already counted. The terms are counted before the document is marked
+// as counted: if marking it fails, adding it again counts its terms twice,
+// which skews the frequencies far less than a counted document whose terms
+// are missing.
+func (s *DocumentFrequencyStore) AddDocument(ctx context.Context, documentID string, terms []string) error {
+	var counted bson.M
+	err := s.documents.FindOne(ctx, bson.M{"_id": documentID}).Decode(&counted)
+	if err == nil {
+		return nil
+	}
+	if err != mongo.ErrNoDocuments {
+		return err
+	}
+
+	seen := make(map[string]bool, len(terms))
+	models := make([]mongo.WriteModel, 0, len(terms))
+	for _, term := range terms {
+		if seen[term] {
+			continue
+		}
+		seen[term] = true
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": term}).
+			SetUpdate(bson.M{"$inc": bson.M{"count": 1}}).
+			SetUpsert(true))
+	}
+	if len(models) > 0 {
+		if _, err := s.terms.BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false)); err != nil {
+			return err
+		}
+	}
+
+	_, err = s.documents.InsertOne(ctx, bson.M{"_id": documentID})
+	if mongo.IsDuplicateKeyError(err) {
+		// Counted concurrently
+		return nil
+	}
+	return err
+}
+
+// DocumentFrequencies returns the document frequency of each known term and
+// the number of documents counted
+func (s *DocumentFrequencyStore) DocumentFrequencies(ctx context.Context, terms []string) (map[string]int, int, error) {
+	total, err := s.documents.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(terms) == 0 {
+		return map[string]int{}, int(total), nil
+	}
+
+	cursor, err := s.terms.Find(ctx, bson.M{"_id": bson.M{"$in": terms}})
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var rows []struct {
+		Term  string `bson:"_id"`
+		Count int    `bson:"count"`
+	}
+	if err = cursor.All(ctx, &rows); err != nil {
+		return nil, 0, err
+	}
+
+	frequencies := make(map[string]int, len(rows))
+	for _, row := range rows {
+		frequencies[row.Term] = row.Count
+	}
+	return frequencies, int(total), nil
+}
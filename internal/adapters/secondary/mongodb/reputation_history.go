@@ -0,0 +1,50 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/reality-filter/internal/core/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ReputationHistory implements the secondary.ReputationHistory interface
+// using MongoDB. Changes are never modified or removed once appended; their
+// generated ids order changes made within the same millisecond.
+type ReputationHistory struct {
+	collection *mongo.Collection
+}
+
+// NewReputationHistory creates a new MongoDB reputation history
+func NewReputationHistory(client *mongo.Client, database string) *ReputationHistory {
+	return &ReputationHistory{
+		collection: client.Database(database).Collection("source_reputation_history"),
+	}
+}
+
+// Append records a reputation change
+func (h *ReputationHistory) Append(ctx context.Context, change *domain.ReputationChange) error {
+	_, err := h.collection.InsertOne(ctx, change)
+	return err
+}
+
+// List retrieves the changes to a source's reputation, most recent first
+func (h *ReputationHistory) List(ctx context.Context, domainName string, limit, offset int) ([]*domain.ReputationChange, error) {
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset)).
+		SetSort(bson.D{{Key: "at", Value: -1}, {Key: "_id", Value: -1}})
+
+	cursor, err := h.collection.Find(ctx, bson.M{"domain": domainName}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var changes []*domain.ReputationChange
+	if err = cursor.All(ctx, &changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
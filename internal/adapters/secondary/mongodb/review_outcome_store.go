@@ -0,0 +1,57 @@
+package mongodb
+
+import (
+	"context"
+
+	"github.com/reality-filter/internal/core/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ReviewOutcomeStore implements the secondary.ReviewOutcomeStore interface
+// using MongoDB. Outcomes are keyed by article ID.
+type ReviewOutcomeStore struct {
+	collection *mongo.Collection
+}
+
+// reviewOutcomeDocument is the stored form of a review outcome
+type reviewOutcomeDocument struct {
+	ArticleID            string `bson:"_id"`
+	domain.ReviewOutcome `bson:",inline"`
+}
+
+// NewReviewOutcomeStore creates a new MongoDB review outcome store
+func NewReviewOutcomeStore(client *mongo.Client, database string) *ReviewOutcomeStore {
+	return &ReviewOutcomeStore{
+		collection: client.Database(database).Collection("review_outcomes"),
+	}
+}
+
+// Save stores the outcome counted for an article, replacing the one stored
+// before
+func (s *ReviewOutcomeStore) Save(ctx context.Context, outcome *domain.ReviewOutcome) error {
+	document := reviewOutcomeDocument{ArticleID: outcome.ArticleID, ReviewOutcome: *outcome}
+	_, err := s.collection.ReplaceOne(ctx, bson.M{"_id": outcome.ArticleID}, document, options.Replace().SetUpsert(true))
+	return err
+}
+
+// Find retrieves the outcome counted for an article
+func (s *ReviewOutcomeStore) Find(ctx context.Context, articleID string) (*domain.ReviewOutcome, error) {
+	var document reviewOutcomeDocument
+	err := s.collection.FindOne(ctx, bson.M{"_id": articleID}).Decode(&document)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &document.ReviewOutcome, nil
+}
+
+// Delete removes the outcome counted for an article if the given source
+// counts it
+func (s *ReviewOutcomeStore) Delete(ctx context.Context, articleID, domainName string) error {
+	_, err := s.collection.DeleteOne(ctx, bson.M{"_id": articleID, "domain": domainName})
+	return err
+}
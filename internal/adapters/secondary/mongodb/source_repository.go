@@ -0,0 +1,140 @@
+package mongodb
+
+import (
+	"context"
+	"strings"
+
+	"github.com/reality-filter/internal/core/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// SourceRepository implements the secondary.SourceRepository interface using
+// MongoDB. Sources are keyed by domain, and a lower-cased copy of the name
+// is kept for case-insensitive lookups.
+type SourceRepository struct {
+	collection *mongo.Collection
+}
+
+// sourceDocument is the stored form of a source
+type sourceDocument struct {
+	Domain        string `bson:"_id"`
+	NameKey       string `bson:"namekey"`
+	domain.Source `bson:",inline"`
+}
+
+func newSourceDocument(source *domain.Source) sourceDocument {
+	return sourceDocument{
+		Domain:  source.Domain,
+		NameKey: nameKey(source.Name),
+		Source:  *source,
+	}
+}
+
+func nameKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// NewSourceRepository creates a new MongoDB source repository
+func NewSourceRepository(client *mongo.Client, database string) *SourceRepository {
+	return &SourceRepository{
+		collection: client.Database(database).Collection("sources"),
+	}
+}
+
+// Create stores a new source
+func (r *SourceRepository) Create(ctx context.Context, source *domain.Source) error {
+	_, err := r.collection.InsertOne(ctx, newSourceDocument(source))
+	if mongo.IsDuplicateKeyError(err) {
+		return domain.ErrSourceExists
+	}
+	return err
+}
+
+// Update replaces a registered source if it still has the version it was
+// read with, and increments the version
+func (r *SourceRepository) Update(ctx context.Context, source *domain.Source) error {
+	var version interface{} = source.Version
+	if source.Version == 0 {
+		// Sources registered before versioning have no version field
+		version = bson.M{"$in": bson.A{0, nil}}
+	}
+
+	updated := *source
+	updated.Version++
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": source.Domain, "version": version}, newSourceDocument(&updated))
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		existing, err := r.FindByDomain(ctx, source.Domain)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return domain.ErrSourceNotFound
+		}
+		return domain.ErrSourceConflict
+	}
+	source.Version = updated.Version
+	return nil
+}
+
+// FindByDomain retrieves a source by its domain
+func (r *SourceRepository) FindByDomain(ctx context.Context, domainName string) (*domain.Source, error) {
+	return r.findOne(ctx, bson.M{"_id": domainName})
+}
+
+// FindByName retrieves a source by its display name, ignoring case
+func (r *SourceRepository) FindByName(ctx context.Context, name string) (*domain.Source, error) {
+	return r.findOne(ctx, bson.M{"namekey": nameKey(name)})
+}
+
+func (r *SourceRepository) findOne(ctx context.Context, filter bson.M) (*domain.Source, error) {
+	var document sourceDocument
+	err := r.collection.FindOne(ctx, filter).Decode(&document)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &document.Source, nil
+}
+
+// List retrieves sources ordered by domain with pagination
+func (r *SourceRepository) List(ctx context.Context, limit, offset int) ([]*domain.Source, error) {
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSkip(int64(offset)).
+		SetSort(bson.D{{Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var documents []sourceDocument
+	if err = cursor.All(ctx, &documents); err != nil {
+		return nil, err
+	}
+	sources := make([]*domain.Source, len(documents))
+	for i := range documents {
+		sources[i] = &documents[i].Source
+	}
+	return sources, nil
+}
+
+// Delete removes a source
+func (r *SourceRepository) Delete(ctx context.Context, domainName string) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": domainName})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrSourceNotFound
+	}
+	return nil
+}
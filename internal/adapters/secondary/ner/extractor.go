@@ -0,0 +1,431 @@
+package ner
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/internal/core/ports/secondary"
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+//go:embed gazetteers/en.gaz
+var defaultGazetteer []byte
+
+const month = `(?:Jan(?:uary)?|Feb(?:ruary)?|Mar(?:ch)?|Apr(?:il)?|May|June?|July?|Aug(?:ust)?|Sept?(?:ember)?|Oct(?:ober)?|Nov(?:ember)?|Dec(?:ember)?)`
+const weekday = `(?:Monday|Tuesday|Wednesday|Thursday|Friday|Saturday|Sunday)`
+
+// datePatterns recognise absolute and relative date expressions. When a
+// pattern has a capturing group only the group is reported as the entity.
+var datePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\b` + month + `\.?\s+\d{1,2}(?:st|nd|rd|th)?(?:,?\s+\d{4})?\b`),
+	regexp.MustCompile(`\b\d{1,2}(?:st|nd|rd|th)?\s+(?:of\s+)?` + month + `\.?(?:,?\s+\d{4})?\b`),
+	regexp.MustCompile(`\b` + month + `\s+\d{4}\b`),
+	regexp.MustCompile(`\b\d{4}-\d{2}-\d{2}\b`),
+	regexp.MustCompile(`\b\d{1,2}/\d{1,2}/(?:\d{4}|\d{2})\b`),
+	regexp.MustCompile(`\b(?i:(?:last|next|this)\s+)?` + weekday + `\b`),
+	regexp.MustCompile(`\b(?i:yesterday|today|tomorrow)\b`),
+	regexp.MustCompile(`\b(?i:(?:last|next|this)\s+(?:week|month|year))\b`),
+	regexp.MustCompile(`\b(?:19|20)\d0s\b`),
+	regexp.MustCompile(`\b(?i:in|since|by|until|during|before|after|from)\s+((?:19|20)\d{2})\b`),
+}
+
+// connectors may appear inside a capitalized name ("Bank of England")
+var connectors = map[string]bool{
+	"of": true, "and": true, "the": true, "for": true, "de": true, "del": true,
+	"da": true, "von": true, "van": true, "der": true, "la": true, "le": true, "&": true,
+}
+
+// functionWords are capitalized at the start of a sentence but never begin a
+// name on their own
+var functionWords = map[string]bool{
+	"the": true, "a": true, "an": true, "this": true, "that": true, "these": true,
+	"those": true, "in": true, "on": true, "at": true, "for": true, "but": true,
+	"and": true, "or": true, "if": true, "when": true, "while": true, "after": true,
+	"before": true, "as": true, "it": true, "he": true, "she": true, "they": true,
+	"we": true, "i": true, "his": true, "her": true, "their": true, "our": true,
+	"there": true, "what": true, "why": true, "how": true, "who": true, "some": true,
+	"many": true, "most": true, "according": true, "however": true, "meanwhile": true,
+}
+
+// speechVerbs following an unknown name suggest that it belongs to a person
+var speechVerbs = map[string]bool{
+	"said": true, "says": true, "told": true, "added": true, "argued": true,
+	"claimed": true, "explained": true, "noted": true, "wrote": true, "stated": true,
+}
+
+// Extractor implements rule- and gazetteer-based named entity extraction
+type Extractor struct {
+	gazetteer *Gazetteer
+}
+
+// Ensure Extractor implements secondary.ContentAnalyzer
+var _ secondary.ContentAnalyzer = (*Extractor)(nil)
+
+// NewExtractor creates an entity extractor using the built-in gazetteer
+// extended with the gazetteer files at the given paths
+func NewExtractor(gazetteerPaths ...string) (*Extractor, error) {
+	gazetteer := NewGazetteer()
+	if err := gazetteer.Parse(bytes.NewReader(defaultGazetteer)); err != nil {
+		return nil, fmt.Errorf("failed to load default gazetteer: %w", err)
+	}
+
+	for _, path := range gazetteerPaths {
+		if path == "" {
+			continue
+		}
+		extra, err := LoadGazetteerFile(path)
+		if err != nil {
+			return nil, err
+		}
+		gazetteer.Merge(extra)
+	}
+
+	return NewExtractorWithGazetteer(gazetteer), nil
+}
+
+// NewExtractorWithGazetteer creates an entity extractor using only the given
+// gazetteer
+func NewExtractorWithGazetteer(gazetteer *Gazetteer) *Extractor {
+	return &Extractor{gazetteer: gazetteer}
+}
+
+// mention is a single occurrence of an entity in the text, in byte offsets
+type mention struct {
+	entityType domain.EntityType
+	value      string
+	start      int
+	end        int
+}
+
+// candidate is a capitalized word that could not be classified on its own
+// and may still refer back to an entity mentioned elsewhere
+type candidate struct {
+	text  string
+	start int
+	end   int
+}
+
+// ExtractEntities finds people, places, dates, organizations and products in
+// the text. Mentions of the same entity are aggregated into one
+// domain.Entity with a mention count and the rune offsets of every mention,
+// ordered by first appearance.
+func (e *Extractor) ExtractEntities(ctx context.Context, text string) ([]domain.Entity, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	mentions := findDates(text)
+	covered := func(start, end int) bool {
+		for _, m := range mentions {
+			if start < m.end && end > m.start {
+				return true
+			}
+		}
+		return false
+	}
+
+	tokens := tokenize.Words(text)
+	sentenceStart := sentenceStarts(text, tokens)
+
+	var candidates []candidate
+	for i := 0; i < len(tokens); {
+		if covered(tokens[i].Start, tokens[i].End) {
+			i++
+			continue
+		}
+
+		if m, n := e.matchGazetteer(text, tokens, i); n > 0 {
+			mentions = append(mentions, m)
+			i += n
+			continue
+		}
+
+		if !startsUpper(tokens[i].Text) || functionWords[strings.ToLower(tokens[i].Text)] {
+			i++
+			continue
+		}
+
+		n := capitalizedRun(text, tokens, i)
+		if m, consumed, ok := e.classify(text, tokens, i, n, sentenceStart[i]); ok {
+			mentions = append(mentions, m)
+			n = consumed
+		} else if n == 1 {
+			candidates = append(candidates, candidate{text: tokens[i].Text, start: tokens[i].Start, end: tokens[i].End})
+		}
+		i += n
+	}
+
+	mentions = append(mentions, resolveCandidates(mentions, candidates)...)
+	return aggregate(text, mentions), nil
+}
+
+// AnalyzeSentiment is not supported by the entity extractor
+func (e *Extractor) AnalyzeSentiment(ctx context.Context, text string) (float64, error) {
+	return 0.5, nil
+}
+
+// DetectBias is not supported by the entity extractor
+func (e *Extractor) DetectBias(ctx context.Context, text string) ([]domain.Flag, error) {
+	return nil, nil
+}
+
+// matchGazetteer returns the longest gazetteer match starting at token i and
+// the number of tokens it spans. Known products absorb a trailing model
+// number such as "iPhone 15".
+func (e *Extractor) matchGazetteer(text string, tokens []tokenize.Token, i int) (mention, int) {
+	limit := e.gazetteer.maxLen
+	for n := min(limit, len(tokens)-i); n > 0; n-- {
+		if !joined(text, tokens[i:i+n], ".,") {
+			continue
+		}
+		words := make([]string, n)
+		for k, t := range tokens[i : i+n] {
+			words[k] = t.Text
+		}
+		entry, ok := e.gazetteer.lookup(words)
+		if !ok {
+			continue
+		}
+
+		m := mention{entityType: entry.entityType, value: entry.canonical, start: tokens[i].Start, end: tokens[i+n-1].End}
+		if entry.entityType == domain.EntityTypeProduct && i+n < len(tokens) &&
+			isModelNumber(tokens[i+n].Text) && joined(text, tokens[i+n-1:i+n+1], "") {
+			m.value += " " + tokens[i+n].Text
+			m.end = tokens[i+n].End
+			n++
+		}
+		return m, n
+	}
+	return mention{}, 0
+}
+
+// classify applies the capitalization heuristics to the run of n tokens
+// starting at index i and returns the number of tokens the mention consumed.
+// Person names stop at the first connector so that "Alan Brown of Stanford
+// University" yields the person and leaves the organization to be matched.
+func (e *Extractor) classify(text string, tokens []tokenize.Token, i, n int, atSentenceStart bool) (mention, int, bool) {
+	run := tokens[i : i+n]
+	words := make([]string, n)
+	lower := make([]string, n)
+	for k, t := range run {
+		words[k] = t.Text
+		lower[k] = strings.ToLower(t.Text)
+	}
+	prev := ""
+	if i > 0 {
+		prev = strings.ToLower(tokens[i-1].Text)
+	}
+	next := ""
+	if i+n < len(tokens) {
+		next = strings.ToLower(tokens[i+n].Text)
+	}
+
+	build := func(entityType domain.EntityType, from int) (mention, int, bool) {
+		last := n - 1
+		if entityType == domain.EntityTypePerson {
+			for k := from; k < n; k++ {
+				if connectors[lower[k]] {
+					last = k - 1
+					break
+				}
+			}
+		}
+		return mention{
+			entityType: entityType,
+			value:      text[run[from].Start:run[last].End],
+			start:      run[from].Start,
+			end:        run[last].End,
+		}, last + 1, true
+	}
+
+	switch {
+	case e.gazetteer.PersonTitles[lower[0]] && n > 1:
+		return build(domain.EntityTypePerson, 1)
+	case e.gazetteer.PersonTitles[prev] && !connectors[lower[0]]:
+		return build(domain.EntityTypePerson, 0)
+	case e.gazetteer.OrgSuffixes[lower[n-1]] && n > 1,
+		n > 2 && e.gazetteer.OrgSuffixes[lower[0]] && lower[1] == "of":
+		return build(domain.EntityTypeOrg, 0)
+	case e.gazetteer.PlaceSuffixes[lower[n-1]] && n > 1:
+		return build(domain.EntityTypePlace, 0)
+	case i+n < len(tokens) && isModelNumber(tokens[i+n].Text) && joined(text, tokens[i+n-1:i+n+1], ""):
+		return mention{
+			entityType: domain.EntityTypeProduct,
+			value:      text[run[0].Start:tokens[i+n].End],
+			start:      run[0].Start,
+			end:        tokens[i+n].End,
+		}, n + 1, true
+	case hasInnerUpper(words[0]) && n == 1:
+		return build(domain.EntityTypeProduct, 0)
+	case n == 1 && isAcronym(words[0]) && len(words[0]) <= 6:
+		return build(domain.EntityTypeOrg, 0)
+	case n > 1 && n <= 3 && e.gazetteer.GivenNames[lower[0]]:
+		return build(domain.EntityTypePerson, 0)
+	case n > 1 && n <= 3 && (speechVerbs[next] || prev == "by"):
+		return build(domain.EntityTypePerson, 0)
+	case e.gazetteer.PlaceCues[prev] && n <= 3 && !atSentenceStart:
+		return build(domain.EntityTypePlace, 0)
+	}
+	return mention{}, 0, false
+}
+
+// capitalizedRun returns the number of tokens in the run of capitalized words
+// starting at index i. Connectors are included only when another capitalized
+// word follows them.
+func capitalizedRun(text string, tokens []tokenize.Token, i int) int {
+	n := 1
+	for j := i + 1; j < len(tokens); j++ {
+		if !joined(text, tokens[j-1:j+1], "") {
+			break
+		}
+		word := tokens[j].Text
+		if startsUpper(word) {
+			n = j - i + 1
+			continue
+		}
+		if connectors[strings.ToLower(word)] {
+			continue
+		}
+		break
+	}
+	return n
+}
+
+// findDates returns the non-overlapping date mentions in the text, preferring
+// earlier and then longer matches
+func findDates(text string) []mention {
+	var found []mention
+	for _, pattern := range datePatterns {
+		for _, loc := range pattern.FindAllStringSubmatchIndex(text, -1) {
+			start, end := loc[0], loc[1]
+			if len(loc) >= 4 && loc[2] >= 0 {
+				start, end = loc[2], loc[3]
+			}
+			found = append(found, mention{entityType: domain.EntityTypeDate, value: text[start:end], start: start, end: end})
+		}
+	}
+	sort.Slice(found, func(a, b int) bool {
+		if found[a].start != found[b].start {
+			return found[a].start < found[b].start
+		}
+		return found[a].end > found[b].end
+	})
+
+	var dates []mention
+	for _, m := range found {
+		if len(dates) > 0 && m.start < dates[len(dates)-1].end {
+			continue
+		}
+		dates = append(dates, m)
+	}
+	return dates
+}
+
+// resolveCandidates links unclassified single capitalized words to entities
+// whose names contain them, so "Smith" counts as a mention of "Jane Smith"
+func resolveCandidates(mentions []mention, candidates []candidate) []mention {
+	byPart := make(map[string]mention)
+	for _, m := range mentions {
+		if m.entityType == domain.EntityTypeDate {
+			continue
+		}
+		for _, part := range strings.Fields(m.value) {
+			if _, exists := byPart[part]; !exists && startsUpper(part) {
+				byPart[part] = m
+			}
+		}
+	}
+
+	var resolved []mention
+	for _, c := range candidates {
+		if m, ok := byPart[c.text]; ok {
+			resolved = append(resolved, mention{entityType: m.entityType, value: m.value, start: c.start, end: c.end})
+		}
+	}
+	return resolved
+}
+
+// aggregate groups mentions by entity and converts offsets to runes
+func aggregate(text string, mentions []mention) []domain.Entity {
+	sort.SliceStable(mentions, func(a, b int) bool {
+		return mentions[a].start < mentions[b].start
+	})
+
+	index := make(map[string]int)
+	entities := make([]domain.Entity, 0)
+	for _, m := range mentions {
+		key := string(m.entityType) + "\x00" + m.value
+		pos, ok := index[key]
+		if !ok {
+			pos = len(entities)
+			index[key] = pos
+			entities = append(entities, domain.Entity{Type: m.entityType, Value: m.value})
+		}
+		start, end := tokenize.RuneOffsets(text, m.start, m.end)
+		entities[pos].Count++
+		entities[pos].Mentions = append(entities[pos].Mentions, domain.Span{Start: start, End: end})
+	}
+	return entities
+}
+
+// sentenceStarts marks the tokens that begin a sentence
+func sentenceStarts(text string, tokens []tokenize.Token) []bool {
+	starts := make([]bool, len(tokens))
+	sentences := tokenize.Sentences(text)
+	s := 0
+	for i, t := range tokens {
+		for s < len(sentences) && sentences[s].End <= t.Start {
+			s++
+		}
+		if s < len(sentences) && (i == 0 || tokens[i-1].End <= sentences[s].Start) {
+			starts[i] = true
+		}
+	}
+	return starts
+}
+
+// joined reports whether consecutive tokens are separated only by spaces and
+// the given extra punctuation characters
+func joined(text string, tokens []tokenize.Token, extra string) bool {
+	for k := 1; k < len(tokens); k++ {
+		gap := text[tokens[k-1].End:tokens[k].Start]
+		if strings.TrimFunc(gap, func(r rune) bool {
+			return r == ' ' || strings.ContainsRune(extra, r)
+		}) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// isModelNumber reports whether a token looks like a product model or
+// version, such as "15", "S24" or "X1"
+func isModelNumber(word string) bool {
+	hasDigit := false
+	for _, r := range word {
+		if unicode.IsDigit(r) {
+			hasDigit = true
+		} else if !unicode.IsUpper(r) {
+			return false
+		}
+	}
+	return hasDigit && len(word) <= 5
+}
+
+// hasInnerUpper reports whether a word has an upper-case letter after its
+// first position, as in brand names like "PlayStation" or "YouTube"
+func hasInnerUpper(word string) bool {
+	for i, r := range word {
+		if i > 0 && unicode.IsUpper(r) {
+			return !isAcronym(word)
+		}
+	}
+	return false
+}
@@ -0,0 +1,205 @@
+package ner
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+// Gazetteer holds known entity names and the cue words used by the
+// capitalization heuristics
+type Gazetteer struct {
+	entries map[string][]gazetteerEntry
+	maxLen  int
+
+	GivenNames    map[string]bool
+	PersonTitles  map[string]bool
+	OrgSuffixes   map[string]bool
+	PlaceCues     map[string]bool
+	PlaceSuffixes map[string]bool
+}
+
+// gazetteerEntry is a single alias of a known entity
+type gazetteerEntry struct {
+	entityType domain.EntityType
+	canonical  string
+	tokens     []string // alias tokens in their original case
+}
+
+// NewGazetteer creates an empty gazetteer
+func NewGazetteer() *Gazetteer {
+	return &Gazetteer{
+		entries:       make(map[string][]gazetteerEntry),
+		GivenNames:    make(map[string]bool),
+		PersonTitles:  make(map[string]bool),
+		OrgSuffixes:   make(map[string]bool),
+		PlaceCues:     make(map[string]bool),
+		PlaceSuffixes: make(map[string]bool),
+	}
+}
+
+// LoadGazetteerFile reads a gazetteer from a file on disk
+func LoadGazetteerFile(path string) (*Gazetteer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open gazetteer: %w", err)
+	}
+	defer f.Close()
+
+	gazetteer := NewGazetteer()
+	if err := gazetteer.Parse(f); err != nil {
+		return nil, fmt.Errorf("failed to parse gazetteer %s: %w", path, err)
+	}
+	return gazetteer, nil
+}
+
+// Parse reads gazetteer entries in the sectioned text format and adds them to
+// the gazetteer. Entity sections are named after a domain.EntityType and hold
+// one entity per line as "Canonical Name | Alias | Alias"; cue sections hold
+// one word per line.
+func (g *Gazetteer) Parse(r io.Reader) error {
+	section := ""
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.Trim(line, "[]")
+			continue
+		}
+
+		switch section {
+		case string(domain.EntityTypePerson), string(domain.EntityTypePlace),
+			string(domain.EntityTypeOrg), string(domain.EntityTypeProduct):
+			names := strings.Split(line, "|")
+			canonical := strings.TrimSpace(names[0])
+			for _, name := range names {
+				g.Add(domain.EntityType(section), canonical, strings.TrimSpace(name))
+			}
+		case "given_names":
+			g.GivenNames[strings.ToLower(line)] = true
+		case "person_titles":
+			g.PersonTitles[strings.ToLower(line)] = true
+		case "org_suffixes":
+			g.OrgSuffixes[strings.ToLower(line)] = true
+		case "place_cues":
+			g.PlaceCues[strings.ToLower(line)] = true
+		case "place_suffixes":
+			g.PlaceSuffixes[strings.ToLower(line)] = true
+		default:
+			return fmt.Errorf("line %d: entry outside of a known section", lineNo)
+		}
+	}
+	return scanner.Err()
+}
+
+// Add registers an alias for a known entity
+func (g *Gazetteer) Add(entityType domain.EntityType, canonical, alias string) {
+	words := tokenize.Words(alias)
+	if len(words) == 0 {
+		return
+	}
+	tokens := make([]string, len(words))
+	for i, w := range words {
+		tokens[i] = w.Text
+	}
+
+	key := lookupKey(tokens)
+	g.entries[key] = append(g.entries[key], gazetteerEntry{
+		entityType: entityType,
+		canonical:  canonical,
+		tokens:     tokens,
+	})
+	if len(tokens) > g.maxLen {
+		g.maxLen = len(tokens)
+	}
+}
+
+// Merge copies every entry of other into the gazetteer
+func (g *Gazetteer) Merge(other *Gazetteer) {
+	for key, entries := range other.entries {
+		g.entries[key] = append(g.entries[key], entries...)
+	}
+	if other.maxLen > g.maxLen {
+		g.maxLen = other.maxLen
+	}
+	for _, pair := range []struct{ dst, src map[string]bool }{
+		{g.GivenNames, other.GivenNames},
+		{g.PersonTitles, other.PersonTitles},
+		{g.OrgSuffixes, other.OrgSuffixes},
+		{g.PlaceCues, other.PlaceCues},
+		{g.PlaceSuffixes, other.PlaceSuffixes},
+	} {
+		for k := range pair.src {
+			pair.dst[k] = true
+		}
+	}
+}
+
+// lookup returns the entry matching the given text tokens, if any. Acronyms
+// and aliases starting with a lower-case letter (such as "iPhone") must match
+// exactly; other aliases match case-insensitively as long as the text is
+// capitalized.
+func (g *Gazetteer) lookup(tokens []string) (gazetteerEntry, bool) {
+	for _, entry := range g.entries[lookupKey(tokens)] {
+		if equalTokens(entry.tokens, tokens) {
+			return entry, true
+		}
+		if isAcronym(strings.Join(entry.tokens, "")) || !startsUpper(entry.tokens[0]) {
+			continue
+		}
+		if startsUpper(tokens[0]) {
+			return entry, true
+		}
+	}
+	return gazetteerEntry{}, false
+}
+
+func lookupKey(tokens []string) string {
+	lower := make([]string, len(tokens))
+	for i, t := range tokens {
+		lower[i] = strings.ToLower(t)
+	}
+	return strings.Join(lower, " ")
+}
+
+func equalTokens(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func startsUpper(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsUpper(r)
+}
+
+// isAcronym reports whether s consists of two or more upper-case letters
+func isAcronym(s string) bool {
+	if utf8.RuneCountInString(s) < 2 {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsUpper(r) {
+			return false
+		}
+	}
+	return true
+}
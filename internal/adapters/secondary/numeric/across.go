@@ -0,0 +1,191 @@
+package numeric
+
+import (
+	"fmt"
+	"strings"
+)
+
+// checkPercentSumAcross reports shares of a whole spread over consecutive
+// sentences, as in "45% support the plan. 40% oppose it. 25% are
+// undecided.", that add up to more than 100
+func checkPercentSumAcross(sentences []sentence) []string {
+	var found []string
+	var run []sentence
+	flush := func() {
+		if details, ok := percentSumOfRun(run); ok {
+			found = append(found, details)
+		}
+		run = nil
+	}
+	for _, s := range sentences {
+		if !hasPercent(s) || !containsAny(s.lower, breakdownCues) || hasChangeWord(s.lower) {
+			flush()
+			continue
+		}
+		run = append(run, s)
+	}
+	flush()
+	return found
+}
+
+// percentSumOfRun sums the percentages of a run of breakdown sentences.
+// Runs in which a single sentence already overshoots are left to
+// checkPercentSum.
+func percentSumOfRun(run []sentence) (string, bool) {
+	if len(run) < 2 {
+		return "", false
+	}
+	var parts []quantity
+	texts := make([]string, len(run))
+	for i, s := range run {
+		if _, _, ok := checkPercentSum(s); ok {
+			return "", false
+		}
+		for _, q := range s.quantities {
+			if q.unit != "%" {
+				continue
+			}
+			if q.value > 100 {
+				return "", false
+			}
+			parts = append(parts, q)
+		}
+		texts[i] = fmt.Sprintf("%q", s.text)
+	}
+	sum := sumOf(parts)
+	if len(parts) < 3 || sum <= 100+percentSumTolerance {
+		return "", false
+	}
+	return fmt.Sprintf("shares %s add up to %s%% across %s",
+		joinText(parts), formatNumber(sum), strings.Join(texts, ", ")), true
+}
+
+// statedTotal is a total and the sentence that states it
+type statedTotal struct {
+	quantity quantity
+	sentence sentence
+}
+
+// checkTotalAcross reports parts in one sentence that exceed a total stated
+// in an earlier one. The total is either the last figure of the preceding
+// sentence, for a sentence opening with "Of them" or the like, or the most
+// recent figure marked as a total, as in "a total of 500 workers", that
+// counts the same thing as the parts.
+func checkTotalAcross(sentences []sentence) []string {
+	var found []string
+	totals := make(map[string]statedTotal)
+	for i, s := range sentences {
+		switch {
+		case i > 0 && hasPrefixAny(s.lower, anaphoraCues):
+			previous := sentences[i-1]
+			total, ok := lastCount(previous)
+			if !ok {
+				break
+			}
+			var parts []quantity
+			for _, q := range s.quantities {
+				if isCount(q) && (q.unit == "" || q.unit == total.unit) {
+					parts = append(parts, q)
+				}
+			}
+			if details, ok := exceedsTotal(parts, statedTotal{total, previous}, s); ok {
+				found = append(found, details)
+			}
+
+		case !containsAny(s.lower, partsAfterCues) && !containsAny(s.lower, sumCues):
+			// Sentences with their own total are left to checkTotal
+			byUnit := make(map[string][]quantity)
+			var units []string
+			for _, q := range s.quantities {
+				if !isCount(q) || q.unit == "" {
+					continue
+				}
+				if _, ok := byUnit[q.unit]; !ok {
+					units = append(units, q.unit)
+				}
+				byUnit[q.unit] = append(byUnit[q.unit], q)
+			}
+			for _, unit := range units {
+				total, ok := totals[unit]
+				if !ok {
+					continue
+				}
+				if details, ok := exceedsTotal(byUnit[unit], total, s); ok {
+					found = append(found, details)
+				}
+			}
+		}
+
+		for _, q := range s.quantities {
+			if isCount(q) && q.unit != "" && isStatedTotal(s, q) {
+				totals[q.unit] = statedTotal{quantity: q, sentence: s}
+			}
+		}
+	}
+	return found
+}
+
+// exceedsTotal reports two or more parts, each smaller than the total, that
+// add up to more than it
+func exceedsTotal(parts []quantity, total statedTotal, s sentence) (string, bool) {
+	if len(parts) < 2 {
+		return "", false
+	}
+	for _, q := range parts {
+		if q.value >= total.quantity.value {
+			return "", false
+		}
+	}
+	sum := sumOf(parts)
+	if sum <= total.quantity.value*1.005 {
+		return "", false
+	}
+	return fmt.Sprintf("parts %s in %q add up to %s, more than the total of %s in %q",
+		joinText(parts), s.text, formatNumber(sum), total.quantity.text, total.sentence.text), true
+}
+
+// isStatedTotal reports whether a quantity is marked as a total, as in "a
+// total of 500 workers" or "500 workers in all"
+func isStatedTotal(s sentence, q quantity) bool {
+	before := " " + strings.TrimSpace(s.lower[:q.start])
+	after := strings.TrimSpace(s.lower[q.end:]) + " "
+	for _, cue := range totalBeforeCues {
+		if strings.HasSuffix(before, " "+cue) {
+			return true
+		}
+	}
+	for _, cue := range totalAfterCues {
+		if strings.HasPrefix(after, cue+" ") || strings.HasPrefix(after, cue+",") || strings.HasPrefix(after, cue+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// lastCount returns the last count in a sentence
+func lastCount(s sentence) (quantity, bool) {
+	for i := len(s.quantities) - 1; i >= 0; i-- {
+		if q := s.quantities[i]; isCount(q) && q.value > 0 {
+			return q, true
+		}
+	}
+	return quantity{}, false
+}
+
+func hasPercent(s sentence) bool {
+	for _, q := range s.quantities {
+		if q.unit == "%" {
+			return true
+		}
+	}
+	return false
+}
+
+func hasPrefixAny(lower string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(lower, p+" ") || strings.HasPrefix(lower, p+",") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,402 @@
+package numeric
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/internal/core/ports/secondary"
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+// CheckerName identifies flags raised by the numeric consistency checker
+const CheckerName = "numeric_checker"
+
+// Confidences of the flags raised by each check
+const (
+	percentSumConfidence = 0.7
+	multiplierConfidence = 0.75
+	changeConfidence     = 0.7
+	totalConfidence      = 0.65
+	// crossSentenceConfidence is lower than the single-sentence checks,
+	// since figures further apart are less certain to describe the same
+	// whole
+	crossSentenceConfidence = 0.55
+)
+
+const (
+	// percentSumTolerance allows for rounding when percentages of a whole
+	// are summed
+	percentSumTolerance = 1.5
+	// multiplierTolerance is the relative deviation allowed between a stated
+	// multiple such as "doubled" and the figures given
+	multiplierTolerance = 0.15
+	// minChangeTolerance is the percentage-point deviation always allowed
+	// between a stated percentage change and the figures given
+	minChangeTolerance = 1.5
+)
+
+var (
+	multiplierWords = map[string]float64{
+		"doubled": 2, "doubling": 2, "doubles": 2, "double": 2,
+		"tripled": 3, "tripling": 3, "triples": 3, "triple": 3,
+		"quadrupled": 4, "quadrupling": 4, "quadruples": 4,
+		"halved": 0.5, "halving": 0.5, "halves": 0.5,
+		"twofold": 2, "threefold": 3, "fourfold": 4, "fivefold": 5, "tenfold": 10,
+	}
+	foldPattern = regexp.MustCompile(`(?i)\b(\d+)-fold\b`)
+
+	increaseWords = map[string]bool{
+		"rose": true, "risen": true, "rise": true, "rises": true, "increased": true,
+		"increase": true, "increases": true, "grew": true, "grown": true, "grow": true,
+		"jumped": true, "climbed": true, "gained": true, "surged": true, "up": true,
+		"soared": true, "higher": true,
+	}
+	decreaseWords = map[string]bool{
+		"fell": true, "fallen": true, "fall": true, "falls": true, "dropped": true,
+		"drop": true, "decreased": true, "decrease": true, "declined": true,
+		"decline": true, "shrank": true, "down": true, "slumped": true, "plunged": true,
+		"lower": true, "cut": true,
+	}
+
+	// breakdownCues mark a sentence that splits a whole into shares
+	breakdownCues = []string{
+		"of respondents", "of voters", "of those", "of people", "of the vote",
+		"of participants", "surveyed", "polled", "said they", "support", "oppose",
+		"undecided", "share", "split", "while", "the rest", "remaining",
+	}
+
+	// partsAfterCues introduce some of the parts of the preceding total
+	partsAfterCues = []string{"of whom", "of which", "including", "among them", ":"}
+	// sumCues introduce a total that the preceding parts add up to
+	sumCues = []string{"for a total of", "totaling", "totalling", "a combined", "in total", "altogether", "in all"}
+
+	// totalBeforeCues and totalAfterCues mark a quantity as a total that
+	// later sentences may break down
+	totalBeforeCues = []string{"a total of", "total of", "totaling", "totalling", "a combined", "all"}
+	totalAfterCues  = []string{"in total", "in all", "altogether", "overall", "combined"}
+	// anaphoraCues open a sentence that breaks down the last figure of the
+	// preceding sentence
+	anaphoraCues = []string{"of them", "of those", "of these", "of whom", "of which", "among them", "among those"}
+)
+
+// Checker implements secondary.ConsistencyChecker by cross-checking the
+// quantities an article states
+type Checker struct{}
+
+// Ensure Checker implements secondary.ConsistencyChecker
+var _ secondary.ConsistencyChecker = (*Checker)(nil)
+
+// NewChecker creates a numeric consistency checker
+func NewChecker() *Checker {
+	return &Checker{}
+}
+
+// sentence is a body sentence with its quantities
+type sentence struct {
+	text       string
+	lower      string
+	quantities []quantity
+}
+
+// CheckConsistency returns a FACTUAL_ERROR flag for every set of figures
+// that contradict each other: percentages of a whole summing past 100,
+// multiples such as "doubled" or stated percentage changes that the from/to
+// figures do not bear out, and parts that exceed or fail to add up to their
+// stated total. Shares and totals are also compared across sentences, so a
+// total given early in the article is checked against a breakdown given
+// later; those flags cite every sentence involved. The cue words are
+// English, so other languages are skipped.
+func (c *Checker) CheckConsistency(ctx context.Context, article *domain.Article) ([]domain.Flag, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if language := article.MetaData.Language; language != "" && language != "en" {
+		return nil, nil
+	}
+
+	var sentences []sentence
+	for _, t := range tokenize.Sentences(article.AnalyzedText()) {
+		sentences = append(sentences, sentence{text: t.Text, lower: strings.ToLower(t.Text), quantities: extractQuantities(t.Text)})
+	}
+
+	var flags []domain.Flag
+	for _, s := range sentences {
+		if len(s.quantities) < 2 {
+			continue
+		}
+		for _, check := range []func(sentence) (float64, string, bool){checkPercentSum, checkMultiplier, checkChange, checkTotal} {
+			if confidence, reason, ok := check(s); ok {
+				flags = append(flags, domain.Flag{
+					Type:       domain.FlagTypeFactualError,
+					Confidence: confidence,
+					Details:    fmt.Sprintf("%s in %q", reason, s.text),
+					DetectedBy: CheckerName,
+				})
+			}
+		}
+	}
+
+	for _, check := range []func([]sentence) []string{checkPercentSumAcross, checkTotalAcross} {
+		for _, details := range check(sentences) {
+			flags = append(flags, domain.Flag{
+				Type:       domain.FlagTypeFactualError,
+				Confidence: crossSentenceConfidence,
+				Details:    details,
+				DetectedBy: CheckerName,
+			})
+		}
+	}
+	return flags, nil
+}
+
+// checkPercentSum reports three or more percentages describing shares of a
+// whole that add up to more than 100
+func checkPercentSum(s sentence) (float64, string, bool) {
+	if !containsAny(s.lower, breakdownCues) || hasChangeWord(s.lower) {
+		return 0, "", false
+	}
+	var parts []string
+	sum := 0.0
+	for _, q := range s.quantities {
+		if q.unit != "%" {
+			continue
+		}
+		if q.value > 100 {
+			return 0, "", false
+		}
+		sum += q.value
+		parts = append(parts, q.text)
+	}
+	if len(parts) < 3 || sum <= 100+percentSumTolerance {
+		return 0, "", false
+	}
+	return percentSumConfidence, fmt.Sprintf("shares %s add up to %s%%", strings.Join(parts, ", "), formatNumber(sum)), true
+}
+
+// checkMultiplier compares a stated multiple with the from/to figures
+func checkMultiplier(s sentence) (float64, string, bool) {
+	word, factor := "", 0.0
+	for _, t := range tokenize.Words(s.lower) {
+		if f, ok := multiplierWords[t.Text]; ok {
+			word, factor = t.Text, f
+			break
+		}
+	}
+	if m := foldPattern.FindStringSubmatch(s.text); m != nil && word == "" {
+		fmt.Sscanf(m[1], "%g", &factor)
+		word = m[0]
+	}
+	if factor == 0 {
+		return 0, "", false
+	}
+
+	from, to, ok := fromTo(s)
+	if !ok || from.value == 0 {
+		return 0, "", false
+	}
+	ratio := to.value / from.value
+	if math.Abs(ratio-factor)/factor <= multiplierTolerance {
+		return 0, "", false
+	}
+	return multiplierConfidence, fmt.Sprintf("%q but the figures go from %s to %s (%s)",
+		word, from.text, to.text, describeChange(from.value, to.value)), true
+}
+
+// checkChange compares a stated percentage change with the from/to figures
+func checkChange(s sentence) (float64, string, bool) {
+	from, to, ok := fromTo(s)
+	if !ok || from.value == 0 {
+		return 0, "", false
+	}
+
+	var stated *quantity
+	for i, q := range s.quantities {
+		if (q.unit == "%" || q.unit == "pp") && q.start != from.start && q.start != to.start {
+			stated = &s.quantities[i]
+			break
+		}
+	}
+	if stated == nil {
+		return 0, "", false
+	}
+
+	direction := 0.0
+	switch {
+	case hasWord(s.lower, increaseWords):
+		direction = 1
+	case hasWord(s.lower, decreaseWords):
+		direction = -1
+	default:
+		return 0, "", false
+	}
+
+	var actual, claimed float64
+	switch {
+	case stated.unit == "pp" && from.unit == "%":
+		actual, claimed = to.value-from.value, direction*stated.value
+	case from.unit == "%":
+		// A bare percentage change between two percentages is ambiguous
+		// between relative change and percentage points
+		return 0, "", false
+	default:
+		actual, claimed = (to.value-from.value)/from.value*100, direction*stated.value
+	}
+
+	tolerance := math.Max(minChangeTolerance, 0.1*math.Abs(claimed))
+	if math.Abs(actual-claimed) <= tolerance {
+		return 0, "", false
+	}
+	return changeConfidence, fmt.Sprintf("stated change of %s does not match the figures %s to %s (%s)",
+		stated.text, from.text, to.text, describeChange(from.value, to.value)), true
+}
+
+// checkTotal compares a stated total with its parts
+func checkTotal(s sentence) (float64, string, bool) {
+	// A total followed by some of its parts: the parts cannot exceed it. The
+	// parts may count subgroups, as in "1,200 people, including 300 children".
+	for _, cue := range partsAfterCues {
+		i := strings.Index(s.lower, cue)
+		if i < 0 {
+			continue
+		}
+		var total *quantity
+		var parts []quantity
+		for k, q := range s.quantities {
+			if q.end <= i {
+				total = &s.quantities[k]
+			} else if total != nil && (q.unit == total.unit || isCount(*total) && isCount(q)) {
+				parts = append(parts, q)
+			}
+		}
+		if total == nil || len(parts) < 2 {
+			continue
+		}
+		if sum := sumOf(parts); sum > total.value*1.005 {
+			return totalConfidence, fmt.Sprintf("parts %s add up to %s, more than the total of %s",
+				joinText(parts), formatNumber(sum), total.text), true
+		}
+		return 0, "", false
+	}
+
+	// Parts followed by the total they add up to
+	for _, cue := range sumCues {
+		i := strings.Index(s.lower, cue)
+		if i < 0 {
+			continue
+		}
+		var total *quantity
+		var parts []quantity
+		for k, q := range s.quantities {
+			if q.start < i {
+				parts = append(parts, q)
+			} else if total == nil {
+				total = &s.quantities[k]
+			}
+		}
+		if total == nil {
+			// "in total" and "altogether" follow the total itself
+			if len(parts) < 3 {
+				return 0, "", false
+			}
+			total, parts = &parts[len(parts)-1], parts[:len(parts)-1]
+		}
+		parts = compatibleWith(*total, parts)
+		if len(parts) < 2 {
+			return 0, "", false
+		}
+		sum := sumOf(parts)
+		if math.Abs(sum-total.value) <= total.value*0.005 {
+			return 0, "", false
+		}
+		return totalConfidence, fmt.Sprintf("parts %s add up to %s, not the stated total of %s",
+			joinText(parts), formatNumber(sum), total.text), true
+	}
+	return 0, "", false
+}
+
+// fromTo finds a "from A to B" pair of compatible quantities
+func fromTo(s sentence) (quantity, quantity, bool) {
+	for i := 0; i+1 < len(s.quantities); i++ {
+		a, b := s.quantities[i], s.quantities[i+1]
+		before := strings.TrimSpace(s.lower[:a.start])
+		between := strings.TrimSpace(s.lower[a.end:b.start])
+		if strings.HasSuffix(before, "from") && between == "to" {
+			if a.unit == "" {
+				a.unit = b.unit
+			}
+			if compatible(a, b) {
+				return a, b, true
+			}
+		}
+	}
+	return quantity{}, quantity{}, false
+}
+
+func compatibleWith(total quantity, parts []quantity) []quantity {
+	var kept []quantity
+	for _, q := range parts {
+		if compatible(total, q) {
+			kept = append(kept, q)
+		}
+	}
+	return kept
+}
+
+func sumOf(quantities []quantity) float64 {
+	sum := 0.0
+	for _, q := range quantities {
+		sum += q.value
+	}
+	return sum
+}
+
+func joinText(quantities []quantity) string {
+	texts := make([]string, len(quantities))
+	for i, q := range quantities {
+		texts[i] = q.text
+	}
+	return strings.Join(texts, ", ")
+}
+
+// describeChange summarises the change between two values as a percentage
+// and, for larger changes, a multiple
+func describeChange(from, to float64) string {
+	change := (to - from) / from * 100
+	if to/from >= 1.5 {
+		return fmt.Sprintf("%+.0f%%, %.1fx", change, to/from)
+	}
+	return fmt.Sprintf("%+.0f%%", change)
+}
+
+func formatNumber(x float64) string {
+	if x == math.Trunc(x) {
+		return fmt.Sprintf("%.0f", x)
+	}
+	return fmt.Sprintf("%.1f", x)
+}
+
+func hasChangeWord(lower string) bool {
+	return hasWord(lower, increaseWords) || hasWord(lower, decreaseWords)
+}
+
+func hasWord(lower string, words map[string]bool) bool {
+	for _, t := range tokenize.Words(lower) {
+		if words[t.Text] {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(lower string, phrases []string) bool {
+	for _, p := range phrases {
+		if strings.Contains(lower, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package numeric
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// quantity is a number mentioned in a sentence, with its scale and unit
+// applied
+type quantity struct {
+	text  string  // the quantity as written
+	value float64 // value after applying the scale word
+	unit  string  // "%", "pp", a currency symbol, a singular unit noun or ""
+	start int     // byte offset in the sentence
+	end   int
+}
+
+var (
+	quantityPattern = regexp.MustCompile(`(?i)(?:([$€£¥])\s?)?(\d{1,3}(?:,\d{3})+|\d+)(?:\.(\d+))?` +
+		`(?:\s?(thousand|million|billion|trillion|bn)\b|(\s?)(m|k)\b)?` +
+		`(?:\s?(%|percent\b|per cent\b|percentage points?\b))?`)
+	unitWordPattern = regexp.MustCompile(`^\s+([a-zA-Z]+)`)
+
+	scales = map[string]float64{
+		"thousand": 1e3, "k": 1e3, "million": 1e6, "m": 1e6,
+		"billion": 1e9, "bn": 1e9, "trillion": 1e12,
+	}
+
+	months = map[string]bool{
+		"january": true, "february": true, "march": true, "april": true, "may": true,
+		"june": true, "july": true, "august": true, "september": true, "october": true,
+		"november": true, "december": true, "jan": true, "feb": true, "mar": true,
+		"apr": true, "jun": true, "jul": true, "aug": true, "sep": true, "sept": true,
+		"oct": true, "nov": true, "dec": true,
+	}
+
+	// unitStopwords follow numbers without being their unit
+	unitStopwords = map[string]bool{
+		"a": true, "an": true, "the": true, "and": true, "or": true, "to": true,
+		"of": true, "in": true, "on": true, "at": true, "for": true, "from": true,
+		"by": true, "with": true, "per": true, "is": true, "are": true, "was": true,
+		"were": true, "more": true, "less": true, "than": true, "percent": true,
+		"times": true, "year": true, "years": true, "over": true, "as": true,
+	}
+)
+
+// extractQuantities returns the quantities in a sentence in order. Years,
+// day numbers after month names and numbers inside words are skipped.
+func extractQuantities(sentence string) []quantity {
+	var quantities []quantity
+	for _, m := range quantityPattern.FindAllStringSubmatchIndex(sentence, -1) {
+		start, end := m[0], m[1]
+		// Skip digits glued to letters, as in "COVID-19" or "G7"
+		if start > 0 && isWordByte(sentence[start-1]) && m[2] < 0 {
+			continue
+		}
+		if end < len(sentence) && isLetterByte(sentence[end]) {
+			continue
+		}
+
+		digits := strings.ReplaceAll(sentence[m[4]:m[5]], ",", "")
+		if m[6] >= 0 {
+			digits += "." + sentence[m[6]:m[7]]
+		}
+		value, err := strconv.ParseFloat(digits, 64)
+		if err != nil {
+			continue
+		}
+
+		// "m" and "k" abbreviate million and thousand after a currency sign
+		// or glued to the number, as in "$10 m" or "5k"; otherwise they are
+		// units such as metres and are left for the unit word
+		if m[12] >= 0 && m[11] > m[10] && m[2] < 0 {
+			m[12], m[13], m[14], m[15] = -1, -1, -1, -1
+			end = m[10]
+		}
+
+		q := quantity{value: value, start: start, end: end}
+		switch {
+		case m[8] >= 0:
+			q.value *= scales[strings.ToLower(sentence[m[8]:m[9]])]
+		case m[12] >= 0:
+			q.value *= scales[strings.ToLower(sentence[m[12]:m[13]])]
+		}
+		switch {
+		case m[14] >= 0 && strings.HasPrefix(strings.ToLower(sentence[m[14]:m[15]]), "percentage"):
+			q.unit = "pp"
+		case m[14] >= 0:
+			q.unit = "%"
+		case m[2] >= 0:
+			q.unit = sentence[m[2]:m[3]]
+		default:
+			if isYear(sentence, m) || followsMonth(sentence[:start]) {
+				continue
+			}
+			if u := unitWordPattern.FindStringSubmatchIndex(sentence[end:]); u != nil {
+				word := strings.ToLower(sentence[end+u[2] : end+u[3]])
+				if !unitStopwords[word] {
+					q.unit = singular(word)
+					q.end = end + u[3]
+				}
+			}
+		}
+		q.text = strings.TrimSpace(sentence[start:q.end])
+		quantities = append(quantities, q)
+	}
+	return quantities
+}
+
+// isYear reports whether a bare four-digit number looks like a year
+func isYear(sentence string, m []int) bool {
+	if m[6] >= 0 || m[8] >= 0 || m[12] >= 0 || m[5]-m[4] != 4 {
+		return false
+	}
+	year, _ := strconv.Atoi(sentence[m[4]:m[5]])
+	return year >= 1800 && year <= 2100
+}
+
+// followsMonth reports whether the text before a number ends with a month
+// name, as in "March 3"
+func followsMonth(before string) bool {
+	fields := strings.Fields(before)
+	if len(fields) == 0 {
+		return false
+	}
+	return months[strings.ToLower(strings.Trim(fields[len(fields)-1], ".,"))]
+}
+
+// singular strips a plural ending from a unit noun
+func singular(word string) string {
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "ss"):
+		return word
+	case strings.HasSuffix(word, "s") && len(word) > 3:
+		return strings.TrimSuffix(word, "s")
+	}
+	return word
+}
+
+// compatible reports whether two quantities measure the same kind of thing.
+// A quantity without a unit word is compatible with any count.
+func compatible(a, b quantity) bool {
+	if a.unit == b.unit {
+		return true
+	}
+	return isCount(a) && isCount(b) && (a.unit == "" || b.unit == "")
+}
+
+// isCount reports whether a quantity is neither a percentage nor money
+func isCount(q quantity) bool {
+	switch q.unit {
+	case "%", "pp", "$", "€", "£", "¥":
+		return false
+	}
+	return true
+}
+
+func isWordByte(b byte) bool {
+	return isLetterByte(b) || b == '-' || (b >= '0' && b <= '9')
+}
+
+func isLetterByte(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
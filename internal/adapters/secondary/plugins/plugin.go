@@ -0,0 +1,443 @@
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/internal/core/ports/secondary"
+	"github.com/reality-filter/pkg/plugin"
+)
+
+// HostName is announced to plugins in the handshake
+const HostName = "reality-filter"
+
+// Config controls how plugins are run
+type Config struct {
+	// CallTimeout bounds every call. A plugin that misses it is killed and
+	// restarted on the next call.
+	CallTimeout time.Duration
+	// StartTimeout bounds the handshake of a freshly started plugin
+	StartTimeout time.Duration
+	// MinBackoff and MaxBackoff bound the wait before restarting a plugin
+	// that keeps failing. The first restart after a crash is immediate.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// Stderr receives the plugins' standard error
+	Stderr io.Writer
+}
+
+// DefaultConfig returns the configuration used when none is given
+func DefaultConfig() Config {
+	return Config{
+		CallTimeout:  5 * time.Second,
+		StartTimeout: 10 * time.Second,
+		MinBackoff:   time.Second,
+		MaxBackoff:   time.Minute,
+		Stderr:       os.Stderr,
+	}
+}
+
+// errCallTimeout is returned when a plugin misses its call deadline
+var errCallTimeout = errors.New("plugin did not respond in time")
+
+// Plugin is a ContentAnalyzer and FactChecker backed by an external plugin
+// executable. Methods the plugin does not implement return neutral results.
+// Calls are serialised, since the protocol allows one request in flight.
+type Plugin struct {
+	path   string
+	config Config
+
+	mu           sync.Mutex
+	proc         *process
+	info         plugin.HandshakeResult
+	capabilities map[string]bool
+	nextID       uint64
+	restarts     int
+	failures     int
+	retryAt      time.Time
+	closed       bool
+}
+
+// Ensure Plugin implements the ContentAnalyzer and FactChecker interfaces
+var (
+	_ secondary.ContentAnalyzer = (*Plugin)(nil)
+	_ secondary.FactChecker     = (*Plugin)(nil)
+)
+
+// Start launches the plugin executable at path and performs the handshake
+func Start(ctx context.Context, path string, config Config) (*Plugin, error) {
+	p := &Plugin{
+		path:   path,
+		config: config,
+	}
+	if err := p.start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to start plugin %s: %w", path, err)
+	}
+	return p, nil
+}
+
+// LoadDir starts every executable file in dir, in name order. Hidden files
+// and subdirectories are ignored.
+func LoadDir(ctx context.Context, dir string, config Config) ([]*Plugin, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin directory: %w", err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var loaded []*Plugin
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat plugin %s: %w", entry.Name(), err)
+		}
+		if !info.Mode().IsRegular() || info.Mode().Perm()&0o111 == 0 {
+			continue
+		}
+
+		p, err := Start(ctx, filepath.Join(dir, entry.Name()), config)
+		if err != nil {
+			for _, started := range loaded {
+				started.Close()
+			}
+			return nil, err
+		}
+		loaded = append(loaded, p)
+	}
+	return loaded, nil
+}
+
+// Name returns the name the plugin announced in the handshake
+func (p *Plugin) Name() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.info.Name
+}
+
+// Info returns the plugin's latest handshake
+func (p *Plugin) Info() plugin.HandshakeResult {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.info
+}
+
+// Restarts returns how many times the plugin has been restarted
+func (p *Plugin) Restarts() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.restarts
+}
+
+// Supports reports whether the plugin implements the given protocol method
+func (p *Plugin) Supports(method string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.capabilities[method]
+}
+
+// AnalyzeSentiment asks the plugin for a sentiment score between 0 and 1
+func (p *Plugin) AnalyzeSentiment(ctx context.Context, text string) (float64, error) {
+	if !p.Supports(plugin.MethodAnalyzeSentiment) {
+		return 0.5, nil
+	}
+
+	var result plugin.SentimentResult
+	if err := p.call(ctx, plugin.MethodAnalyzeSentiment, textParams(ctx, text), &result); err != nil {
+		return 0, err
+	}
+	if result.Score < 0 || result.Score > 1 {
+		return 0, fmt.Errorf("plugin %s returned sentiment %v outside [0, 1]", p.Name(), result.Score)
+	}
+	return result.Score, nil
+}
+
+// ExtractEntities asks the plugin for the named entities in the text
+func (p *Plugin) ExtractEntities(ctx context.Context, text string) ([]domain.Entity, error) {
+	if !p.Supports(plugin.MethodExtractEntities) {
+		return nil, nil
+	}
+
+	var result plugin.EntitiesResult
+	if err := p.call(ctx, plugin.MethodExtractEntities, textParams(ctx, text), &result); err != nil {
+		return nil, err
+	}
+
+	entities := make([]domain.Entity, 0, len(result.Entities))
+	for _, e := range result.Entities {
+		entity := domain.Entity{
+			Type:  domain.EntityType(e.Type),
+			Value: e.Value,
+			Count: e.Count,
+		}
+		for _, m := range e.Mentions {
+			entity.Mentions = append(entity.Mentions, domain.Span{Start: m.Start, End: m.End})
+		}
+		if entity.Count < len(entity.Mentions) {
+			entity.Count = len(entity.Mentions)
+		}
+		entities = append(entities, entity)
+	}
+	return entities, nil
+}
+
+// DetectBias asks the plugin for the issues it finds in the text
+func (p *Plugin) DetectBias(ctx context.Context, text string) ([]domain.Flag, error) {
+	if !p.Supports(plugin.MethodDetectBias) {
+		return nil, nil
+	}
+
+	var result plugin.FlagsResult
+	if err := p.call(ctx, plugin.MethodDetectBias, textParams(ctx, text), &result); err != nil {
+		return nil, err
+	}
+	return p.toFlags(result.Flags), nil
+}
+
+// CheckFacts asks the plugin to fact-check an article
+func (p *Plugin) CheckFacts(ctx context.Context, article *domain.Article) ([]domain.Flag, error) {
+	if !p.Supports(plugin.MethodCheckFacts) {
+		return nil, nil
+	}
+
+	params := plugin.ArticleParams{Article: plugin.Article{
+		ID:        article.ID.String(),
+		Title:     article.Title,
+		Content:   article.AnalyzedText(),
+		Source:    article.Source,
+		Author:    article.Author,
+		Tags:      article.Tags,
+		Language:  article.MetaData.Language,
+		CreatedAt: article.CreatedAt,
+	}}
+	var result plugin.FlagsResult
+	if err := p.call(ctx, plugin.MethodCheckFacts, params, &result); err != nil {
+		return nil, err
+	}
+	return p.toFlags(result.Flags), nil
+}
+
+// GetSourceReputation asks the plugin for the reputation of a news source
+func (p *Plugin) GetSourceReputation(ctx context.Context, source string) (float64, error) {
+	if !p.Supports(plugin.MethodSourceReputation) {
+		return 0, secondary.ErrUnknownSource
+	}
+
+	var result plugin.ReputationResult
+	if err := p.call(ctx, plugin.MethodSourceReputation, plugin.SourceParams{Source: source}, &result); err != nil {
+		return 0, err
+	}
+	if result.Score < 0 || result.Score > 1 {
+		return 0, fmt.Errorf("plugin %s returned reputation %v outside [0, 1]", p.Name(), result.Score)
+	}
+	return result.Score, nil
+}
+
+// Close asks the plugin to shut down, killing it if it does not exit promptly
+func (p *Plugin) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	proc := p.proc
+	p.proc = nil
+	p.closed = true
+	if proc == nil || !proc.alive() {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	p.roundTrip(ctx, proc, time.Second, plugin.MethodShutdown, struct{}{}, nil)
+	proc.stdin.Close()
+
+	select {
+	case <-proc.done:
+	case <-time.After(2 * time.Second):
+		proc.stop()
+		<-proc.done
+	}
+	return nil
+}
+
+// call sends a request to the plugin, starting or restarting it as needed.
+// A plugin that times out, crashes or breaks the protocol is killed, so that
+// the next call starts a fresh instance.
+func (p *Plugin) call(ctx context.Context, method string, params, result any) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err := p.ensureRunning(ctx); err != nil {
+		return fmt.Errorf("plugin %s is unavailable: %w", p.info.Name, err)
+	}
+
+	err := p.roundTrip(ctx, p.proc, p.config.CallTimeout, method, params, result)
+	var protocolErr *plugin.Error
+	switch {
+	case err == nil:
+		p.failures = 0
+	case errors.As(err, &protocolErr), ctx.Err() != nil:
+		// The plugin is still in step with the host
+	default:
+		p.proc.stop()
+		p.failed()
+	}
+	if err != nil {
+		return fmt.Errorf("plugin %s failed to %s: %w", p.info.Name, method, err)
+	}
+	return nil
+}
+
+// ensureRunning restarts the plugin if it is not running, unless it is
+// still backing off after repeated failures
+func (p *Plugin) ensureRunning(ctx context.Context) error {
+	if p.closed {
+		return errors.New("plugin is closed")
+	}
+	if p.proc != nil && p.proc.alive() {
+		return nil
+	}
+	if p.proc != nil && p.failures == 0 {
+		// The plugin crashed between calls
+		p.failed()
+	}
+	if wait := time.Until(p.retryAt); wait > 0 {
+		return fmt.Errorf("restarting in %s", wait.Round(time.Millisecond))
+	}
+
+	if err := p.start(ctx); err != nil {
+		p.failed()
+		return err
+	}
+	p.restarts++
+	return nil
+}
+
+// failed records a failure and schedules the next restart with an
+// exponential backoff
+func (p *Plugin) failed() {
+	p.failures++
+	if p.failures == 1 {
+		p.retryAt = time.Time{}
+		return
+	}
+
+	backoff := p.config.MinBackoff << (p.failures - 2)
+	if backoff <= 0 || backoff > p.config.MaxBackoff {
+		backoff = p.config.MaxBackoff
+	}
+	p.retryAt = time.Now().Add(backoff)
+}
+
+// start launches a new process and performs the handshake
+func (p *Plugin) start(ctx context.Context) error {
+	proc, err := startProcess(p.path, p.config.Stderr)
+	if err != nil {
+		return err
+	}
+
+	var info plugin.HandshakeResult
+	params := plugin.HandshakeParams{ProtocolVersion: plugin.ProtocolVersion, Host: HostName}
+	if err := p.roundTrip(ctx, proc, p.config.StartTimeout, plugin.MethodHandshake, params, &info); err != nil {
+		proc.stop()
+		return fmt.Errorf("handshake failed: %w", err)
+	}
+	if info.ProtocolVersion != plugin.ProtocolVersion {
+		proc.stop()
+		return fmt.Errorf("plugin speaks protocol version %d, host speaks %d", info.ProtocolVersion, plugin.ProtocolVersion)
+	}
+	if info.Name == "" {
+		info.Name = filepath.Base(p.path)
+	}
+
+	p.proc = proc
+	p.info = info
+	p.capabilities = make(map[string]bool, len(info.Capabilities))
+	for _, capability := range info.Capabilities {
+		p.capabilities[capability] = true
+	}
+	return nil
+}
+
+// roundTrip sends one request to proc and decodes the response into result.
+// Responses to earlier requests whose callers gave up are skipped.
+func (p *Plugin) roundTrip(ctx context.Context, proc *process, timeout time.Duration, method string, params, result any) error {
+	data, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("failed to encode params: %w", err)
+	}
+	p.nextID++
+	request := plugin.Request{ID: p.nextID, Method: method, Params: data}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	// Writing may block while the plugin is busy, so it must not hold up the
+	// deadline
+	sent := make(chan error, 1)
+	go func() { sent <- proc.send(request) }()
+
+	for {
+		select {
+		case err := <-sent:
+			if err != nil {
+				return err
+			}
+		case response := <-proc.responses:
+			if response.ID != request.ID {
+				continue
+			}
+			if response.Error != nil {
+				return response.Error
+			}
+			if result == nil {
+				return nil
+			}
+			if err := json.Unmarshal(response.Result, result); err != nil {
+				return fmt.Errorf("failed to decode result: %w", err)
+			}
+			return nil
+		case <-proc.done:
+			return proc.err
+		case <-timer.C:
+			return errCallTimeout
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// toFlags converts protocol flags, attributing them to the plugin
+func (p *Plugin) toFlags(flags []plugin.Flag) []domain.Flag {
+	name := p.Name()
+	converted := make([]domain.Flag, 0, len(flags))
+	for _, f := range flags {
+		converted = append(converted, domain.Flag{
+			Type:       domain.FlagType(f.Type),
+			Confidence: math.Min(math.Max(f.Confidence, 0), 1),
+			Details:    f.Details,
+			DetectedBy: name,
+		})
+	}
+	return converted
+}
+
+// textParams builds the parameters of a text analysis request
+func textParams(ctx context.Context, text string) plugin.TextParams {
+	return plugin.TextParams{
+		Text:     text,
+		Language: secondary.LanguageFromContext(ctx),
+	}
+}
@@ -0,0 +1,152 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/reality-filter/pkg/plugin"
+)
+
+// referencePlugin is the path of the reference plugin built by TestMain
+var referencePlugin string
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "reference-plugin")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	referencePlugin = filepath.Join(dir, "reference-plugin")
+	build := exec.Command("go", "build", "-o", referencePlugin, "github.com/reality-filter/cmd/reference-plugin")
+	build.Stderr = os.Stderr
+	if err := build.Run(); err != nil {
+		fmt.Fprintln(os.Stderr, "failed to build the reference plugin:", err)
+		os.RemoveAll(dir)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// startReference starts the reference plugin with the given flags. The host
+// runs plugins without arguments, so the flags are passed by a wrapper
+// script.
+func startReference(t *testing.T, config Config, flags ...string) (*Plugin, error) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin wrappers are shell scripts")
+	}
+	path := filepath.Join(t.TempDir(), "plugin")
+	script := fmt.Sprintf("#!/bin/sh\nexec %q %s\n", referencePlugin, strings.Join(flags, " "))
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	p, err := Start(ctx, path, config)
+	if p != nil {
+		t.Cleanup(func() { p.Close() })
+	}
+	return p, err
+}
+
+func testConfig() Config {
+	config := DefaultConfig()
+	config.CallTimeout = 2 * time.Second
+	config.MinBackoff = 10 * time.Millisecond
+	config.MaxBackoff = 100 * time.Millisecond
+	config.Stderr = io.Discard
+	return config
+}
+
+func TestHandshake(t *testing.T) {
+	p, err := startReference(t, testConfig(), "-name", "fixture")
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	info := p.Info()
+	if info.Name != "fixture" || info.Version != "1.0.0" || info.ProtocolVersion != plugin.ProtocolVersion {
+		t.Errorf("Info() = %+v, want fixture 1.0.0 speaking version %d", info, plugin.ProtocolVersion)
+	}
+	for _, method := range []string{plugin.MethodAnalyzeSentiment, plugin.MethodExtractEntities, plugin.MethodDetectBias, plugin.MethodCheckFacts} {
+		if !p.Supports(method) {
+			t.Errorf("Supports(%s) = false, want true", method)
+		}
+	}
+	if p.Supports(plugin.MethodSourceReputation) {
+		t.Errorf("Supports(%s) = true, want false", plugin.MethodSourceReputation)
+	}
+
+	score, err := p.AnalyzeSentiment(context.Background(), "The launch was a great success.")
+	if err != nil {
+		t.Fatalf("AnalyzeSentiment() error = %v", err)
+	}
+	if score != 1 {
+		t.Errorf("AnalyzeSentiment() = %v, want 1", score)
+	}
+}
+
+func TestHandshakeVersionMismatch(t *testing.T) {
+	_, err := startReference(t, testConfig(), "-protocol-version", "2")
+	if err == nil {
+		t.Fatal("Start() error = nil, want a protocol version error")
+	}
+	if !strings.Contains(err.Error(), "protocol version 2") {
+		t.Errorf("Start() error = %v, want it to name protocol version 2", err)
+	}
+}
+
+func TestRestartAfterTimeout(t *testing.T) {
+	config := testConfig()
+	config.CallTimeout = 100 * time.Millisecond
+	p, err := startReference(t, config, "-delay", "1s")
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	for call := 1; call <= 2; call++ {
+		_, err := p.AnalyzeSentiment(context.Background(), "text")
+		if !errors.Is(err, errCallTimeout) {
+			t.Fatalf("call %d: AnalyzeSentiment() error = %v, want %v", call, err, errCallTimeout)
+		}
+	}
+	// The plugin killed after the first timeout is replaced, rather than
+	// sent the second call
+	if got := p.Restarts(); got != 1 {
+		t.Errorf("Restarts() = %d, want 1", got)
+	}
+}
+
+func TestRestartAfterCrash(t *testing.T) {
+	p, err := startReference(t, testConfig(), "-crash-after", "1")
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	ctx := context.Background()
+
+	if _, err := p.AnalyzeSentiment(ctx, "text"); err != nil {
+		t.Fatalf("first call: AnalyzeSentiment() error = %v", err)
+	}
+	if _, err := p.AnalyzeSentiment(ctx, "text"); err == nil {
+		t.Fatal("second call: AnalyzeSentiment() error = nil, want the crash")
+	}
+	if _, err := p.AnalyzeSentiment(ctx, "text"); err != nil {
+		t.Fatalf("call after the crash: AnalyzeSentiment() error = %v", err)
+	}
+	if got := p.Restarts(); got != 1 {
+		t.Errorf("Restarts() = %d, want 1", got)
+	}
+}
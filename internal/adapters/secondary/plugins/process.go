@@ -0,0 +1,143 @@
+package plugins
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"sync"
+
+	"github.com/reality-filter/pkg/plugin"
+)
+
+// maxMessageSize bounds the length of a single protocol message
+const maxMessageSize = 16 << 20
+
+// process is one running instance of a plugin executable
+type process struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	// writeMu keeps requests whose writes outlive their call from
+	// interleaving with the next one
+	writeMu sync.Mutex
+
+	// responses carries every response the plugin writes, in order
+	responses chan plugin.Response
+	// done is closed once the plugin's output has ended and it has exited
+	done chan struct{}
+	// err describes why the process ended, valid once done is closed
+	err error
+
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// startProcess launches the executable at path with its standard error
+// forwarded to stderr
+func startProcess(path string, stderr io.Writer) (*process, error) {
+	cmd := exec.Command(path)
+	cmd.Stderr = stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open plugin input: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open plugin output: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start plugin: %w", err)
+	}
+
+	p := &process{
+		cmd:       cmd,
+		stdin:     stdin,
+		responses: make(chan plugin.Response, 1),
+		done:      make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+	go p.read(stdout)
+	return p, nil
+}
+
+// read decodes responses until the plugin's output ends, then reaps the
+// process. Output that is not a valid response ends the process, since the
+// stream can no longer be trusted.
+func (p *process) read(stdout io.Reader) {
+	defer close(p.done)
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+
+	var readErr error
+	for scanner.Scan() {
+		var response plugin.Response
+		if err := json.Unmarshal(scanner.Bytes(), &response); err != nil {
+			readErr = fmt.Errorf("plugin wrote an invalid response: %w", err)
+			break
+		}
+		select {
+		case p.responses <- response:
+		case <-p.stopped:
+		}
+	}
+	if readErr == nil {
+		readErr = scanner.Err()
+	}
+	if readErr != nil {
+		p.stop()
+	}
+
+	// Drain any remaining output so that the plugin is not blocked on a full
+	// pipe while it is being reaped
+	io.Copy(io.Discard, stdout)
+
+	waitErr := p.cmd.Wait()
+	switch {
+	case readErr != nil:
+		p.err = readErr
+	case waitErr != nil:
+		p.err = fmt.Errorf("plugin exited: %w", waitErr)
+	default:
+		p.err = fmt.Errorf("plugin exited")
+	}
+}
+
+// send writes a request to the plugin's input
+func (p *process) send(request plugin.Request) error {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+	if _, err := p.stdin.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write request: %w", err)
+	}
+	return nil
+}
+
+// alive reports whether the process is still running. A process that was
+// stopped counts as ended even before it has been reaped.
+func (p *process) alive() bool {
+	select {
+	case <-p.done:
+		return false
+	case <-p.stopped:
+		return false
+	default:
+		return true
+	}
+}
+
+// stop kills the process. It is safe to call more than once.
+func (p *process) stop() {
+	p.stopOnce.Do(func() {
+		close(p.stopped)
+		p.stdin.Close()
+		p.cmd.Process.Kill()
+	})
+}
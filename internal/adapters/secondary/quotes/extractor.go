@@ -0,0 +1,390 @@
+package quotes
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/internal/core/ports/secondary"
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+const (
+	// maxQuoteLength bounds the bytes between an opening and closing quote
+	// mark so an unbalanced mark cannot swallow the rest of the article
+	maxQuoteLength = 1500
+	// maxSpeakerWords bounds the length of an attribution's speaker phrase
+	maxSpeakerWords = 7
+)
+
+const speechVerbs = `said|says|say|told|tells|stated|states|claimed|claims|claim|argued|argues|argue|` +
+	`warned|warns|warn|added|adds|explained|explains|noted|notes|insisted|insists|` +
+	`believe|believes|suggested|suggests|wrote|writes|announced|announces|` +
+	`confirmed|confirms|denied|denies|alleged|alleges|asserted|asserts|` +
+	`admitted|admits|acknowledged|acknowledges|predicted|predicts|estimated|estimates`
+
+var (
+	// Attribution after a direct quote: ”, said Jane Doe. / ”, Jane Doe said.
+	verbSpeakerAfter = regexp.MustCompile(`^\s*,?\s*(?:` + speechVerbs + `)\s+([^.,;:!?"“”]+)`)
+	speakerVerbAfter = regexp.MustCompile(`^\s*,?\s*([^.,;:!?"“”]+?)\s+(?:` + speechVerbs + `)\b`)
+
+	// Attribution before a direct quote: Jane Doe said: “ / According to Jane Doe, “
+	speakerVerbBefore = regexp.MustCompile(`(?:^|[.,;!?]\s+|^\s*)([^.,;:!?"“”]+?)\s+(?:` + speechVerbs + `)(?:\s+that|\s+\w+){0,2}\s*[,:]?\s*$`)
+	accordingToBefore = regexp.MustCompile(`(?i)according to\s+([^.,;:!?"“”]+?)\s*,\s*$`)
+
+	// Indirect quotes
+	accordingTo       = regexp.MustCompile(`(?i)^according to\s+([^,]+),\s*(.+)$`)
+	accordingToAfter  = regexp.MustCompile(`(?i)^(.+?),\s*according to\s+([^,.]+)[.!?]?$`)
+	trailingSpeaker   = regexp.MustCompile(`^(.+?),\s*([^,]+?)\s+(?:` + speechVerbs + `)[.!?]?$`)
+	leadingSpeaker    = regexp.MustCompile(`^(.+?)\s+(?:` + speechVerbs + `)(?:\s+(?:on|in)\s+\w+day)?(?:\s+that|,)?\s+(.+)$`)
+	impersonalPassive = regexp.MustCompile(`(?i)\b(?:it\s+(?:is|was|has\s+been|had\s+been)\s+(?:said|claimed|reported|believed|alleged|rumou?red|understood|thought)\s+that|reportedly|allegedly|rumou?rs\s+(?:say|have\s+it|suggest))\b`)
+)
+
+var (
+	// anonymousCues mark attribution phrases that do not identify a speaker
+	anonymousCues = map[string]bool{
+		"source": true, "sources": true, "insider": true, "insiders": true,
+		"official": true, "officials": true, "expert": true, "experts": true,
+		"critic": true, "critics": true, "analyst": true, "analysts": true,
+		"observer": true, "observers": true, "people": true, "person": true,
+		"someone": true, "some": true, "many": true, "others": true,
+		"aide": true, "aides": true, "diplomat": true, "diplomats": true,
+		"spokesperson": true, "spokesman": true, "spokeswoman": true,
+		"witness": true, "witnesses": true, "scientists": true, "researchers": true,
+		"studies": true, "reports": true, "rumors": true, "rumours": true,
+		"unnamed": true, "anonymous": true, "everyone": true,
+	}
+	anonymousPhrases = []string{
+		"familiar with", "close to", "asked not to be named", "condition of anonymity",
+		"not authorized to", "not authorised to", "declined to be named",
+	}
+	pronouns = map[string]bool{
+		"he": true, "she": true, "they": true, "it": true, "we": true,
+	}
+	// determiners and adverbs trimmed from the start of a speaker phrase
+	leadingFiller = map[string]bool{
+		"the": true, "a": true, "an": true, "but": true, "and": true, "also": true,
+		"however": true, "later": true, "then": true, "as": true,
+	}
+)
+
+// Extractor implements secondary.QuoteExtractor for English text using
+// quotation marks and speech-verb attribution patterns
+type Extractor struct{}
+
+// Ensure Extractor implements secondary.QuoteExtractor
+var _ secondary.QuoteExtractor = (*Extractor)(nil)
+
+// NewExtractor creates a quote extractor
+func NewExtractor() *Extractor {
+	return &Extractor{}
+}
+
+// ExtractQuotes finds direct quotes in quotation marks and indirect quotes
+// introduced by speech verbs or "according to". Speakers are linked to
+// PERSON and ORGANIZATION entities whose mentions overlap the attribution,
+// and pronoun speakers to the most recently linked speaker. Attributions
+// that name no one, such as "sources say", are marked anonymous. Text in
+// languages other than English yields no quotes.
+func (e *Extractor) ExtractQuotes(ctx context.Context, text string, entities []domain.Entity) ([]domain.Quote, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if lang := secondary.LanguageFromContext(ctx); lang != "" && lang != "en" {
+		return nil, nil
+	}
+
+	r := &resolver{text: text, entities: entities}
+	var quotes []domain.Quote
+
+	direct := findQuoted(text)
+	for _, q := range direct {
+		quotes = append(quotes, r.direct(q))
+	}
+
+	for _, sentence := range tokenize.Sentences(text) {
+		if overlapsAny(sentence, direct) {
+			continue
+		}
+		if q, ok := r.indirect(sentence); ok {
+			quotes = append(quotes, q)
+		}
+	}
+
+	sortQuotes(quotes)
+	r.resolvePronouns(quotes)
+	return quotes, nil
+}
+
+// quoted is a span of text between quotation marks, excluding the marks
+type quoted struct {
+	start, end int
+	after      int // offset just past the closing mark
+}
+
+// findQuoted pairs curly quotes with each other and straight double quotes
+// with each other. Quotes shorter than three words are treated as scare
+// quotes or titles and skipped.
+func findQuoted(text string) []quoted {
+	var spans []quoted
+	for i := 0; i < len(text); {
+		var opener, closer string
+		switch {
+		case strings.HasPrefix(text[i:], "“"):
+			opener, closer = "“", "”"
+		case text[i] == '"':
+			opener, closer = `"`, `"`
+		default:
+			i++
+			continue
+		}
+		open := i + len(opener)
+		end := strings.Index(text[open:], closer)
+		if end < 0 || end > maxQuoteLength || strings.Contains(text[open:open+end], "\n\n") {
+			i = open
+			continue
+		}
+		end += open
+		if len(tokenize.Words(text[open:end])) >= 3 {
+			// A comma before the closing mark belongs to the attribution
+			trimmed := open + len(strings.TrimRight(text[open:end], ", "))
+			spans = append(spans, quoted{start: open, end: trimmed, after: end + len(closer)})
+		}
+		i = end + len(closer)
+	}
+	return spans
+}
+
+// resolver attributes quotes to speakers
+type resolver struct {
+	text     string
+	entities []domain.Entity
+}
+
+// direct attributes a quoted span using the clause right after the closing
+// mark, or failing that the clause right before the opening mark
+func (r *resolver) direct(q quoted) domain.Quote {
+	quote := r.quote(q.start, q.end, true)
+
+	afterOffset := q.after
+	after := r.text[afterOffset:]
+	if m := verbSpeakerAfter.FindStringSubmatchIndex(after); m != nil {
+		r.attribute(&quote, afterOffset+m[2], afterOffset+m[3])
+		return quote
+	}
+	if m := speakerVerbAfter.FindStringSubmatchIndex(after); m != nil && isSpeakerPhrase(after[m[2]:m[3]]) {
+		r.attribute(&quote, afterOffset+m[2], afterOffset+m[3])
+		return quote
+	}
+
+	before := r.text[:q.start]
+	before = strings.TrimRight(strings.TrimSuffix(before, "“"), `"`)
+	lineStart := 0
+	if i := strings.LastIndexAny(before, "\n”\""); i >= 0 {
+		_, size := utf8.DecodeRuneInString(before[i:])
+		lineStart = i + size
+	}
+	clause := before[lineStart:]
+	if m := accordingToBefore.FindStringSubmatchIndex(clause); m != nil {
+		r.attribute(&quote, lineStart+m[2], lineStart+m[3])
+		return quote
+	}
+	if m := speakerVerbBefore.FindStringSubmatchIndex(clause); m != nil && isSpeakerPhrase(clause[m[2]:m[3]]) {
+		r.attribute(&quote, lineStart+m[2], lineStart+m[3])
+		return quote
+	}
+
+	// An unattributed direct quote
+	quote.Anonymous = true
+	return quote
+}
+
+// indirect recognizes reported speech in a sentence without quotation marks
+func (r *resolver) indirect(sentence tokenize.Token) (domain.Quote, bool) {
+	s := strings.TrimSpace(sentence.Text)
+	base := sentence.Start
+
+	if m := accordingTo.FindStringSubmatchIndex(s); m != nil {
+		quote := r.quote(base+m[4], base+trimEnd(s, m[5]), false)
+		r.attribute(&quote, base+m[2], base+m[3])
+		return quote, true
+	}
+	if m := accordingToAfter.FindStringSubmatchIndex(s); m != nil {
+		quote := r.quote(base+m[2], base+m[3], false)
+		r.attribute(&quote, base+m[4], base+m[5])
+		return quote, true
+	}
+	if m := trailingSpeaker.FindStringSubmatchIndex(s); m != nil && isSpeakerPhrase(s[m[4]:m[5]]) {
+		quote := r.quote(base+m[2], base+m[3], false)
+		r.attribute(&quote, base+m[4], base+m[5])
+		return quote, true
+	}
+	if m := leadingSpeaker.FindStringSubmatchIndex(s); m != nil {
+		speakerStart, speakerEnd := subjectOf(s, m[2], m[3])
+		if isSpeakerPhrase(s[speakerStart:speakerEnd]) {
+			quote := r.quote(base+m[4], base+trimEnd(s, m[5]), false)
+			r.attribute(&quote, base+speakerStart, base+speakerEnd)
+			return quote, true
+		}
+	}
+	if m := impersonalPassive.FindStringIndex(s); m != nil {
+		quote := r.quote(base, base+trimEnd(s, len(s)), false)
+		quote.Attribution = s[m[0]:m[1]]
+		quote.Anonymous = true
+		return quote, true
+	}
+	return domain.Quote{}, false
+}
+
+// quote creates an unattributed quote over the byte range of the text
+func (r *resolver) quote(start, end int, direct bool) domain.Quote {
+	runeStart, runeEnd := tokenize.RuneOffsets(r.text, start, end)
+	return domain.Quote{
+		Text:   strings.TrimSpace(r.text[start:end]),
+		Span:   domain.Span{Start: runeStart, End: runeEnd},
+		Direct: direct,
+	}
+}
+
+// attribute sets the quote's speaker from the attribution phrase at the
+// given byte range, linking it to an entity where one is mentioned
+func (r *resolver) attribute(quote *domain.Quote, start, end int) {
+	phrase := trimSpeaker(r.text[start:end])
+	quote.Attribution = phrase
+	quote.Speaker = phrase
+
+	runeStart, runeEnd := tokenize.RuneOffsets(r.text, start, end)
+	if entity := r.entityAt(runeStart, runeEnd); entity != nil {
+		quote.Speaker = entity.Value
+		quote.SpeakerType = entity.Type
+		return
+	}
+	quote.Anonymous = isAnonymous(phrase)
+}
+
+// entityAt returns the person or organization mentioned within the rune
+// range, preferring people
+func (r *resolver) entityAt(start, end int) *domain.Entity {
+	var found *domain.Entity
+	for i := range r.entities {
+		entity := &r.entities[i]
+		if entity.Type != domain.EntityTypePerson && entity.Type != domain.EntityTypeOrg {
+			continue
+		}
+		for _, m := range entity.Mentions {
+			if m.Start < end && m.End > start {
+				if entity.Type == domain.EntityTypePerson {
+					return entity
+				}
+				if found == nil {
+					found = entity
+				}
+			}
+		}
+	}
+	return found
+}
+
+// resolvePronouns links pronoun speakers to the most recent linked speaker
+func (r *resolver) resolvePronouns(quotes []domain.Quote) {
+	var last *domain.Quote
+	for i := range quotes {
+		q := &quotes[i]
+		if pronouns[strings.ToLower(q.Speaker)] {
+			if last != nil {
+				q.Speaker = last.Speaker
+				q.SpeakerType = last.SpeakerType
+			}
+			continue
+		}
+		if q.SpeakerType != "" {
+			last = q
+		}
+	}
+}
+
+// subjectOf narrows the text before a speech verb to its grammatical
+// subject: an appositive after a comma is dropped, and only the last words
+// of a long phrase are kept
+func subjectOf(s string, start, end int) (int, int) {
+	if i := strings.Index(s[start:end], ","); i >= 0 {
+		end = start + i
+	}
+	words := tokenize.Words(s[start:end])
+	if len(words) > maxSpeakerWords {
+		start += words[len(words)-maxSpeakerWords].Start
+	}
+	return start, end
+}
+
+// isSpeakerPhrase rejects attribution phrases that are too long to be a
+// speaker or that start in lowercase without being a pronoun or an
+// anonymous attribution, which usually means a clause was matched
+func isSpeakerPhrase(phrase string) bool {
+	phrase = trimSpeaker(phrase)
+	words := tokenize.Words(phrase)
+	if len(words) == 0 || len(words) > maxSpeakerWords {
+		return false
+	}
+	first := strings.ToLower(words[0].Text)
+	if pronouns[first] || isAnonymous(phrase) {
+		return true
+	}
+	for _, w := range words {
+		if r := []rune(w.Text)[0]; r >= 'A' && r <= 'Z' {
+			return true
+		}
+	}
+	return false
+}
+
+// isAnonymous reports whether an attribution phrase names no one
+func isAnonymous(phrase string) bool {
+	lower := strings.ToLower(phrase)
+	for _, p := range anonymousPhrases {
+		if strings.Contains(lower, p) {
+			return true
+		}
+	}
+	for _, w := range tokenize.Words(lower) {
+		if anonymousCues[tokenize.Normalize(w.Text)] {
+			return true
+		}
+	}
+	return false
+}
+
+// trimSpeaker removes surrounding space and leading filler words
+func trimSpeaker(phrase string) string {
+	phrase = strings.TrimSpace(phrase)
+	for {
+		i := strings.IndexByte(phrase, ' ')
+		if i < 0 || !leadingFiller[strings.ToLower(phrase[:i])] {
+			return phrase
+		}
+		phrase = strings.TrimSpace(phrase[i+1:])
+	}
+}
+
+// trimEnd returns the offset of the end of s[:end] without trailing
+// sentence punctuation
+func trimEnd(s string, end int) int {
+	return len(strings.TrimRight(s[:end], ".!? "))
+}
+
+func overlapsAny(sentence tokenize.Token, spans []quoted) bool {
+	for _, q := range spans {
+		if q.start < sentence.End && q.end > sentence.Start {
+			return true
+		}
+	}
+	return false
+}
+
+// sortQuotes orders quotes by position in the text
+func sortQuotes(quotes []domain.Quote) {
+	sort.Slice(quotes, func(i, j int) bool { return quotes[i].Span.Start < quotes[j].Span.Start })
+}
@@ -0,0 +1,252 @@
+package sentiment
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/internal/core/ports/secondary"
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+//go:embed lexicons/*.lex
+var lexiconFiles embed.FS
+
+const (
+	// Neutral is the score returned for text without any sentiment words or
+	// in a language without a lexicon
+	Neutral = 0.5
+	// DefaultLanguage is used when the context carries no detected language
+	DefaultLanguage = "en"
+
+	// negationScalar is applied to a word's valence when it is negated
+	negationScalar = -0.74
+	// negationWindow is how many preceding tokens are searched for a negator
+	negationWindow = 3
+	// normalizationAlpha controls how quickly sentence scores saturate
+	normalizationAlpha = 15.0
+)
+
+// Analyzer implements lexicon-based sentiment analysis with one lexicon per
+// language
+type Analyzer struct {
+	lexicons map[string]*Lexicon
+}
+
+// SentenceScore is the sentiment of a single sentence
+type SentenceScore struct {
+	Text  string
+	Start int
+	End   int
+	// Score is on the same 0-1 scale as AnalyzeSentiment
+	Score float64
+	// Words lists the sentiment-bearing words that contributed to the score
+	Words []string
+}
+
+// Ensure Analyzer implements secondary.ContentAnalyzer
+var _ secondary.ContentAnalyzer = (*Analyzer)(nil)
+
+// NewAnalyzer creates a sentiment analyzer using the built-in lexicons, with
+// the English lexicon extended by the lexicon files at the given paths
+func NewAnalyzer(lexiconPaths ...string) (*Analyzer, error) {
+	a := &Analyzer{lexicons: make(map[string]*Lexicon)}
+
+	entries, err := lexiconFiles.ReadDir("lexicons")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read built-in lexicons: %w", err)
+	}
+	for _, entry := range entries {
+		data, err := lexiconFiles.ReadFile("lexicons/" + entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read built-in lexicon: %w", err)
+		}
+		lexicon, err := ParseLexicon(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load built-in lexicon %s: %w", entry.Name(), err)
+		}
+		a.AddLexicon(strings.TrimSuffix(entry.Name(), ".lex"), lexicon)
+	}
+
+	for _, path := range lexiconPaths {
+		if path == "" {
+			continue
+		}
+		extra, err := LoadLexiconFile(path)
+		if err != nil {
+			return nil, err
+		}
+		a.AddLexicon(DefaultLanguage, extra)
+	}
+
+	return a, nil
+}
+
+// NewAnalyzerWithLexicon creates a sentiment analyzer using only the given
+// lexicon for English
+func NewAnalyzerWithLexicon(lexicon *Lexicon) *Analyzer {
+	return &Analyzer{lexicons: map[string]*Lexicon{DefaultLanguage: lexicon}}
+}
+
+// AddLexicon merges a lexicon into the one used for the given ISO 639-1
+// language
+func (a *Analyzer) AddLexicon(language string, lexicon *Lexicon) {
+	existing, ok := a.lexicons[language]
+	if !ok {
+		existing = NewLexicon()
+		a.lexicons[language] = existing
+	}
+	existing.Merge(lexicon)
+}
+
+// AnalyzeSentiment returns the sentiment of the text on a 0-1 scale where 0
+// is most negative, 0.5 neutral and 1 most positive. The document score is
+// the mean of the sentence scores, weighted by how many sentiment words each
+// sentence contains. The lexicon is chosen by the language in the context.
+func (a *Analyzer) AnalyzeSentiment(ctx context.Context, text string) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return Neutral, err
+	}
+
+	var total, weight float64
+	for _, sentence := range a.ScoreSentences(ctx, text) {
+		if len(sentence.Words) == 0 {
+			continue
+		}
+		w := float64(len(sentence.Words))
+		total += (sentence.Score*2 - 1) * w
+		weight += w
+	}
+	if weight == 0 {
+		return Neutral, nil
+	}
+	return toUnitScale(total / weight), nil
+}
+
+// ScoreSentences scores every sentence of the text individually so callers can
+// see which passages drive the document score. Texts in a language without a
+// lexicon score neutral throughout.
+func (a *Analyzer) ScoreSentences(ctx context.Context, text string) []SentenceScore {
+	language := secondary.LanguageFromContext(ctx)
+	if language == "" {
+		language = DefaultLanguage
+	}
+	lexicon, ok := a.lexicons[language]
+	if !ok {
+		lexicon = NewLexicon()
+	}
+
+	sentences := tokenize.Sentences(text)
+	scores := make([]SentenceScore, 0, len(sentences))
+	for _, sentence := range sentences {
+		compound, words := scoreSentence(lexicon, sentence.Text)
+		scores = append(scores, SentenceScore{
+			Text:  sentence.Text,
+			Start: sentence.Start,
+			End:   sentence.End,
+			Score: toUnitScale(compound),
+			Words: words,
+		})
+	}
+	return scores
+}
+
+// ExtractEntities is not supported by the sentiment analyzer
+func (a *Analyzer) ExtractEntities(ctx context.Context, text string) ([]domain.Entity, error) {
+	return nil, nil
+}
+
+// DetectBias is not supported by the sentiment analyzer
+func (a *Analyzer) DetectBias(ctx context.Context, text string) ([]domain.Flag, error) {
+	return nil, nil
+}
+
+// scoreSentence returns the compound score of a sentence in [-1, 1] and the
+// sentiment words found in it
+func scoreSentence(lexicon *Lexicon, sentence string) (float64, []string) {
+	tokens := tokenize.Words(sentence)
+	words := make([]string, len(tokens))
+	for i, token := range tokens {
+		words[i] = tokenize.Normalize(token.Text)
+	}
+
+	// Clauses after a contrastive "but" carry more weight than those before it
+	butIndex := -1
+	for i, word := range words {
+		if word == "but" || word == "however" {
+			butIndex = i
+		}
+	}
+
+	var sum float64
+	var found []string
+	for i, word := range words {
+		valence, ok := lexicon.Valence[word]
+		if !ok {
+			continue
+		}
+
+		valence = applyIntensifiers(lexicon, words, i, valence)
+		if isNegated(lexicon, words, i) {
+			valence *= negationScalar
+		}
+		if butIndex >= 0 {
+			if i < butIndex {
+				valence *= 0.5
+			} else {
+				valence *= 1.5
+			}
+		}
+
+		sum += valence
+		found = append(found, word)
+	}
+
+	// Exclamation marks amplify whatever polarity the sentence already has
+	if exclamations := strings.Count(sentence, "!"); exclamations > 0 && sum != 0 {
+		boost := math.Min(float64(exclamations), 4) * 0.29
+		sum += math.Copysign(boost, sum)
+	}
+
+	return sum / math.Sqrt(sum*sum+normalizationAlpha), found
+}
+
+// applyIntensifiers scales the valence by intensifiers in the three preceding
+// tokens, with closer intensifiers having more effect
+func applyIntensifiers(lexicon *Lexicon, words []string, i int, valence float64) float64 {
+	for distance := 1; distance <= 3 && i-distance >= 0; distance++ {
+		factor, ok := lexicon.Intensifiers[words[i-distance]]
+		if !ok {
+			continue
+		}
+		switch distance {
+		case 2:
+			factor *= 0.95
+		case 3:
+			factor *= 0.9
+		}
+		valence += math.Copysign(1, valence) * factor
+	}
+	return valence
+}
+
+// isNegated reports whether a negator occurs within the negation window
+// before the word at index i
+func isNegated(lexicon *Lexicon, words []string, i int) bool {
+	for distance := 1; distance <= negationWindow && i-distance >= 0; distance++ {
+		word := words[i-distance]
+		if lexicon.Negators[word] || strings.HasSuffix(word, "n't") {
+			return true
+		}
+	}
+	return false
+}
+
+// toUnitScale maps a compound score in [-1, 1] to the 0-1 scale
+func toUnitScale(compound float64) float64 {
+	return math.Max(0, math.Min(1, (compound+1)/2))
+}
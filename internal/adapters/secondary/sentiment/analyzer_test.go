@@ -0,0 +1,44 @@
+package sentiment
+
+import (
+	"context"
+	"testing"
+)
+
+func TestIntensifiers(t *testing.T) {
+	a, err := NewAnalyzer()
+	if err != nil {
+		t.Fatalf("NewAnalyzer() error = %v", err)
+	}
+	score := func(text string) float64 {
+		t.Helper()
+		s, err := a.AnalyzeSentiment(context.Background(), text)
+		if err != nil {
+			t.Fatalf("AnalyzeSentiment(%q) error = %v", text, err)
+		}
+		return s
+	}
+
+	for _, word := range []string{"good", "bad"} {
+		plain := score("The result was " + word + ".")
+		boosted := score("The result was very " + word + ".")
+		dampened := score("The result was slightly " + word + ".")
+
+		// Distance from neutral
+		strength := func(s float64) float64 {
+			if s < 0.5 {
+				return 0.5 - s
+			}
+			return s - 0.5
+		}
+		if strength(boosted) <= strength(plain) {
+			t.Errorf("%q: very %.3f is not stronger than plain %.3f", word, boosted, plain)
+		}
+		if strength(dampened) >= strength(plain) {
+			t.Errorf("%q: slightly %.3f is not closer to neutral than plain %.3f", word, dampened, plain)
+		}
+		if (dampened < 0.5) != (plain < 0.5) {
+			t.Errorf("%q: slightly %.3f has the opposite polarity of plain %.3f", word, dampened, plain)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package sentiment
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Lexicon holds the word lists used to score sentiment
+type Lexicon struct {
+	// Valence maps a lower-cased word to its polarity in [-4, 4]
+	Valence map[string]float64
+	// Negators flip the polarity of the sentiment words that follow them
+	Negators map[string]bool
+	// Intensifiers scale the sentiment word that follows them
+	Intensifiers map[string]float64
+}
+
+// NewLexicon creates an empty lexicon
+func NewLexicon() *Lexicon {
+	return &Lexicon{
+		Valence:      make(map[string]float64),
+		Negators:     make(map[string]bool),
+		Intensifiers: make(map[string]float64),
+	}
+}
+
+// LoadLexiconFile reads a lexicon from a file on disk
+func LoadLexiconFile(path string) (*Lexicon, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open lexicon: %w", err)
+	}
+	defer f.Close()
+
+	lexicon, err := ParseLexicon(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse lexicon %s: %w", path, err)
+	}
+	return lexicon, nil
+}
+
+// ParseLexicon reads a lexicon in the sectioned text format:
+//
+//	[valence]
+//	good	1.9
+//	[negators]
+//	not
+//	[intensifiers]
+//	very	0.3
+//
+// Blank lines and lines starting with '#' are ignored.
+func ParseLexicon(r io.Reader) (*Lexicon, error) {
+	lexicon := NewLexicon()
+	section := ""
+
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = strings.ToLower(strings.Trim(line, "[]"))
+			continue
+		}
+
+		fields := strings.Fields(line)
+		term := strings.ToLower(fields[0])
+		switch section {
+		case "valence", "intensifiers":
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("line %d: expected term and value", lineNo)
+			}
+			value, err := strconv.ParseFloat(fields[1], 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid value %q: %w", lineNo, fields[1], err)
+			}
+			if section == "valence" {
+				lexicon.Valence[term] = value
+			} else {
+				lexicon.Intensifiers[term] = value
+			}
+		case "negators":
+			lexicon.Negators[term] = true
+		default:
+			return nil, fmt.Errorf("line %d: entry outside of a known section", lineNo)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lexicon, nil
+}
+
+// Merge copies every entry of other into the lexicon, overriding existing
+// values
+func (l *Lexicon) Merge(other *Lexicon) {
+	for k, v := range other.Valence {
+		l.Valence[k] = v
+	}
+	for k := range other.Negators {
+		l.Negators[k] = true
+	}
+	for k, v := range other.Intensifiers {
+		l.Intensifiers[k] = v
+	}
+}
@@ -0,0 +1,76 @@
+// Package sources rates articles by the reputation of their source in the
+// source registry.
+package sources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/internal/core/ports/secondary"
+)
+
+// CheckerName identifies the source registry among fact checkers
+const CheckerName = "source_registry"
+
+// Checker is a FactChecker that looks up source reputations in the source
+// registry. It does not check facts itself.
+//
+// An article's source is resolved by its domain, so that URLs and host
+// names such as "https://www.bbc.co.uk/news" find the "bbc.co.uk" entry;
+// subdomains fall back to their parent domains. Sources that are not
+// domains are looked up by display name.
+type Checker struct {
+	repository secondary.SourceRepository
+}
+
+// Ensure Checker implements the FactChecker interface
+var _ secondary.FactChecker = (*Checker)(nil)
+
+// NewChecker creates a checker backed by the source repository
+func NewChecker(repository secondary.SourceRepository) *Checker {
+	return &Checker{repository: repository}
+}
+
+// CheckFacts returns no flags
+func (c *Checker) CheckFacts(ctx context.Context, article *domain.Article) ([]domain.Flag, error) {
+	return nil, nil
+}
+
+// GetSourceReputation returns the current reputation of the registry entry
+// the source resolves to, or ErrUnknownSource
+func (c *Checker) GetSourceReputation(ctx context.Context, source string) (float64, error) {
+	entry, err := c.Resolve(ctx, source)
+	if err != nil {
+		return 0, err
+	}
+	if entry == nil {
+		return 0, secondary.ErrUnknownSource
+	}
+	return entry.ReputationAt(time.Now()), nil
+}
+
+// Resolve returns the registry entry of an article source, or nil if there
+// is none
+func (c *Checker) Resolve(ctx context.Context, source string) (*domain.Source, error) {
+	for _, d := range domain.ParentDomains(domain.SourceDomain(source)) {
+		entry, err := c.repository.FindByDomain(ctx, d)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up source %s: %w", d, err)
+		}
+		if entry != nil {
+			return entry, nil
+		}
+	}
+
+	if strings.TrimSpace(source) == "" {
+		return nil, nil
+	}
+	entry, err := c.repository.FindByName(ctx, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up source %q: %w", source, err)
+	}
+	return entry, nil
+}
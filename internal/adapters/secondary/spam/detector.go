@@ -0,0 +1,387 @@
+package spam
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/internal/core/ports/secondary"
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+const (
+	// DetectorName identifies flags raised by the spam detector
+	DetectorName = "spam_detector"
+	// DefaultThreshold is the spam probability at which a flag is raised
+	DefaultThreshold = 0.5
+
+	// minStuffingWords is the length below which keyword stuffing is not
+	// measured, since short texts naturally repeat their subject
+	minStuffingWords = 40
+)
+
+// Weights are the coefficients of the logistic spam model. Every signal is
+// scaled to [0, 1] before it is weighted.
+type Weights struct {
+	Bias            float64
+	LinkDensity     float64
+	RepeatedNgrams  float64
+	KeywordStuffing float64
+	AllCaps         float64
+	TrackingLinks   float64
+	PromotionalCopy float64
+}
+
+// DefaultWeights returns weights under which one saturated signal alone stays
+// near the threshold and two clearly cross it
+func DefaultWeights() Weights {
+	return Weights{
+		Bias:            -4.0,
+		LinkDensity:     2.5,
+		RepeatedNgrams:  2.5,
+		KeywordStuffing: 2.5,
+		AllCaps:         1.5,
+		TrackingLinks:   2.5,
+		PromotionalCopy: 3.5,
+	}
+}
+
+var (
+	urlPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"')\]]+`)
+
+	// trackingParams are query parameters used by affiliate programmes and
+	// campaign tracking
+	trackingParams = []string{
+		"utm_", "ref", "aff", "affiliate", "affid", "tag", "clickid", "irclickid",
+		"gclid", "fbclid", "mc_cid", "subid", "partner", "campaign",
+	}
+
+	// shorteners hide the destination of a link
+	shorteners = map[string]bool{
+		"bit.ly": true, "tinyurl.com": true, "goo.gl": true, "t.co": true,
+		"ow.ly": true, "amzn.to": true, "is.gd": true, "buff.ly": true,
+		"rebrand.ly": true, "cutt.ly": true, "shorturl.at": true,
+	}
+
+	promotionalPhrases = []string{
+		"buy now", "order now", "shop now", "click here", "click the link",
+		"limited time offer", "limited time only", "act now", "don't miss out",
+		"while supplies last", "risk-free", "risk free", "money-back guarantee",
+		"100% free", "free trial", "special promotion", "exclusive deal",
+		"best price", "lowest price", "discount code", "promo code", "coupon code",
+		"use code", "sign up today", "subscribe now", "earn money", "make money from home",
+		"work from home", "get rich", "double your", "miracle cure", "lose weight fast",
+		"no credit check", "cheap meds", "visit our website", "check out our",
+		"sponsored content", "affiliate link", "as seen on tv", "call now",
+	}
+
+	stopwords = map[string]bool{
+		"the": true, "a": true, "an": true, "and": true, "or": true, "but": true,
+		"of": true, "to": true, "in": true, "on": true, "at": true, "for": true,
+		"with": true, "by": true, "from": true, "is": true, "are": true, "was": true,
+		"were": true, "be": true, "been": true, "it": true, "its": true, "this": true,
+		"that": true, "these": true, "those": true, "as": true, "he": true, "she": true,
+		"they": true, "we": true, "you": true, "i": true, "his": true, "her": true,
+		"their": true, "our": true, "your": true, "not": true, "no": true, "has": true,
+		"have": true, "had": true, "will": true, "would": true, "can": true, "could": true,
+		"said": true, "also": true, "more": true, "than": true, "which": true, "who": true,
+	}
+)
+
+// Detector implements a weighted spam and content-farm model
+type Detector struct {
+	weights   Weights
+	threshold float64
+}
+
+// Ensure Detector implements secondary.ContentAnalyzer
+var _ secondary.ContentAnalyzer = (*Detector)(nil)
+
+// NewDetector creates a spam detector with the default weights
+func NewDetector(threshold float64) *Detector {
+	return NewDetectorWithWeights(DefaultWeights(), threshold)
+}
+
+// NewDetectorWithWeights creates a spam detector with custom weights
+func NewDetectorWithWeights(weights Weights, threshold float64) *Detector {
+	return &Detector{weights: weights, threshold: threshold}
+}
+
+// signal is one measured spam indicator
+type signal struct {
+	name   string
+	value  float64 // scaled to [0, 1]
+	weight float64
+	detail string
+}
+
+// DetectBias returns a SPAM flag when the spam probability of the text reaches
+// the detector's threshold. The flag details break the probability down into
+// the individual signals.
+//
+// Links and promotional copy are counted in the raw content when the context
+// carries it, since content normalization removes link targets, tracking
+// parameters and promotional lines; the other signals use the text, or the
+// raw content if normalization left no words.
+func (d *Detector) DetectBias(ctx context.Context, text string) ([]domain.Flag, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	raw := secondary.RawContentFromContext(ctx)
+	if raw == "" {
+		raw = text
+	}
+	words := tokenize.Words(urlPattern.ReplaceAllString(text, " "))
+	if len(words) == 0 {
+		// Content that normalization removed entirely is measured as is
+		text = raw
+		words = tokenize.Words(urlPattern.ReplaceAllString(text, " "))
+	}
+	if len(words) == 0 {
+		return nil, nil
+	}
+	signals := d.signals(text, raw, words)
+	score := d.weights.Bias
+	for _, s := range signals {
+		score += s.value * s.weight
+	}
+	probability := 1 / (1 + math.Exp(-score))
+	if probability < d.threshold {
+		return nil, nil
+	}
+
+	var parts []string
+	for _, s := range signals {
+		if s.value == 0 {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s %.2f (%s)", s.name, s.value, s.detail))
+	}
+	return []domain.Flag{{
+		Type:       domain.FlagTypeSpam,
+		Confidence: probability,
+		Details:    fmt.Sprintf("spam probability %.2f: %s", probability, strings.Join(parts, "; ")),
+		DetectedBy: DetectorName,
+	}}, nil
+}
+
+// AnalyzeSentiment is not supported by the spam detector
+func (d *Detector) AnalyzeSentiment(ctx context.Context, text string) (float64, error) {
+	return 0.5, nil
+}
+
+// ExtractEntities is not supported by the spam detector
+func (d *Detector) ExtractEntities(ctx context.Context, text string) ([]domain.Entity, error) {
+	return nil, nil
+}
+
+// signals measures every spam indicator. Links and promotional copy are
+// taken from raw, the rest from the words of the text.
+func (d *Detector) signals(text, raw string, words []tokenize.Token) []signal {
+	normalized := make([]string, len(words))
+	for i, w := range words {
+		normalized[i] = tokenize.Normalize(w.Text)
+	}
+	links := urlPattern.FindAllString(raw, -1)
+
+	return []signal{
+		linkDensity(links, len(words), d.weights.LinkDensity),
+		repeatedNgrams(normalized, d.weights.RepeatedNgrams),
+		keywordStuffing(normalized, d.weights.KeywordStuffing),
+		allCaps(words, d.weights.AllCaps),
+		trackingLinks(links, d.weights.TrackingLinks),
+		promotionalCopy(strings.ToLower(raw), d.weights.PromotionalCopy),
+	}
+}
+
+// linkDensity saturates at five links per 100 words
+func linkDensity(links []string, words int, weight float64) signal {
+	per100 := float64(len(links)) * 100 / math.Max(float64(words), 100)
+	return signal{
+		name:   "link density",
+		value:  math.Min(1, per100/5),
+		weight: weight,
+		detail: fmt.Sprintf("%d links, %.1f per 100 words", len(links), per100),
+	}
+}
+
+// repeatedNgrams measures the share of word trigrams that occur more than
+// once, saturating at 30%
+func repeatedNgrams(words []string, weight float64) signal {
+	s := signal{name: "repeated phrasing", weight: weight, detail: "no repeated trigrams"}
+	if len(words) < 3 {
+		return s
+	}
+
+	counts := make(map[string]int)
+	for i := 0; i+3 <= len(words); i++ {
+		counts[strings.Join(words[i:i+3], " ")]++
+	}
+	total := len(words) - 2
+	repeated := 0
+	top, topCount := "", 1
+	for gram, c := range counts {
+		if c > 1 {
+			repeated += c - 1
+		}
+		if c > topCount || (c == topCount && c > 1 && gram < top) {
+			top, topCount = gram, c
+		}
+	}
+	ratio := float64(repeated) / float64(total)
+	s.value = math.Min(1, ratio/0.3)
+	if repeated > 0 {
+		s.detail = fmt.Sprintf("%.0f%% of trigrams repeated, e.g. %q x%d", ratio*100, top, topCount)
+	}
+	return s
+}
+
+// keywordStuffing measures how far the most frequent content word exceeds a
+// natural 3% share of the text, saturating at 10%
+func keywordStuffing(words []string, weight float64) signal {
+	s := signal{name: "keyword stuffing", weight: weight, detail: "text too short"}
+	if len(words) < minStuffingWords {
+		return s
+	}
+
+	counts := make(map[string]int)
+	for _, w := range words {
+		if len([]rune(w)) < 3 || stopwords[w] || isNumber(w) {
+			continue
+		}
+		counts[w]++
+	}
+	keys := make([]string, 0, len(counts))
+	for w := range counts {
+		keys = append(keys, w)
+	}
+	sort.Slice(keys, func(a, b int) bool {
+		if counts[keys[a]] != counts[keys[b]] {
+			return counts[keys[a]] > counts[keys[b]]
+		}
+		return keys[a] < keys[b]
+	})
+	if len(keys) == 0 {
+		s.detail = "no content words"
+		return s
+	}
+
+	share := float64(counts[keys[0]]) / float64(len(words))
+	s.value = math.Max(0, math.Min(1, (share-0.03)/0.07))
+	s.detail = fmt.Sprintf("%q is %.1f%% of words", keys[0], share*100)
+	return s
+}
+
+// allCaps measures the share of shouted words, saturating at 30%
+func allCaps(words []tokenize.Token, weight float64) signal {
+	shouted := 0
+	for _, w := range words {
+		letters, upper := 0, 0
+		for _, r := range w.Text {
+			if unicode.IsLetter(r) {
+				letters++
+				if unicode.IsUpper(r) {
+					upper++
+				}
+			}
+		}
+		if letters >= 3 && upper == letters {
+			shouted++
+		}
+	}
+	ratio := float64(shouted) / float64(len(words))
+	return signal{
+		name:   "all-caps",
+		value:  math.Min(1, ratio/0.3),
+		weight: weight,
+		detail: fmt.Sprintf("%.0f%% of words", ratio*100),
+	}
+}
+
+// trackingLinks counts affiliate, campaign-tracking and shortened links,
+// saturating at two
+func trackingLinks(links []string, weight float64) signal {
+	var found []string
+	for _, link := range links {
+		if reason := trackingReason(link); reason != "" {
+			found = append(found, reason)
+		}
+	}
+	s := signal{
+		name:   "affiliate/tracking links",
+		value:  math.Min(1, float64(len(found))/2),
+		weight: weight,
+		detail: "none",
+	}
+	if len(found) > 0 {
+		s.detail = strings.Join(found, ", ")
+	}
+	return s
+}
+
+// trackingReason explains why a link counts as affiliate or tracking, or
+// returns an empty string
+func trackingReason(link string) string {
+	// Links in HTML attributes escape their ampersands
+	link = html.UnescapeString(link)
+	if !strings.Contains(link, "://") {
+		link = "http://" + link
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if shorteners[host] {
+		return "shortener " + host
+	}
+	for param := range u.Query() {
+		lower := strings.ToLower(param)
+		for _, p := range trackingParams {
+			if lower == p || (strings.HasSuffix(p, "_") && strings.HasPrefix(lower, p)) {
+				return fmt.Sprintf("%s?%s=", host, param)
+			}
+		}
+	}
+	return ""
+}
+
+// promotionalCopy counts boilerplate sales phrases, saturating at three
+func promotionalCopy(lower string, weight float64) signal {
+	lower = strings.ReplaceAll(lower, "’", "'")
+	var found []string
+	total := 0
+	for _, phrase := range promotionalPhrases {
+		if n := strings.Count(lower, phrase); n > 0 {
+			found = append(found, fmt.Sprintf("%q", phrase))
+			total += n
+		}
+	}
+	s := signal{
+		name:   "promotional copy",
+		value:  math.Min(1, float64(total)/3),
+		weight: weight,
+		detail: "none",
+	}
+	if len(found) > 0 {
+		s.detail = strings.Join(found, ", ")
+	}
+	return s
+}
+
+func isNumber(word string) bool {
+	for _, r := range word {
+		if !unicode.IsDigit(r) && r != '.' && r != ',' {
+			return false
+		}
+	}
+	return true
+}
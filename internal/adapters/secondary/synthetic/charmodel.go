@@ -0,0 +1,324 @@
+package synthetic
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+const (
+	// FormatName identifies character language model files
+	FormatName = "reality-filter/charlm"
+	// FormatVersion is the model file version written by this package.
+	// Readers accept any file with the same version.
+	FormatVersion = 1
+
+	// minSentenceRunes is the length below which a sentence is too short for
+	// its cross-entropy to be meaningful
+	minSentenceRunes = 20
+	// minBaselineDocuments is the number of held-out documents needed to
+	// estimate the baseline
+	minBaselineDocuments = 10
+)
+
+// CharModel is a character n-gram language model with Witten-Bell
+// interpolation, together with the cross-entropy statistics of held-out
+// human-written articles. Text the model finds unusually predictable, or
+// uniformly predictable from sentence to sentence, is more likely to be
+// machine-generated.
+type CharModel struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	TrainedAt time.Time `json:"trainedAt"`
+	Order     int       `json:"order"`
+	// Ngrams counts every n-gram of 1 to Order characters seen in training
+	Ngrams   map[string]int `json:"ngrams"`
+	Baseline Baseline       `json:"baseline"`
+
+	// contexts holds the total count and number of distinct continuations of
+	// every history
+	contexts   map[string]contextStats
+	vocabulary int
+}
+
+// Baseline describes held-out human-written documents under the model
+type Baseline struct {
+	Documents int `json:"documents"`
+	// MeanEntropy and StdEntropy describe the documents' mean sentence
+	// cross-entropy, in bits per character
+	MeanEntropy float64 `json:"meanEntropy"`
+	StdEntropy  float64 `json:"stdEntropy"`
+	// MeanVariation and StdVariation describe the documents' coefficient of
+	// variation of sentence cross-entropy
+	MeanVariation float64 `json:"meanVariation"`
+	StdVariation  float64 `json:"stdVariation"`
+}
+
+type contextStats struct {
+	total int
+	types int
+}
+
+// CharModelConfig controls language model training
+type CharModelConfig struct {
+	// Order is the longest n-gram counted
+	Order int
+	// MinCount prunes n-grams of three or more characters seen fewer times
+	MinCount int
+	// HoldOut is the fraction of documents reserved for the baseline
+	HoldOut float64
+}
+
+// DefaultCharModelConfig returns the configuration used by cmd/train
+func DefaultCharModelConfig() CharModelConfig {
+	return CharModelConfig{
+		Order:    5,
+		MinCount: 2,
+		HoldOut:  0.1,
+	}
+}
+
+// TrainCharModel fits a language model to human-written documents, holding
+// some of them out to measure the baseline
+func TrainCharModel(documents []string, config CharModelConfig) (*CharModel, error) {
+	if config.Order < 1 {
+		return nil, errors.New("order must be at least 1")
+	}
+	if config.HoldOut <= 0 || config.HoldOut >= 1 {
+		return nil, errors.New("hold-out fraction must be between 0 and 1")
+	}
+
+	// Every k-th document is held out, which keeps the split deterministic
+	step := int(math.Round(1 / config.HoldOut))
+	var train, heldOut []string
+	for i, doc := range documents {
+		if i%step == step-1 {
+			heldOut = append(heldOut, doc)
+		} else {
+			train = append(train, doc)
+		}
+	}
+	if len(heldOut) < minBaselineDocuments {
+		return nil, fmt.Errorf("need at least %d held-out documents, got %d", minBaselineDocuments, len(heldOut))
+	}
+
+	m := &CharModel{
+		Format:    FormatName,
+		Version:   FormatVersion,
+		TrainedAt: time.Now().UTC(),
+		Order:     config.Order,
+		Ngrams:    make(map[string]int),
+	}
+	for _, doc := range train {
+		for _, sentence := range tokenize.Sentences(doc) {
+			runes := prepare(sentence.Text)
+			for i := range runes {
+				for n := 1; n <= m.Order && n <= i+1; n++ {
+					m.Ngrams[string(runes[i+1-n:i+1])]++
+				}
+			}
+		}
+	}
+	for gram, c := range m.Ngrams {
+		if c < config.MinCount && len([]rune(gram)) >= 3 {
+			delete(m.Ngrams, gram)
+		}
+	}
+	m.index()
+
+	var entropies, variations []float64
+	for _, doc := range heldOut {
+		mean, variation, ok := m.documentEntropy(doc)
+		if !ok {
+			continue
+		}
+		entropies = append(entropies, mean)
+		variations = append(variations, variation)
+	}
+	if len(entropies) < minBaselineDocuments {
+		return nil, fmt.Errorf("need at least %d held-out documents with enough text, got %d", minBaselineDocuments, len(entropies))
+	}
+	m.Baseline = Baseline{Documents: len(entropies)}
+	m.Baseline.MeanEntropy, m.Baseline.StdEntropy = meanStd(entropies)
+	m.Baseline.MeanVariation, m.Baseline.StdVariation = meanStd(variations)
+	return m, nil
+}
+
+// LoadCharModel reads a gzip-compressed model file
+func LoadCharModel(path string) (*CharModel, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open language model: %w", err)
+	}
+	defer f.Close()
+
+	model, err := ReadCharModel(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read language model %s: %w", path, err)
+	}
+	return model, nil
+}
+
+// ReadCharModel decodes a gzip-compressed model and checks its format version
+func ReadCharModel(r io.Reader) (*CharModel, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	var model CharModel
+	if err := json.NewDecoder(gz).Decode(&model); err != nil {
+		return nil, err
+	}
+	if model.Format != FormatName {
+		return nil, fmt.Errorf("unknown model format %q", model.Format)
+	}
+	if model.Version != FormatVersion {
+		return nil, fmt.Errorf("unsupported model version %d, expected %d", model.Version, FormatVersion)
+	}
+	if model.Order < 1 || len(model.Ngrams) == 0 {
+		return nil, errors.New("model has no n-grams")
+	}
+	model.index()
+	return &model, nil
+}
+
+// Save writes the model to a gzip-compressed file
+func (m *CharModel) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create language model file: %w", err)
+	}
+	if err := m.Write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write language model: %w", err)
+	}
+	return f.Close()
+}
+
+// Write encodes the model as gzip-compressed JSON
+func (m *CharModel) Write(w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	if err := json.NewEncoder(gz).Encode(m); err != nil {
+		gz.Close()
+		return err
+	}
+	return gz.Close()
+}
+
+// index derives the history statistics from the n-gram counts
+func (m *CharModel) index() {
+	m.contexts = make(map[string]contextStats)
+	m.vocabulary = 0
+	for gram, c := range m.Ngrams {
+		runes := []rune(gram)
+		history := string(runes[:len(runes)-1])
+		stats := m.contexts[history]
+		stats.total += c
+		stats.types++
+		m.contexts[history] = stats
+		if len(runes) == 1 {
+			m.vocabulary++
+		}
+	}
+}
+
+// probability returns P(c | history) with Witten-Bell interpolation, backing
+// off to add-one smoothed unigrams with one slot for unseen characters
+func (m *CharModel) probability(history []rune, c rune) float64 {
+	unigrams := m.contexts[""]
+	p := float64(m.Ngrams[string(c)]+1) / float64(unigrams.total+m.vocabulary+1)
+
+	for k := 1; k < m.Order && k <= len(history); k++ {
+		h := string(history[len(history)-k:])
+		stats, ok := m.contexts[h]
+		if !ok {
+			break
+		}
+		p = (float64(m.Ngrams[h+string(c)]) + float64(stats.types)*p) / float64(stats.total+stats.types)
+	}
+	return p
+}
+
+// CrossEntropy returns the average number of bits per character the model
+// needs to encode the text
+func (m *CharModel) CrossEntropy(text string) float64 {
+	runes := prepare(text)
+	if len(runes) == 0 {
+		return 0
+	}
+	var bits float64
+	for i, r := range runes {
+		bits -= math.Log2(m.probability(runes[:i], r))
+	}
+	return bits / float64(len(runes))
+}
+
+// documentEntropy returns the mean and coefficient of variation of the
+// cross-entropy of the document's sentences. It reports false when the
+// document has too few sentences long enough to measure.
+func (m *CharModel) documentEntropy(text string) (float64, float64, bool) {
+	var entropies []float64
+	for _, sentence := range tokenize.Sentences(text) {
+		if len([]rune(sentence.Text)) < minSentenceRunes {
+			continue
+		}
+		entropies = append(entropies, m.CrossEntropy(sentence.Text))
+	}
+	if len(entropies) < minSentences {
+		return 0, 0, false
+	}
+	mean, std := meanStd(entropies)
+	if mean == 0 {
+		return 0, 0, false
+	}
+	return mean, std / mean, true
+}
+
+// prepare lowercases the text, maps digits to 0 and collapses whitespace, so
+// that the model generalises across numbers and layout
+func prepare(text string) []rune {
+	runes := make([]rune, 0, len(text)+1)
+	runes = append(runes, ' ')
+	for _, r := range text {
+		switch {
+		case unicode.IsSpace(r):
+			if runes[len(runes)-1] != ' ' {
+				runes = append(runes, ' ')
+			}
+		case unicode.IsDigit(r):
+			runes = append(runes, '0')
+		default:
+			runes = append(runes, unicode.ToLower(r))
+		}
+	}
+	return []rune(strings.TrimRight(string(runes), " "))
+}
+
+// meanStd returns the mean and population standard deviation of the values
+func meanStd(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(squares / float64(len(values)))
+}
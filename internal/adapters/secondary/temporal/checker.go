@@ -0,0 +1,298 @@
+package temporal
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/internal/core/ports/secondary"
+	"github.com/reality-filter/pkg/dates"
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+// CheckerName identifies flags raised by the temporal consistency checker
+const CheckerName = "temporal_checker"
+
+// Confidences of the flags raised by each check
+const (
+	weekdayConfidence          = 0.8
+	inferredWeekdayConfidence  = 0.6
+	relativeFutureConfidence   = 0.75
+	afterPublicationConfidence = 0.7
+)
+
+const (
+	// maxGovernedGap is the number of words allowed between a verb and the
+	// date it governs, as in "reported record losses in <date>"
+	maxGovernedGap = 3
+	// clauseBoundaries end a clause
+	clauseBoundaries = ",;:()—–"
+)
+
+const month = `(?:Jan(?:uary)?|Feb(?:ruary)?|Mar(?:ch)?|Apr(?:il)?|May|June?|July?|Aug(?:ust)?|Sept?(?:ember)?|Oct(?:ober)?|Nov(?:ember)?|Dec(?:ember)?)`
+
+var (
+	// weekdayDate matches a weekday named next to a calendar date, as in
+	// "Tuesday, March 5, 2024" or "Tuesday (5 March)"
+	weekdayDate = regexp.MustCompile(`\b(Monday|Tuesday|Wednesday|Thursday|Friday|Saturday|Sunday),?\s+\(?` +
+		`(` + month + `\.?\s+\d{1,2}(?:st|nd|rd|th)?(?:,?\s+\d{4})?|\d{1,2}(?:st|nd|rd|th)?\s+(?:of\s+)?` + month + `\.?(?:,?\s+\d{4})?)\b`)
+
+	pastAuxiliaries = map[string]bool{
+		"was": true, "were": true, "had": true, "did": true, "wasn't": true,
+		"weren't": true, "hadn't": true, "didn't": true,
+	}
+	irregularPast = map[string]bool{
+		"went": true, "came": true, "said": true, "told": true, "took": true,
+		"made": true, "met": true, "won": true, "lost": true, "held": true,
+		"began": true, "saw": true, "gave": true, "ran": true, "fell": true,
+		"rose": true, "left": true, "found": true, "spoke": true, "wrote": true,
+		"struck": true, "sold": true, "bought": true, "brought": true, "became": true,
+		"broke": true, "chose": true, "drove": true, "flew": true, "grew": true,
+		"hit": true, "kept": true, "led": true, "paid": true, "sent": true,
+		"shot": true, "stood": true, "threw": true, "understood": true,
+	}
+	// futureMarkers show that a sentence talks about what is yet to happen
+	futureMarkers = []string{
+		"will", "won't", "shall", "going to", "expected", "expects",
+		"scheduled", "slated", "set to", "plans", "planned", "planning",
+		"due to", "upcoming", "would", "may", "might", "could", "intends",
+		"is to", "are to", "next", "later", "tomorrow", "forecast", "aims",
+	}
+	// deadlineMarkers introduce a date as a target or deadline rather than
+	// the time of an event
+	deadlineMarkers = map[string]bool{
+		"by": true, "until": true, "till": true, "through": true, "for": true,
+		"before": true,
+	}
+	// dateOpeners may precede a date that opens a clause
+	dateOpeners = map[string]bool{
+		"on": true, "in": true, "at": true, "during": true, "early": true, "late": true,
+	}
+	// clauseWords start a new clause, which a verb does not govern across
+	clauseWords = map[string]bool{
+		"to": true, "and": true, "but": true, "or": true, "which": true,
+		"that": true, "who": true, "while": true, "when": true, "because": true,
+	}
+	// nonPastEd are common words ending in -ed that are not verbs in the
+	// past tense
+	nonPastEd = map[string]bool{
+		"need": true, "speed": true, "indeed": true, "seed": true, "feed": true,
+		"bed": true, "red": true, "hundred": true, "united": true, "limited": true,
+		"based": true, "related": true, "red-handed": true, "proceed": true,
+		"exceed": true, "succeed": true, "breed": true, "greed": true, "shed": true,
+	}
+)
+
+// Checker implements secondary.ConsistencyChecker by checking article dates
+// against the calendar and the publication time
+type Checker struct {
+	now func() time.Time
+}
+
+// Ensure Checker implements secondary.ConsistencyChecker
+var _ secondary.ConsistencyChecker = (*Checker)(nil)
+
+// NewChecker creates a temporal consistency checker
+func NewChecker() *Checker {
+	return &Checker{now: time.Now}
+}
+
+// CheckConsistency returns FACTUAL_ERROR flags for weekdays that do not match
+// the date they accompany, and for events dated after the article's
+// publication time (CreatedAt) that are described in the past tense. The
+// tense and weekday checks are English only.
+func (c *Checker) CheckConsistency(ctx context.Context, article *domain.Article) ([]domain.Flag, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if language := article.MetaData.Language; language != "" && language != "en" {
+		return nil, nil
+	}
+
+	published := article.CreatedAt
+	if published.IsZero() {
+		published = c.now()
+	}
+
+	flags := weekdayMismatches(article.AnalyzedText(), published)
+	flags = append(flags, pastTenseFutureEvents(article, published)...)
+	return flags, nil
+}
+
+// weekdayMismatches flags weekdays paired with a date that falls on another
+// day of the week
+func weekdayMismatches(text string, published time.Time) []domain.Flag {
+	var flags []domain.Flag
+	for _, m := range weekdayDate.FindAllStringSubmatch(text, -1) {
+		stated, _ := dates.ParseWeekday(m[1])
+		d, ok := dates.Normalize(m[2], published)
+		if !ok || d.Precision != dates.Day || d.Start.Weekday() == stated {
+			continue
+		}
+
+		confidence := weekdayConfidence
+		details := fmt.Sprintf("%q: %s is a %s, not a %s", m[0], d.ISO(), d.Start.Weekday(), stated)
+		if d.YearInferred {
+			confidence = inferredWeekdayConfidence
+			details += fmt.Sprintf(" (assuming %d from the publication date)", d.Start.Year())
+		}
+		flags = append(flags, domain.Flag{
+			Type:       domain.FlagTypeFactualError,
+			Confidence: confidence,
+			Details:    details,
+			DetectedBy: CheckerName,
+		})
+	}
+	return flags
+}
+
+// pastTenseFutureEvents flags sentences that describe, in the past tense and
+// without any future marker, an event dated after the publication day. The
+// past-tense verb must govern the date; see pastVerbGoverns.
+func pastTenseFutureEvents(article *domain.Article, published time.Time) []domain.Flag {
+	publishedDay := time.Date(published.Year(), published.Month(), published.Day(), 0, 0, 0, 0, published.Location())
+	publicationEnd := publishedDay.AddDate(0, 0, 1)
+
+	type mention struct {
+		value string
+		span  domain.Span
+	}
+	var future []mention
+	for _, e := range article.MetaData.Entities {
+		if e.Type != domain.EntityTypeDate {
+			continue
+		}
+		d, ok := dates.Normalize(e.Value, published)
+		if !ok || d.Start.Before(publicationEnd) {
+			continue
+		}
+		for _, span := range e.Mentions {
+			future = append(future, mention{value: e.Value, span: span})
+		}
+	}
+	if len(future) == 0 {
+		return nil
+	}
+
+	var flags []domain.Flag
+	flagged := make(map[int]bool)
+	for i, s := range tokenize.Sentences(article.AnalyzedText()) {
+		start, end := tokenize.RuneOffsets(article.AnalyzedText(), s.Start, s.End)
+		for _, m := range future {
+			if m.span.Start < start || m.span.End > end || flagged[i] {
+				continue
+			}
+			// The date expression itself ("next Tuesday") is not a marker
+			rest := strings.Replace(s.Text, m.value, " ", 1)
+			runes := []rune(s.Text)
+			from, to := m.span.Start-start, m.span.End-start
+			if to > len(runes) || hasFutureMarker(rest) || !pastVerbGoverns(string(runes[:from]), string(runes[to:])) {
+				continue
+			}
+			flagged[i] = true
+
+			d, _ := dates.Normalize(m.value, published)
+			confidence, what := afterPublicationConfidence, "event dated"
+			if d.Relative {
+				confidence, what = relativeFutureConfidence, "future event"
+			}
+			flags = append(flags, domain.Flag{
+				Type:       domain.FlagTypeFactualError,
+				Confidence: confidence,
+				Details: fmt.Sprintf("%s %q (%s, after publication on %s) is described in the past tense: %q",
+					what, m.value, d.ISO(), publishedDay.Format("2006-01-02"), s.Text),
+				DetectedBy: CheckerName,
+			})
+		}
+	}
+	return flags
+}
+
+// pastVerbGoverns reports whether a verb in the past tense governs a date,
+// given the text of its sentence before and after the date: the verb and
+// the date are in the same clause, at most maxGovernedGap words apart, as
+// in "was held on <date>" or "<date>, the plant closed". Dates introduced
+// as targets or deadlines ("by 2030", "until 2030") are not governed.
+func pastVerbGoverns(before, after string) bool {
+	if i := strings.LastIndexAny(before, clauseBoundaries); i >= 0 {
+		before = before[i+1:]
+	}
+	words := lowerWords(before)
+	if len(words) > 0 && deadlineMarkers[words[len(words)-1]] {
+		return false
+	}
+	for i, n := len(words)-1, 0; i >= 0 && n <= maxGovernedGap; i, n = i-1, n+1 {
+		if clauseWords[words[i]] {
+			break
+		}
+		if isPastVerb(words[i]) {
+			return true
+		}
+	}
+
+	// A date opening its clause, as in "On May 5, the summit ended"
+	for _, w := range words {
+		if !dateOpeners[w] {
+			return false
+		}
+	}
+	after = strings.TrimPrefix(strings.TrimSpace(after), ",")
+	if i := strings.IndexAny(after, clauseBoundaries); i >= 0 {
+		after = after[:i]
+	}
+	for n, w := range lowerWords(after) {
+		if n > maxGovernedGap || clauseWords[w] {
+			break
+		}
+		if isPastVerb(w) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPastVerb reports whether a lower-case word is a past auxiliary, an
+// irregular past form or a verb ending in -ed
+func isPastVerb(w string) bool {
+	return pastAuxiliaries[w] || irregularPast[w] || (len(w) > 4 && strings.HasSuffix(w, "ed") && !nonPastEd[w])
+}
+
+// lowerWords returns the lower-cased words of text. Capitalized words ending
+// in -ed, such as names, are replaced by an empty word so that they are not
+// taken for verbs.
+func lowerWords(text string) []string {
+	var words []string
+	for _, t := range tokenize.Words(text) {
+		w := strings.ToLower(strings.ReplaceAll(t.Text, "’", "'"))
+		if isCapitalized(t.Text) && strings.HasSuffix(w, "ed") {
+			w = ""
+		}
+		words = append(words, w)
+	}
+	return words
+}
+
+func hasFutureMarker(sentence string) bool {
+	var words []string
+	for _, t := range tokenize.Words(sentence) {
+		w := strings.ToLower(strings.ReplaceAll(t.Text, "’", "'"))
+		if strings.HasSuffix(w, "'ll") {
+			return true
+		}
+		words = append(words, w)
+	}
+	padded := " " + strings.Join(words, " ") + " "
+	for _, marker := range futureMarkers {
+		if strings.Contains(padded, " "+marker+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+func isCapitalized(word string) bool {
+	return word != "" && word[0] >= 'A' && word[0] <= 'Z'
+}
@@ -0,0 +1,133 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/reality-filter/internal/core/domain"
+)
+
+const (
+	// trendingWindow is the recent period in which trending topics are
+	// counted
+	trendingWindow = 24 * time.Hour
+	// trendingBaseline is the period before the recent window that topic
+	// frequencies are compared with
+	trendingBaseline = 7 * 24 * time.Hour
+	// minTrendingArticles is the number of recent articles a topic needs
+	// before it can trend
+	minTrendingArticles = 3
+)
+
+// GetSourceStats implements the AnalyticsProvider interface. It counts the
+// articles created within the time range, such as "24h" or "7d", per
+// source.
+func (s *ArticleAnalyzerService) GetSourceStats(ctx context.Context, timeRange string) (map[string]int, error) {
+	period, err := parseTimeRange(timeRange)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	stats, err := s.repository.CountBySource(ctx, now.Add(-period), now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count articles by source: %w", err)
+	}
+	return stats, nil
+}
+
+// GetFlagStats implements the AnalyticsProvider interface. It counts the
+// flags of each type raised on articles created within the time range.
+func (s *ArticleAnalyzerService) GetFlagStats(ctx context.Context, timeRange string) (map[domain.FlagType]int, error) {
+	period, err := parseTimeRange(timeRange)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	stats, err := s.repository.CountFlags(ctx, now.Add(-period), now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count flags: %w", err)
+	}
+	return stats, nil
+}
+
+// GetTrendingTopics implements the AnalyticsProvider interface. It returns
+// the topics whose share of the articles created in the last day has risen
+// most against their share over the week before, most trending first.
+func (s *ArticleAnalyzerService) GetTrendingTopics(ctx context.Context, limit int) ([]string, error) {
+	now := time.Now()
+	recentStart := now.Add(-trendingWindow)
+
+	recent, recentTotal, err := s.repository.CountTopics(ctx, recentStart, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recent topics: %w", err)
+	}
+	baseline, baselineTotal, err := s.repository.CountTopics(ctx, recentStart.Add(-trendingBaseline), recentStart)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count baseline topics: %w", err)
+	}
+	return trendingTopics(recent, recentTotal, baseline, baselineTotal, limit), nil
+}
+
+// trendingTopics ranks topics by the lift of their recent article share over
+// their baseline share. Both shares are Laplace-smoothed, so that a topic
+// absent from the baseline gets a large but finite lift, and only topics
+// whose share has risen are returned.
+func trendingTopics(recent map[string]int, recentTotal int, baseline map[string]int, baselineTotal, limit int) []string {
+	type trend struct {
+		phrase   string
+		articles int
+		lift     float64
+	}
+
+	var trends []trend
+	for phrase, articles := range recent {
+		if articles < minTrendingArticles {
+			continue
+		}
+		recentShare := float64(articles+1) / float64(recentTotal+2)
+		baselineShare := float64(baseline[phrase]+1) / float64(baselineTotal+2)
+		if lift := recentShare / baselineShare; lift > 1 {
+			trends = append(trends, trend{phrase: phrase, articles: articles, lift: lift})
+		}
+	}
+
+	sort.Slice(trends, func(i, j int) bool {
+		if trends[i].lift != trends[j].lift {
+			return trends[i].lift > trends[j].lift
+		}
+		if trends[i].articles != trends[j].articles {
+			return trends[i].articles > trends[j].articles
+		}
+		return trends[i].phrase < trends[j].phrase
+	})
+	if limit >= 0 && len(trends) > limit {
+		trends = trends[:limit]
+	}
+
+	topics := make([]string, len(trends))
+	for i, t := range trends {
+		topics[i] = t.phrase
+	}
+	return topics
+}
+
+// parseTimeRange parses a duration such as "90m" or "24h", or a number of
+// days such as "7d"
+func parseTimeRange(timeRange string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(timeRange, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid time range %q", timeRange)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	period, err := time.ParseDuration(timeRange)
+	if err != nil || period <= 0 {
+		return 0, fmt.Errorf("invalid time range %q", timeRange)
+	}
+	return period, nil
+}
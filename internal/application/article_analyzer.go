@@ -2,24 +2,119 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/reality-filter/internal/core/domain"
 	"github.com/reality-filter/internal/core/ports/primary"
 	"github.com/reality-filter/internal/core/ports/secondary"
+	"github.com/reality-filter/pkg/dates"
+	"github.com/reality-filter/pkg/readability"
+	"github.com/reality-filter/pkg/sanitize"
+	"github.com/reality-filter/pkg/stylometry"
+	"github.com/reality-filter/pkg/textstats"
 )
 
 // ArticleAnalyzerService implements the ArticleAnalyzer port
 type ArticleAnalyzerService struct {
-	repository      secondary.ArticleRepository
-	cache           secondary.ArticleCache
-	factChecker     secondary.FactChecker
-	contentAnalyzer secondary.ContentAnalyzer
-	eventPublisher  secondary.EventPublisher
+	repository        secondary.ArticleRepository
+	cache             secondary.ArticleCache
+	factChecker       secondary.FactChecker
+	contentAnalyzer   secondary.ContentAnalyzer
+	eventPublisher    secondary.EventPublisher
+	titleAnalyzer     secondary.TitleAnalyzer
+	languageDetector  secondary.LanguageDetector
+	claimExtractor    secondary.ClaimExtractor
+	quoteExtractor    secondary.QuoteExtractor
+	keyphrases        secondary.KeyphraseExtractor
+	consistency       []secondary.ConsistencyChecker
+	sources           secondary.SourceRepository
+	reputationHistory secondary.ReputationHistory
+	reviewOutcomes    secondary.ReviewOutcomeStore
 }
 
-// Ensure ArticleAnalyzerService implements primary.ArticleAnalyzer
-var _ primary.ArticleAnalyzer = (*ArticleAnalyzerService)(nil)
+// ArticleAnalyzerOption configures an optional analysis stage of the
+// ArticleAnalyzerService
+type ArticleAnalyzerOption func(*ArticleAnalyzerService)
+
+// WithTitleAnalyzer enables headline analysis
+func WithTitleAnalyzer(titleAnalyzer secondary.TitleAnalyzer) ArticleAnalyzerOption {
+	return func(s *ArticleAnalyzerService) {
+		s.titleAnalyzer = titleAnalyzer
+	}
+}
+
+// WithLanguageDetector enables language identification. The detected language
+// is passed to the other analyzers through the context.
+func WithLanguageDetector(languageDetector secondary.LanguageDetector) ArticleAnalyzerOption {
+	return func(s *ArticleAnalyzerService) {
+		s.languageDetector = languageDetector
+	}
+}
+
+// WithClaimExtractor enables check-worthy claim extraction. Claims are stored
+// on the article before fact-checking so fact checkers can work claim by
+// claim.
+func WithClaimExtractor(claimExtractor secondary.ClaimExtractor) ArticleAnalyzerOption {
+	return func(s *ArticleAnalyzerService) {
+		s.claimExtractor = claimExtractor
+	}
+}
+
+// WithQuoteExtractor enables quote and attribution extraction. Articles that
+// rely mostly on anonymous attributions are flagged as unverified.
+func WithQuoteExtractor(quoteExtractor secondary.QuoteExtractor) ArticleAnalyzerOption {
+	return func(s *ArticleAnalyzerService) {
+		s.quoteExtractor = quoteExtractor
+	}
+}
+
+// WithKeyphraseExtractor enables topic extraction. Topics are stored with
+// the article's metadata and back the trending topics analytics.
+func WithKeyphraseExtractor(keyphrases secondary.KeyphraseExtractor) ArticleAnalyzerOption {
+	return func(s *ArticleAnalyzerService) {
+		s.keyphrases = keyphrases
+	}
+}
+
+// WithConsistencyCheckers adds checks that compare parts of an article with
+// each other. They run after the article's metadata has been extracted.
+func WithConsistencyCheckers(checkers ...secondary.ConsistencyChecker) ArticleAnalyzerOption {
+	return func(s *ArticleAnalyzerService) {
+		s.consistency = append(s.consistency, checkers...)
+	}
+}
+
+// WithSourceRepository enables the source registry
+func WithSourceRepository(sources secondary.SourceRepository) ArticleAnalyzerOption {
+	return func(s *ArticleAnalyzerService) {
+		s.sources = sources
+	}
+}
+
+// WithReputationHistory enables learning source reputations from the
+// outcome of reviews: verified and rejected articles update the track
+// record of their source, the outcome counted for each article is kept in
+// outcomes, and every change is kept in the history. It needs the source
+// registry.
+func WithReputationHistory(history secondary.ReputationHistory, outcomes secondary.ReviewOutcomeStore) ArticleAnalyzerOption {
+	return func(s *ArticleAnalyzerService) {
+		s.reputationHistory = history
+		s.reviewOutcomes = outcomes
+	}
+}
+
+// Ensure ArticleAnalyzerService implements primary.ArticleAnalyzer,
+// primary.AuthorProfiler, primary.AnalyticsProvider and
+// primary.SourceRegistry
+var (
+	_ primary.ArticleAnalyzer   = (*ArticleAnalyzerService)(nil)
+	_ primary.AuthorProfiler    = (*ArticleAnalyzerService)(nil)
+	_ primary.AnalyticsProvider = (*ArticleAnalyzerService)(nil)
+	_ primary.SourceRegistry    = (*ArticleAnalyzerService)(nil)
+)
 
 // NewArticleAnalyzerService creates a new instance of ArticleAnalyzerService
 func NewArticleAnalyzerService(
@@ -28,32 +123,85 @@ func NewArticleAnalyzerService(
 	factChecker secondary.FactChecker,
 	contentAnalyzer secondary.ContentAnalyzer,
 	eventPublisher secondary.EventPublisher,
+	opts ...ArticleAnalyzerOption,
 ) *ArticleAnalyzerService {
-	return &ArticleAnalyzerService{
+	s := &ArticleAnalyzerService{
 		repository:      repository,
 		cache:           cache,
 		factChecker:     factChecker,
 		contentAnalyzer: contentAnalyzer,
 		eventPublisher:  eventPublisher,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // AnalyzeArticle performs comprehensive analysis on an article
 func (s *ArticleAnalyzerService) AnalyzeArticle(ctx context.Context, article *domain.Article) error {
+	// Clean the submitted content; every analyzer works on the clean text
+	content := normalizeContent(article)
+
+	// Step 0: Identify the language so analyzers can use language-specific resources
+	language, languageConfidence := "en", 0.0
+	if s.languageDetector != nil {
+		var err error
+		language, languageConfidence, err = s.languageDetector.DetectLanguage(ctx, content)
+		if err != nil {
+			return fmt.Errorf("failed to detect language: %w", err)
+		}
+	}
+	ctx = secondary.ContextWithLanguage(ctx, language)
+	// Normalization drops link targets and promotional boilerplate, which
+	// the spam detector needs
+	ctx = secondary.ContextWithRawContent(ctx, article.Content)
+
 	// Step 1: Analyze sentiment
-	sentiment, err := s.contentAnalyzer.AnalyzeSentiment(ctx, article.Content)
+	sentiment, err := s.contentAnalyzer.AnalyzeSentiment(ctx, content)
 	if err != nil {
 		return fmt.Errorf("failed to analyze sentiment: %w", err)
 	}
 
 	// Step 2: Extract entities
-	entities, err := s.contentAnalyzer.ExtractEntities(ctx, article.Content)
+	entities, err := s.contentAnalyzer.ExtractEntities(ctx, content)
 	if err != nil {
 		return fmt.Errorf("failed to extract entities: %w", err)
 	}
 
+	// Resolve dates such as "last Tuesday" against the publication time
+	normalizeDates(entities, article.CreatedAt)
+
+	// Step 2a: Extract check-worthy claims
+	if s.claimExtractor != nil {
+		claims, err := s.claimExtractor.ExtractClaims(ctx, content, entities)
+		if err != nil {
+			return fmt.Errorf("failed to extract claims: %w", err)
+		}
+		article.UpdateClaims(claims)
+	}
+
+	// Step 2b: Extract quotes and their attributions
+	var quotes []domain.Quote
+	if s.quoteExtractor != nil {
+		quotes, err = s.quoteExtractor.ExtractQuotes(ctx, content, entities)
+		if err != nil {
+			return fmt.Errorf("failed to extract quotes: %w", err)
+		}
+	}
+
+	// Step 2c: Extract the topics of the headline and body
+	var topics []domain.Topic
+	if s.keyphrases != nil {
+		text := sanitize.Sanitize(article.Title).Text + "\n\n" + content
+		topics, err = s.keyphrases.ExtractKeyphrases(ctx, article.ID.String(), text)
+		if err != nil {
+			return fmt.Errorf("failed to extract keyphrases: %w", err)
+		}
+	}
+
 	// Step 3: Detect bias
-	biasFlags, err := s.contentAnalyzer.DetectBias(ctx, article.Content)
+	biasFlags, err := s.contentAnalyzer.DetectBias(ctx, content)
 	if err != nil {
 		return fmt.Errorf("failed to detect bias: %w", err)
 	}
@@ -66,27 +214,77 @@ func (s *ArticleAnalyzerService) AnalyzeArticle(ctx context.Context, article *do
 
 	// Step 5: Get source reputation
 	sourceScore, err := s.factChecker.GetSourceReputation(ctx, article.Source)
+	if errors.Is(err, secondary.ErrUnknownSource) {
+		sourceScore, err = unknownSourceReputation, nil
+	}
 	if err != nil {
 		return fmt.Errorf("failed to get source reputation: %w", err)
 	}
 
+	// Step 6: Analyze the headline
+	var titleFlags []domain.Flag
+	if s.titleAnalyzer != nil {
+		titleFlags, err = s.titleAnalyzer.AnalyzeTitle(ctx, sanitize.Sanitize(article.Title).Text)
+		if err != nil {
+			return fmt.Errorf("failed to analyze title: %w", err)
+		}
+	}
+
 	// Update article metadata
+	stats := textstats.Compute(content, language)
+	readabilityMetrics := readability.Compute(content, language)
 	article.UpdateMetadata(domain.ArticleMetadata{
-		Entities:    entities,
-		Sentiment:   sentiment,
-		Language:    "en",                       // TODO: Implement language detection
-		WordCount:   len(article.Content),       // TODO: Implement proper word counting
-		ReadingTime: len(article.Content) / 200, // Rough estimate: 200 words per minute
+		Entities:           entities,
+		Quotes:             quotes,
+		Sentiment:          sentiment,
+		Language:           language,
+		LanguageConfidence: languageConfidence,
+		WordCount:          stats.Words,
+		SentenceCount:      stats.Sentences,
+		ParagraphCount:     stats.Paragraphs,
+		ReadingTime:        stats.ReadingTime,
+		Readability: domain.ReadabilityMetrics{
+			FleschKincaidGrade: readabilityMetrics.FleschKincaidGrade,
+			GunningFog:         readabilityMetrics.GunningFog,
+			SMOG:               readabilityMetrics.SMOG,
+			AvgSentenceLength:  readabilityMetrics.AvgSentenceLength,
+			PassiveVoiceRatio:  readabilityMetrics.PassiveVoiceRatio,
+			ExclamationDensity: readabilityMetrics.ExclamationDensity,
+			AllCapsRatio:       readabilityMetrics.AllCapsRatio,
+		},
+		Style:  stylometry.Extract(content, language),
+		Topics: topics,
 	})
 
-	// Add all detected flags
-	for _, flag := range biasFlags {
-		article.AddFlag(flag.Type, flag.Confidence, flag.Details, "bias_detector")
+	// Step 7: Check the article for internal inconsistencies
+	var consistencyFlags []domain.Flag
+	for _, checker := range s.consistency {
+		flags, err := checker.CheckConsistency(ctx, article)
+		if err != nil {
+			return fmt.Errorf("failed to check consistency: %w", err)
+		}
+		consistencyFlags = append(consistencyFlags, flags...)
 	}
-	for _, flag := range factFlags {
-		article.AddFlag(flag.Type, flag.Confidence, flag.Details, "fact_checker")
+
+	// Step 8: Compare the article's style with the author's earlier articles
+	// in the same language
+	var authorFlags []domain.Flag
+	if article.Author != "" && len(article.MetaData.Style) > 0 {
+		profile, _, err := s.authorProfile(ctx, article.Author, language, article.ID)
+		if err != nil {
+			return fmt.Errorf("failed to build author profile: %w", err)
+		}
+		authorFlags = authorshipFlags(article.Author, profile, article.MetaData.Style)
 	}
 
+	// Add all detected flags
+	addFlags(article, biasFlags, "bias_detector")
+	addFlags(article, factFlags, "fact_checker")
+	addFlags(article, titleFlags, "title_analyzer")
+	addFlags(article, attributionFlags(quotes), "attribution_checker")
+	addFlags(article, consistencyFlags, "consistency_checker")
+	addFlags(article, authorFlags, "authorship_checker")
+
 	// Calculate final credibility score (simple weighted average)
 	credibilityScore := calculateCredibilityScore(sourceScore, sentiment, len(article.Flags))
 	article.UpdateScore(credibilityScore)
@@ -121,6 +319,11 @@ func (s *ArticleAnalyzerService) AnalyzeArticle(ctx context.Context, article *do
 		}
 	}
 
+	// Reanalysis discards an earlier review, so its outcome no longer counts
+	if err := s.reconcileReputation(ctx, article); err != nil {
+		fmt.Printf("failed to update source reputation: %v\n", err)
+	}
+
 	return nil
 }
 
@@ -156,6 +359,7 @@ func (s *ArticleAnalyzerService) ReprocessArticle(ctx context.Context, articleID
 	// Clear existing analysis results
 	article.Flags = make([]domain.Flag, 0)
 	article.Score = 0
+	article.Claims = nil
 	article.Status = domain.ArticleStatusPending
 	article.MetaData = domain.ArticleMetadata{
 		Entities: make([]domain.Entity, 0),
@@ -165,6 +369,51 @@ func (s *ArticleAnalyzerService) ReprocessArticle(ctx context.Context, articleID
 	return s.AnalyzeArticle(ctx, article)
 }
 
+// addFlags adds the flags to the article, attributing each one to the
+// detector that reported it or to the given default stage name
+func addFlags(article *domain.Article, flags []domain.Flag, defaultDetector string) {
+	for _, flag := range flags {
+		detectedBy := flag.DetectedBy
+		if detectedBy == "" {
+			detectedBy = defaultDetector
+		}
+		article.AddFlag(flag.Type, flag.Confidence, flag.Details, detectedBy)
+	}
+}
+
+// normalizeContent sanitizes the article content, stores the clean text and
+// its offset map on the article, and returns the clean text
+func normalizeContent(article *domain.Article) string {
+	cleaned := sanitize.Sanitize(article.Content)
+	segments := make([]domain.OffsetSegment, len(cleaned.Segments))
+	for i, seg := range cleaned.Segments {
+		segments[i] = domain.OffsetSegment{
+			CleanStart: seg.CleanStart,
+			CleanEnd:   seg.CleanEnd,
+			RawStart:   seg.RawStart,
+			RawEnd:     seg.RawEnd,
+		}
+	}
+	article.UpdateCleanText(cleaned.Text, segments)
+	return cleaned.Text
+}
+
+// normalizeDates sets the ISO 8601 form of every DATE entity that can be
+// resolved relative to the publication time
+func normalizeDates(entities []domain.Entity, published time.Time) {
+	for i := range entities {
+		if entities[i].Type != domain.EntityTypeDate {
+			continue
+		}
+		if d, ok := dates.Normalize(entities[i].Value, published); ok {
+			entities[i].Normalized = d.ISO()
+		}
+	}
+}
+
+// unknownSourceReputation is the reputation of sources no fact checker knows
+const unknownSourceReputation = 0.5
+
 // calculateCredibilityScore calculates the final credibility score
 func calculateCredibilityScore(sourceScore, sentiment float64, numFlags int) float64 {
 	// Simple weighted average:
@@ -220,12 +469,62 @@ func (s *ArticleAnalyzerService) ListFlaggedArticles(ctx context.Context, limit,
 	return s.repository.FindFlagged(ctx, limit, offset)
 }
 
-// UpdateArticleStatus implements the ArticleManager interface
+// UpdateClaimVerdict implements the ArticleManager interface
+func (s *ArticleAnalyzerService) UpdateClaimVerdict(ctx context.Context, articleID, claimID string, verdict domain.ClaimVerdict, details, reviewer string) error {
+	if !verdict.IsValid() {
+		return fmt.Errorf("%w: unknown verdict %q", domain.ErrInvalidClaimReview, verdict)
+	}
+	id, err := uuid.Parse(claimID)
+	if err != nil {
+		return fmt.Errorf("%w: malformed claim id: %v", domain.ErrInvalidClaimReview, err)
+	}
+
+	article, err := s.repository.FindByID(ctx, articleID)
+	if err != nil {
+		return fmt.Errorf("failed to find article: %w", err)
+	}
+	if article == nil {
+		return fmt.Errorf("%w: %s", domain.ErrArticleNotFound, articleID)
+	}
+
+	claim := article.FindClaim(id)
+	if claim == nil {
+		return fmt.Errorf("%w: %s", domain.ErrClaimNotFound, claimID)
+	}
+	claim.SetVerdict(verdict, details, reviewer)
+
+	if err := s.repository.Update(ctx, article); err != nil {
+		return fmt.Errorf("failed to update article: %w", err)
+	}
+	if err := s.cache.Set(ctx, article); err != nil {
+		fmt.Printf("failed to update cache: %v\n", err)
+	}
+	return nil
+}
+
+// UpdateArticleStatus implements the ArticleManager interface. Verifying or
+// rejecting an article updates the reputation of its source.
 func (s *ArticleAnalyzerService) UpdateArticleStatus(ctx context.Context, id string, status domain.ArticleStatus) error {
+	if !status.IsValid() {
+		return fmt.Errorf("invalid status %q", status)
+	}
 	article, err := s.repository.FindByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	if article == nil {
+		return fmt.Errorf("article %s not found", id)
+	}
 	article.UpdateStatus(status)
-	return s.repository.Update(ctx, article)
+	if err := s.repository.Update(ctx, article); err != nil {
+		return err
+	}
+	if err := s.cache.Set(ctx, article); err != nil {
+		fmt.Printf("failed to update cache: %v\n", err)
+	}
+
+	if err := s.reconcileReputation(ctx, article); err != nil {
+		fmt.Printf("failed to update source reputation: %v\n", err)
+	}
+	return nil
 }
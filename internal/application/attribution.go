@@ -0,0 +1,56 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/reality-filter/internal/core/domain"
+)
+
+const (
+	// minAttributions is the number of attributed quotes an article needs
+	// before its share of anonymous attributions is judged
+	minAttributions = 3
+	// anonymousAttributionThreshold is the share of anonymous attributions
+	// at which an article is flagged as unverified
+	anonymousAttributionThreshold = 0.5
+	// maxAttributionExamples bounds the attribution phrases quoted in flag
+	// details
+	maxAttributionExamples = 5
+)
+
+// attributionFlags returns an UNVERIFIED flag when at least half of an
+// article's quotes are attributed to no one in particular. The confidence is
+// the anonymous share.
+func attributionFlags(quotes []domain.Quote) []domain.Flag {
+	if len(quotes) < minAttributions {
+		return nil
+	}
+
+	var examples []string
+	anonymous := 0
+	for _, q := range quotes {
+		if !q.Anonymous {
+			continue
+		}
+		anonymous++
+		if len(examples) < maxAttributionExamples {
+			if q.Attribution != "" {
+				examples = append(examples, fmt.Sprintf("%q", q.Attribution))
+			} else {
+				examples = append(examples, "unattributed quote")
+			}
+		}
+	}
+
+	share := float64(anonymous) / float64(len(quotes))
+	if share < anonymousAttributionThreshold {
+		return nil
+	}
+	return []domain.Flag{{
+		Type:       domain.FlagTypeUnverified,
+		Confidence: share,
+		Details: fmt.Sprintf("%d of %d quotes have anonymous or no attribution: %s",
+			anonymous, len(quotes), strings.Join(examples, ", ")),
+	}}
+}
@@ -0,0 +1,138 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/reality-filter/internal/core/domain"
+	"github.com/reality-filter/pkg/stylometry"
+)
+
+const (
+	// minProfileArticles is the number of earlier articles an author needs
+	// before new articles are compared with their profile
+	minProfileArticles = 5
+	// maxProfileArticles bounds the articles a profile is built from
+	maxProfileArticles = 50
+	// impersonationThreshold is the stylometric delta at which an article is
+	// flagged as not written by its claimed author
+	impersonationThreshold = 2.0
+	// maxStyleExamples bounds the deviating features quoted in flag details
+	maxStyleExamples = 3
+)
+
+// GetAuthorProfile implements the AuthorProfiler interface. It returns nil
+// when the author has no analyzed articles long enough to profile.
+func (s *ArticleAnalyzerService) GetAuthorProfile(ctx context.Context, author string) (*domain.AuthorProfile, error) {
+	profile, updatedAt, err := s.authorProfile(ctx, author, "", uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+	if profile.Samples == 0 {
+		return nil, nil
+	}
+
+	features := make([]domain.StyleFeature, 0, len(profile.Mean))
+	for name, mean := range profile.Mean {
+		features = append(features, domain.StyleFeature{
+			Name:        name,
+			Description: stylometry.Describe(name),
+			Mean:        math.Round(mean*100) / 100,
+			StdDev:      math.Round(profile.StdDev[name]*100) / 100,
+		})
+	}
+	sort.Slice(features, func(i, j int) bool { return features[i].Name < features[j].Name })
+
+	return &domain.AuthorProfile{
+		Author:    author,
+		Articles:  profile.Samples,
+		Features:  features,
+		UpdatedAt: updatedAt,
+	}, nil
+}
+
+// authorProfile builds the stylometric profile of an author from their most
+// recent analyzed articles in the given language, or in any language if it
+// is empty, leaving out the excluded article, rejected articles and articles
+// already flagged as impersonations. Style features depend on the language,
+// so articles in one language are not compared with a profile of another.
+// It also returns the last update time of the newest article used.
+func (s *ArticleAnalyzerService) authorProfile(ctx context.Context, author, language string, exclude uuid.UUID) (stylometry.Profile, time.Time, error) {
+	var samples []stylometry.Features
+	var updatedAt time.Time
+	for offset := 0; len(samples) < maxProfileArticles; offset += maxProfileArticles {
+		articles, err := s.repository.FindByAuthor(ctx, author, maxProfileArticles, offset)
+		if err != nil {
+			return stylometry.Profile{}, time.Time{}, fmt.Errorf("failed to find articles by author: %w", err)
+		}
+		for _, a := range articles {
+			if !profileable(a, exclude) || (language != "" && a.MetaData.Language != language) {
+				continue
+			}
+			samples = append(samples, a.MetaData.Style)
+			if a.UpdatedAt.After(updatedAt) {
+				updatedAt = a.UpdatedAt
+			}
+			if len(samples) == maxProfileArticles {
+				break
+			}
+		}
+		if len(articles) < maxProfileArticles {
+			break
+		}
+	}
+	return stylometry.BuildProfile(samples), updatedAt, nil
+}
+
+// profileable reports whether an article can contribute to its author's
+// profile
+func profileable(article *domain.Article, exclude uuid.UUID) bool {
+	if article.ID == exclude || len(article.MetaData.Style) == 0 {
+		return false
+	}
+	switch article.Status {
+	case domain.ArticleStatusAnalyzed, domain.ArticleStatusFlagged, domain.ArticleStatusVerified:
+	default:
+		return false
+	}
+	for _, f := range article.Flags {
+		if f.Type == domain.FlagTypeImpersonation {
+			return false
+		}
+	}
+	return true
+}
+
+// authorshipFlags returns an IMPERSONATION flag when the article's style
+// deviates strongly from the profile of its claimed author. The confidence
+// rises from 0.5 at the threshold towards 1.
+func authorshipFlags(author string, profile stylometry.Profile, style stylometry.Features) []domain.Flag {
+	if profile.Samples < minProfileArticles || len(style) == 0 {
+		return nil
+	}
+
+	comparison := stylometry.Compare(profile, style)
+	if comparison.Delta < impersonationThreshold {
+		return nil
+	}
+
+	var examples []string
+	for _, d := range comparison.Deviations {
+		if len(examples) == maxStyleExamples {
+			break
+		}
+		examples = append(examples, fmt.Sprintf("%s %.1f vs %.1f±%.1f",
+			stylometry.Describe(d.Feature), d.Value, d.Mean, d.StdDev))
+	}
+	return []domain.Flag{{
+		Type:       domain.FlagTypeImpersonation,
+		Confidence: 1 - 0.5*math.Exp(-(comparison.Delta-impersonationThreshold)),
+		Details: fmt.Sprintf("style deviates from %d earlier articles by %s (delta %.2f): %s",
+			profile.Samples, author, comparison.Delta, strings.Join(examples, "; ")),
+	}}
+}
@@ -0,0 +1,515 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/reality-filter/internal/core/domain"
+)
+
+const (
+	// verifiedWeight is how much a verified article counts for its source
+	verifiedWeight = 1.0
+	// maxSourceUpdateAttempts bounds the retries of a source update that
+	// races with another one
+	maxSourceUpdateAttempts = 5
+)
+
+// rejectionWeights is how much a rejected article counts against its source
+// by the most severe of its flags. Flags not listed, and rejected articles
+// without flags, count 1.
+var rejectionWeights = map[domain.FlagType]float64{
+	domain.FlagTypeFactualError:  2,
+	domain.FlagTypeHateSpeech:    2,
+	domain.FlagTypeImpersonation: 1.5,
+	domain.FlagTypeMisleading:    1.5,
+	domain.FlagTypeClickbait:     0.75,
+	domain.FlagTypeBiased:        0.75,
+}
+
+// reviewOutcome reports whether reviewers verified or rejected an article
+func reviewOutcome(status domain.ArticleStatus) (verified, reviewed bool) {
+	switch status {
+	case domain.ArticleStatusVerified:
+		return true, true
+	case domain.ArticleStatusRejected:
+		return false, true
+	}
+	return false, false
+}
+
+// outcomeWeight returns how much the review of an article counts for its
+// source, and the types of the article's flags
+func outcomeWeight(article *domain.Article, verified bool) (float64, []domain.FlagType) {
+	seen := make(map[domain.FlagType]bool)
+	var flags []domain.FlagType
+	for _, flag := range article.Flags {
+		if !seen[flag.Type] {
+			seen[flag.Type] = true
+			flags = append(flags, flag.Type)
+		}
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i] < flags[j] })
+
+	if verified {
+		return verifiedWeight, flags
+	}
+	weight := 0.0
+	for _, flag := range flags {
+		w, ok := rejectionWeights[flag]
+		if !ok {
+			w = 1
+		}
+		weight = max(weight, w)
+	}
+	if weight == 0 {
+		weight = 1
+	}
+	return weight, flags
+}
+
+// reconcileReputation brings the track record of an article's source in
+// line with the article's status: the outcome of an earlier review that no
+// longer holds is retracted, and a verified or rejected article is
+// recorded. The outcome counted for each article is kept in the review
+// outcomes; a source marks the change to its track record as pending in the
+// same update, until the outcome is stored, so calling it again for the
+// same status changes nothing, even if storing the outcome or adding to the
+// history failed.
+func (s *ArticleAnalyzerService) reconcileReputation(ctx context.Context, article *domain.Article) error {
+	if s.sources == nil || s.reputationHistory == nil || s.reviewOutcomes == nil {
+		return nil
+	}
+	verified, reviewed := reviewOutcome(article.Status)
+
+	var entry *domain.Source
+	var err error
+	if reviewed {
+		entry, err = s.sourceOf(ctx, article)
+	} else {
+		entry, err = s.registeredSourceOf(ctx, article)
+	}
+	if err != nil {
+		return err
+	}
+
+	recorded, err := s.recordedOutcome(ctx, article.ID.String(), entry)
+	if err != nil {
+		return err
+	}
+	if recorded != nil {
+		if reviewed && entry != nil && recorded.Domain == entry.Domain && recorded.Verified == verified {
+			// Already counted, by an earlier call for the same status
+			return nil
+		}
+		if err := s.retractOutcome(ctx, article, *recorded); err != nil {
+			return err
+		}
+	}
+	if !reviewed || entry == nil {
+		return nil
+	}
+	return s.recordOutcome(ctx, article, entry.Domain, verified)
+}
+
+// recordedOutcome returns the outcome counted for an article, after storing
+// the pending changes of the article's source and of the source that counts
+// the outcome, if the registry has changed since
+func (s *ArticleAnalyzerService) recordedOutcome(ctx context.Context, articleID string, entry *domain.Source) (*domain.ReviewOutcome, error) {
+	recorded, err := s.reviewOutcomes.Find(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review outcome: %w", err)
+	}
+	var domains []string
+	if entry != nil {
+		domains = append(domains, entry.Domain)
+	}
+	if recorded != nil && (entry == nil || recorded.Domain != entry.Domain) {
+		domains = append(domains, recorded.Domain)
+	}
+
+	settled := false
+	for _, d := range domains {
+		n, err := s.settlePendingOutcomes(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+		settled = settled || n > 0
+	}
+	if !settled {
+		return recorded, nil
+	}
+	recorded, err = s.reviewOutcomes.Find(ctx, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review outcome: %w", err)
+	}
+	return recorded, nil
+}
+
+// retractOutcome withdraws the outcome counted for an article from the
+// track record of its source
+func (s *ArticleAnalyzerService) retractOutcome(ctx context.Context, article *domain.Article, recorded domain.ReviewOutcome) error {
+	busy := false
+	source, previous, err := s.changeSource(ctx, recorded.Domain, func(source *domain.Source, now time.Time) bool {
+		if _, busy = source.Pending[recorded.ArticleID]; busy {
+			return false
+		}
+		source.RetractOutcome(recorded, now)
+		return true
+	})
+	switch {
+	case err != nil:
+		return err
+	case busy:
+		return fmt.Errorf("review of article %s is being counted concurrently", recorded.ArticleID)
+	}
+
+	pending := domain.PendingOutcome{Outcome: recorded, Retracted: true}
+	if source == nil {
+		// The source was removed from the registry, with its track record
+		return s.storeOutcome(ctx, pending)
+	}
+	s.recordReviewChange(ctx, source, previous, &domain.ReputationChange{
+		Cause:     domain.ReputationCauseRetracted,
+		ArticleID: recorded.ArticleID,
+		Verified:  recorded.Verified,
+		Weight:    recorded.Weight,
+		Flags:     recorded.Flags,
+		Details:   fmt.Sprintf("review of article %s withdrawn: it is now %s", recorded.ArticleID, article.Status),
+	})
+	return s.settleOutcome(ctx, source.Domain, pending)
+}
+
+// recordOutcome counts the outcome of the review of an article in the track
+// record of the given source
+func (s *ArticleAnalyzerService) recordOutcome(ctx context.Context, article *domain.Article, domainName string, verified bool) error {
+	articleID := article.ID.String()
+	weight, flags := outcomeWeight(article, verified)
+
+	busy := false
+	var pending domain.PendingOutcome
+	source, previous, err := s.changeSource(ctx, domainName, func(source *domain.Source, now time.Time) bool {
+		if _, busy = source.Pending[articleID]; busy {
+			return false
+		}
+		source.RecordOutcome(domain.ReviewOutcome{
+			ArticleID:  articleID,
+			Domain:     source.Domain,
+			Verified:   verified,
+			Weight:     weight,
+			Flags:      flags,
+			RecordedAt: now,
+		})
+		pending = source.Pending[articleID]
+		return true
+	})
+	switch {
+	case err != nil:
+		return err
+	case busy:
+		return fmt.Errorf("review of article %s is being counted concurrently", articleID)
+	case source == nil:
+		return nil
+	}
+
+	change := &domain.ReputationChange{
+		Cause:     domain.ReputationCauseVerified,
+		ArticleID: articleID,
+		Verified:  verified,
+		Weight:    weight,
+		Flags:     flags,
+		Details:   fmt.Sprintf("article %s was verified", articleID),
+	}
+	if !verified {
+		change.Cause = domain.ReputationCauseRejected
+		change.Details = fmt.Sprintf("article %s was rejected", articleID)
+		if len(flags) > 0 {
+			names := make([]string, len(flags))
+			for i, flag := range flags {
+				names[i] = string(flag)
+			}
+			change.Details += " with flags " + strings.Join(names, ", ")
+		}
+	}
+	change.Details += fmt.Sprintf(" and counts as %.2f reviewed articles", weight)
+	if source.Override != nil {
+		change.Details += "; the reputation is overridden, so it takes effect once the override is cleared"
+	}
+	s.recordReviewChange(ctx, source, previous, change)
+	return s.settleOutcome(ctx, source.Domain, pending)
+}
+
+// settlePendingOutcomes stores the pending outcome changes of a source,
+// such as those left by a failure between updating the source and storing
+// the outcome, and returns how many there were
+func (s *ArticleAnalyzerService) settlePendingOutcomes(ctx context.Context, domainName string) (int, error) {
+	source, err := s.sources.FindByDomain(ctx, domainName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get source: %w", err)
+	}
+	if source == nil {
+		return 0, nil
+	}
+	for _, pending := range source.Pending {
+		if err := s.settleOutcome(ctx, domainName, pending); err != nil {
+			return 0, err
+		}
+	}
+	return len(source.Pending), nil
+}
+
+// settleOutcome stores a pending outcome change of a source and clears it
+func (s *ArticleAnalyzerService) settleOutcome(ctx context.Context, domainName string, pending domain.PendingOutcome) error {
+	if err := s.storeOutcome(ctx, pending); err != nil {
+		return err
+	}
+	_, _, err := s.changeSource(ctx, domainName, func(source *domain.Source, now time.Time) bool {
+		return source.SettleOutcome(pending)
+	})
+	return err
+}
+
+// storeOutcome applies an outcome change to the stored review outcomes
+func (s *ArticleAnalyzerService) storeOutcome(ctx context.Context, pending domain.PendingOutcome) error {
+	outcome := pending.Outcome
+	var err error
+	if pending.Retracted {
+		err = s.reviewOutcomes.Delete(ctx, outcome.ArticleID, outcome.Domain)
+	} else {
+		err = s.reviewOutcomes.Save(ctx, &outcome)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to store review outcome: %w", err)
+	}
+	return nil
+}
+
+// recordReviewChange adds a change caused by a review to the history.
+// Failures are logged, since the history only explains the track record.
+func (s *ArticleAnalyzerService) recordReviewChange(ctx context.Context, source *domain.Source, previous float64, change *domain.ReputationChange) {
+	if err := s.appendReputationChange(ctx, source, previous, change); err != nil {
+		fmt.Printf("failed to add review to reputation history: %v\n", err)
+	}
+}
+
+// registeredSourceOf returns the registry entry of an article's source, or
+// nil if it is not registered
+func (s *ArticleAnalyzerService) registeredSourceOf(ctx context.Context, article *domain.Article) (*domain.Source, error) {
+	for _, d := range domain.ParentDomains(domain.SourceDomain(article.Source)) {
+		entry, err := s.sources.FindByDomain(ctx, d)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get source: %w", err)
+		}
+		if entry != nil {
+			return entry, nil
+		}
+	}
+	if strings.TrimSpace(article.Source) == "" {
+		return nil, nil
+	}
+	entry, err := s.sources.FindByName(ctx, article.Source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source: %w", err)
+	}
+	return entry, nil
+}
+
+// sourceOf returns the registry entry of an article's source. Sources
+// given as domains are registered with the default reputation if they are
+// not yet; it returns nil for other unknown sources.
+func (s *ArticleAnalyzerService) sourceOf(ctx context.Context, article *domain.Article) (*domain.Source, error) {
+	entry, err := s.registeredSourceOf(ctx, article)
+	if err != nil || entry != nil {
+		return entry, err
+	}
+	if strings.TrimSpace(article.Source) == "" {
+		return nil, nil
+	}
+
+	entry = domain.NewSource(article.Source, domain.SourceDomain(article.Source))
+	if entry.Validate() != nil {
+		return nil, nil
+	}
+	err = s.CreateSource(ctx, entry)
+	if errors.Is(err, domain.ErrSourceExists) {
+		return s.sources.FindByDomain(ctx, entry.Domain)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// changeSource applies change to a registered source and stores it,
+// starting over if the source was updated in the meantime. It returns the
+// source and its reputation before the change, or nil if the source is not
+// registered or change reports that it made no change.
+func (s *ArticleAnalyzerService) changeSource(ctx context.Context, domainName string, change func(source *domain.Source, now time.Time) bool) (*domain.Source, float64, error) {
+	for attempt := 1; ; attempt++ {
+		source, err := s.sources.FindByDomain(ctx, domainName)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get source: %w", err)
+		}
+		if source == nil {
+			return nil, 0, nil
+		}
+
+		now := time.Now()
+		previous := source.ReputationAt(now)
+		if !change(source, now) {
+			return nil, 0, nil
+		}
+
+		err = s.sources.Update(ctx, source)
+		switch {
+		case errors.Is(err, domain.ErrSourceConflict) && attempt < maxSourceUpdateAttempts:
+			continue
+		case errors.Is(err, domain.ErrSourceNotFound):
+			return nil, 0, nil
+		case err != nil:
+			return nil, 0, fmt.Errorf("failed to update source: %w", err)
+		}
+		return source, previous, nil
+	}
+}
+
+// appendReputationChange completes a change to a source's reputation with
+// the source's state and adds it to the history
+func (s *ArticleAnalyzerService) appendReputationChange(ctx context.Context, source *domain.Source, previous float64, change *domain.ReputationChange) error {
+	change.Domain = source.Domain
+	change.At = source.UpdatedAt
+	change.Previous = previous
+	change.Reputation = source.Reputation
+	change.TrackRecord = source.TrackRecord
+	if err := s.reputationHistory.Append(ctx, change); err != nil {
+		return fmt.Errorf("failed to record reputation change: %w", err)
+	}
+	return nil
+}
+
+// recordAssessment adds a change of a source's assessed reputation to the
+// history. Failures are logged, since the source itself is stored.
+func (s *ArticleAnalyzerService) recordAssessment(ctx context.Context, source *domain.Source, previous float64, details string) {
+	if s.reputationHistory == nil {
+		return
+	}
+	err := s.appendReputationChange(ctx, source, previous, &domain.ReputationChange{
+		Cause:   domain.ReputationCauseAssessment,
+		Actor:   source.Provenance.AssessedBy,
+		Details: details,
+	})
+	if err != nil {
+		fmt.Printf("failed to record reputation assessment: %v\n", err)
+	}
+}
+
+// ExplainReputation implements the SourceRegistry interface
+func (s *ArticleAnalyzerService) ExplainReputation(ctx context.Context, domainName string, limit, offset int) (*domain.ReputationExplanation, error) {
+	if s.sources == nil {
+		return nil, errNoSourceRegistry
+	}
+	source, err := s.sources.FindByDomain(ctx, domain.SourceDomain(domainName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source: %w", err)
+	}
+	if source == nil {
+		return nil, nil
+	}
+
+	explanation := source.ExplainReputation(time.Now())
+	explanation.History = []*domain.ReputationChange{}
+	if s.reputationHistory != nil {
+		history, err := s.reputationHistory.List(ctx, source.Domain, limit, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get reputation history: %w", err)
+		}
+		if history != nil {
+			explanation.History = history
+		}
+	}
+	return explanation, nil
+}
+
+// OverrideReputation implements the SourceRegistry interface
+func (s *ArticleAnalyzerService) OverrideReputation(ctx context.Context, domainName string, override domain.ReputationOverride) (*domain.Source, error) {
+	if s.sources == nil {
+		return nil, errNoSourceRegistry
+	}
+	if override.Reputation < 0 || override.Reputation > 1 {
+		return nil, fmt.Errorf("%w: override %v is outside 0-1", domain.ErrInvalidSource, override.Reputation)
+	}
+	if strings.TrimSpace(override.SetBy) == "" {
+		return nil, fmt.Errorf("%w: the override needs the name of who sets it", domain.ErrInvalidSource)
+	}
+
+	source, previous, err := s.changeSource(ctx, domain.SourceDomain(domainName), func(source *domain.Source, now time.Time) bool {
+		o := override
+		o.SetAt = now
+		source.SetOverride(&o, now)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		return nil, domain.ErrSourceNotFound
+	}
+
+	if s.reputationHistory != nil {
+		details := fmt.Sprintf("reputation set to %.2f by %s", override.Reputation, override.SetBy)
+		if override.Reason != "" {
+			details += ": " + override.Reason
+		}
+		err := s.appendReputationChange(ctx, source, previous, &domain.ReputationChange{
+			Cause:   domain.ReputationCauseOverride,
+			Actor:   override.SetBy,
+			Details: details,
+		})
+		if err != nil {
+			fmt.Printf("failed to record reputation override: %v\n", err)
+		}
+	}
+	return source, nil
+}
+
+// ClearReputationOverride implements the SourceRegistry interface. Sources
+// without an override are returned unchanged.
+func (s *ArticleAnalyzerService) ClearReputationOverride(ctx context.Context, domainName, by string) (*domain.Source, error) {
+	if s.sources == nil {
+		return nil, errNoSourceRegistry
+	}
+	if strings.TrimSpace(by) == "" {
+		return nil, fmt.Errorf("%w: removing an override needs the name of who removes it", domain.ErrInvalidSource)
+	}
+
+	source, previous, err := s.changeSource(ctx, domain.SourceDomain(domainName), func(source *domain.Source, now time.Time) bool {
+		if source.Override == nil {
+			return false
+		}
+		source.SetOverride(nil, now)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if source == nil {
+		// Either the source is not registered or it has no override
+		return s.GetSource(ctx, domainName)
+	}
+
+	if s.reputationHistory != nil {
+		err := s.appendReputationChange(ctx, source, previous, &domain.ReputationChange{
+			Cause:   domain.ReputationCauseOverrideRemoved,
+			Actor:   by,
+			Details: fmt.Sprintf("override removed by %s; the reputation is learned again", by),
+		})
+		if err != nil {
+			fmt.Printf("failed to record reputation override removal: %v\n", err)
+		}
+	}
+	return source, nil
+}
@@ -0,0 +1,120 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/reality-filter/internal/core/domain"
+)
+
+// errNoSourceRegistry is returned by the source registry methods when the
+// service has no source repository
+var errNoSourceRegistry = errors.New("source registry is not configured")
+
+// CreateSource implements the SourceRegistry interface
+func (s *ArticleAnalyzerService) CreateSource(ctx context.Context, source *domain.Source) error {
+	if s.sources == nil {
+		return errNoSourceRegistry
+	}
+	source.Domain = domain.SourceDomain(source.Domain)
+	if err := source.Validate(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	source.CreatedAt = now
+	source.Version = 0
+	if source.Provenance.AssessedAt.IsZero() {
+		source.Provenance.AssessedAt = now
+	}
+	source.Refresh(now)
+	if err := s.sources.Create(ctx, source); err != nil {
+		return fmt.Errorf("failed to create source: %w", err)
+	}
+
+	s.recordAssessment(ctx, source, source.Reputation,
+		fmt.Sprintf("registered with %s reputation %.2f", strings.ToLower(string(source.Provenance.Method)), source.Assessment))
+	return nil
+}
+
+// GetSource implements the SourceRegistry interface
+func (s *ArticleAnalyzerService) GetSource(ctx context.Context, domainName string) (*domain.Source, error) {
+	if s.sources == nil {
+		return nil, errNoSourceRegistry
+	}
+	source, err := s.sources.FindByDomain(ctx, domain.SourceDomain(domainName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source: %w", err)
+	}
+	if source != nil {
+		source.Reputation = source.ReputationAt(time.Now())
+	}
+	return source, nil
+}
+
+// ListSources implements the SourceRegistry interface
+func (s *ArticleAnalyzerService) ListSources(ctx context.Context, limit, offset int) ([]*domain.Source, error) {
+	if s.sources == nil {
+		return nil, errNoSourceRegistry
+	}
+	sources, err := s.sources.List(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sources: %w", err)
+	}
+	now := time.Now()
+	for _, source := range sources {
+		source.Reputation = source.ReputationAt(now)
+	}
+	return sources, nil
+}
+
+// UpdateSource implements the SourceRegistry interface. The source keeps
+// its registration time; it must have the version it was read with.
+func (s *ArticleAnalyzerService) UpdateSource(ctx context.Context, source *domain.Source) error {
+	if s.sources == nil {
+		return errNoSourceRegistry
+	}
+	source.Domain = domain.SourceDomain(source.Domain)
+	if err := source.Validate(); err != nil {
+		return err
+	}
+
+	existing, err := s.sources.FindByDomain(ctx, source.Domain)
+	if err != nil {
+		return fmt.Errorf("failed to get source: %w", err)
+	}
+	if existing == nil {
+		return domain.ErrSourceNotFound
+	}
+	now := time.Now()
+	source.CreatedAt = existing.CreatedAt
+	if source.Provenance.AssessedAt.IsZero() {
+		source.Provenance.AssessedAt = now
+	}
+	source.Refresh(now)
+
+	if err := s.sources.Update(ctx, source); err != nil {
+		return fmt.Errorf("failed to update source: %w", err)
+	}
+
+	if source.Assessment != existing.Assessment || !source.Provenance.AssessedAt.Equal(existing.Provenance.AssessedAt) {
+		s.recordAssessment(ctx, source, existing.ReputationAt(now),
+			fmt.Sprintf("assessed reputation changed from %.2f to %.2f (%s)",
+				existing.Assessment, source.Assessment, strings.ToLower(string(source.Provenance.Method))))
+	}
+	return nil
+}
+
+// DeleteSource implements the SourceRegistry interface
+func (s *ArticleAnalyzerService) DeleteSource(ctx context.Context, domainName string) error {
+	if s.sources == nil {
+		return errNoSourceRegistry
+	}
+	if err := s.sources.Delete(ctx, domain.SourceDomain(domainName)); err != nil {
+		return fmt.Errorf("failed to delete source: %w", err)
+	}
+	return nil
+}
@@ -1,16 +1,23 @@
 package domain
 
 import (
+	"errors"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrArticleNotFound is returned when an article does not exist
+var ErrArticleNotFound = errors.New("article not found")
+
 // Article represents the core domain entity for a news article
 type Article struct {
 	ID        uuid.UUID
 	Title     string
 	Content   string
+	CleanText string          // Content without markup and boilerplate; the text the analyzers ran on
+	OffsetMap []OffsetSegment // maps offsets in CleanText back to Content
 	Source    string
 	Author    string
 	Tags      []string
@@ -18,23 +25,75 @@ type Article struct {
 	UpdatedAt time.Time
 	Score     float64
 	Flags     []Flag
+	Claims    []Claim
 	Status    ArticleStatus
 	MetaData  ArticleMetadata
 }
 
 // ArticleMetadata contains extracted information about the article
 type ArticleMetadata struct {
-	Entities    []Entity
-	Sentiment   float64
-	Language    string
-	WordCount   int
-	ReadingTime int // in minutes
+	Entities           []Entity
+	Quotes             []Quote
+	Sentiment          float64
+	Language           string  // ISO 639-1 code
+	LanguageConfidence float64 // confidence of the language detection, 0-1
+	WordCount          int
+	SentenceCount      int
+	ParagraphCount     int
+	ReadingTime        int // in minutes
+	Readability        ReadabilityMetrics
+	Style              map[string]float64 // stylometric features, see pkg/stylometry
+	Topics             []Topic            // keyphrases, most relevant first
+}
+
+// ReadabilityMetrics holds readability formulas and style measurements of
+// the article. Pointer fields are nil when the measurement is not applicable
+// to the article's language.
+type ReadabilityMetrics struct {
+	FleschKincaidGrade *float64 // US school grade level
+	GunningFog         *float64 // US school grade level
+	SMOG               *float64 // US school grade level
+	AvgSentenceLength  float64  // words per sentence
+	PassiveVoiceRatio  *float64 // share of sentences in the passive voice
+	ExclamationDensity float64  // exclamation marks per sentence
+	AllCapsRatio       *float64 // share of words written entirely in capitals
 }
 
 // Entity represents a named entity in the article content
 type Entity struct {
-	Type  EntityType
-	Value string
+	Type       EntityType
+	Value      string
+	Normalized string // ISO 8601 form of a DATE, resolved against the publication time
+	Count      int    // number of times the entity is mentioned
+	Mentions   []Span // location of every mention in the analyzed text
+}
+
+// Span identifies a range of the analyzed text by character (rune) offsets,
+// with Start inclusive and End exclusive
+type Span struct {
+	Start int
+	End   int
+}
+
+// Quote is a direct or indirect quote and its attribution
+type Quote struct {
+	Text        string     // quoted words, or the reported statement of an indirect quote
+	Span        Span       // location of Text in the analyzed text
+	Speaker     string     // linked entity name, or the attribution phrase as written
+	SpeakerType EntityType // type of the linked entity, empty when not linked
+	Attribution string     // attribution phrase as written, e.g. "officials said"
+	Direct      bool       // true for quotes in quotation marks
+	Anonymous   bool       // true when the attribution names no one
+}
+
+// OffsetSegment maps a character range of an article's CleanText to the
+// range of Content it was produced from. Equal-length ranges map character
+// by character; otherwise the whole clean range maps to the whole raw range.
+type OffsetSegment struct {
+	CleanStart int
+	CleanEnd   int
+	RawStart   int
+	RawEnd     int
 }
 
 // EntityType represents different types of named entities
@@ -61,13 +120,15 @@ type Flag struct {
 type FlagType string
 
 const (
-	FlagTypeClickbait    FlagType = "CLICKBAIT"
-	FlagTypeMisleading   FlagType = "MISLEADING"
-	FlagTypeBiased       FlagType = "BIASED"
-	FlagTypeUnverified   FlagType = "UNVERIFIED"
-	FlagTypeFactualError FlagType = "FACTUAL_ERROR"
-	FlagTypeHateSpeech   FlagType = "HATE_SPEECH"
-	FlagTypeSpam         FlagType = "SPAM"
+	FlagTypeClickbait        FlagType = "CLICKBAIT"
+	FlagTypeMisleading       FlagType = "MISLEADING"
+	FlagTypeBiased           FlagType = "BIASED"
+	FlagTypeUnverified       FlagType = "UNVERIFIED"
+	FlagTypeFactualError     FlagType = "FACTUAL_ERROR"
+	FlagTypeHateSpeech       FlagType = "HATE_SPEECH"
+	FlagTypeSpam             FlagType = "SPAM"
+	FlagTypeMachineGenerated FlagType = "MACHINE_GENERATED"
+	FlagTypeImpersonation    FlagType = "IMPERSONATION"
 )
 
 // ArticleStatus represents the current state of an article
@@ -81,6 +142,16 @@ const (
 	ArticleStatusRejected ArticleStatus = "REJECTED"
 )
 
+// IsValid reports whether s is a known article status
+func (s ArticleStatus) IsValid() bool {
+	switch s {
+	case ArticleStatusPending, ArticleStatusAnalyzed, ArticleStatusFlagged,
+		ArticleStatusVerified, ArticleStatusRejected:
+		return true
+	}
+	return false
+}
+
 // NewArticle creates a new Article instance with default values
 func NewArticle(title, content, source, author string, tags []string) *Article {
 	now := time.Now()
@@ -132,6 +203,72 @@ func (a *Article) UpdateStatus(status ArticleStatus) {
 	a.UpdatedAt = time.Now()
 }
 
+// UpdateClaims replaces the article's extracted claims
+func (a *Article) UpdateClaims(claims []Claim) {
+	a.Claims = claims
+	a.UpdatedAt = time.Now()
+}
+
+// FindClaim returns the claim with the given ID, or nil if the article has
+// no such claim
+func (a *Article) FindClaim(id uuid.UUID) *Claim {
+	for i := range a.Claims {
+		if a.Claims[i].ID == id {
+			return &a.Claims[i]
+		}
+	}
+	return nil
+}
+
+// UpdateCleanText stores the normalized content and its offset map
+func (a *Article) UpdateCleanText(clean string, offsetMap []OffsetSegment) {
+	a.CleanText = clean
+	a.OffsetMap = offsetMap
+	a.UpdatedAt = time.Now()
+}
+
+// AnalyzedText returns the text the analyzers ran on: CleanText, or Content
+// for articles analyzed before content normalization existed
+func (a *Article) AnalyzedText() string {
+	if a.CleanText != "" || a.Content == "" {
+		return a.CleanText
+	}
+	return a.Content
+}
+
+// RawSpan maps a span of CleanText, such as an entity mention, to the
+// corresponding span of Content. Spans are returned unchanged when the
+// article has no offset map.
+func (a *Article) RawSpan(span Span) Span {
+	if len(a.OffsetMap) == 0 {
+		return span
+	}
+	return Span{Start: a.rawOffset(span.Start, false), End: a.rawOffset(span.End, true)}
+}
+
+// rawOffset maps a clean offset to a raw one. An offset on the boundary of
+// two segments maps into the following segment for a start and into the
+// preceding segment for an end.
+func (a *Article) rawOffset(offset int, isEnd bool) int {
+	i := sort.Search(len(a.OffsetMap), func(i int) bool {
+		if isEnd {
+			return a.OffsetMap[i].CleanEnd >= offset
+		}
+		return a.OffsetMap[i].CleanEnd > offset
+	})
+	if i == len(a.OffsetMap) {
+		return a.OffsetMap[len(a.OffsetMap)-1].RawEnd
+	}
+	seg := a.OffsetMap[i]
+	if seg.CleanEnd-seg.CleanStart == seg.RawEnd-seg.RawStart {
+		return seg.RawStart + offset - seg.CleanStart
+	}
+	if isEnd {
+		return seg.RawEnd
+	}
+	return seg.RawStart
+}
+
 // UpdateMetadata updates the article's metadata
 func (a *Article) UpdateMetadata(metadata ArticleMetadata) {
 	a.MetaData = metadata
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// AuthorProfile summarises an author's writing style over their previously
+// analyzed articles
+type AuthorProfile struct {
+	Author    string
+	Articles  int // number of articles the profile is built from
+	Features  []StyleFeature
+	UpdatedAt time.Time // last update of the most recent article in the profile
+}
+
+// StyleFeature is the distribution of one stylometric feature across an
+// author's articles
+type StyleFeature struct {
+	Name        string
+	Description string
+	Mean        float64
+	StdDev      float64
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidClaimReview is returned for a malformed claim id or an
+	// unknown verdict
+	ErrInvalidClaimReview = errors.New("invalid claim review")
+	// ErrClaimNotFound is returned when an article has no claim with the
+	// given id
+	ErrClaimNotFound = errors.New("claim not found")
+)
+
+// Claim is a check-worthy factual statement extracted from an article
+type Claim struct {
+	ID             uuid.UUID
+	Text           string
+	Span           Span     // location of the claim in the article content
+	CheckWorthy    float64  // check-worthiness score, 0-1
+	Entities       []Entity // entities mentioned in the claim
+	Verdict        ClaimVerdict
+	VerdictDetails string
+	CheckedBy      string
+	CheckedAt      time.Time
+}
+
+// ClaimVerdict represents the outcome of checking a claim
+type ClaimVerdict string
+
+const (
+	ClaimVerdictUnchecked    ClaimVerdict = "UNCHECKED"
+	ClaimVerdictTrue         ClaimVerdict = "TRUE"
+	ClaimVerdictFalse        ClaimVerdict = "FALSE"
+	ClaimVerdictMixed        ClaimVerdict = "MIXED"
+	ClaimVerdictUnverifiable ClaimVerdict = "UNVERIFIABLE"
+)
+
+// IsValid reports whether v is a known verdict
+func (v ClaimVerdict) IsValid() bool {
+	switch v {
+	case ClaimVerdictUnchecked, ClaimVerdictTrue, ClaimVerdictFalse, ClaimVerdictMixed, ClaimVerdictUnverifiable:
+		return true
+	}
+	return false
+}
+
+// NewClaim creates an unchecked claim
+func NewClaim(text string, span Span, checkWorthy float64, entities []Entity) Claim {
+	return Claim{
+		ID:          uuid.New(),
+		Text:        text,
+		Span:        span,
+		CheckWorthy: checkWorthy,
+		Entities:    entities,
+		Verdict:     ClaimVerdictUnchecked,
+	}
+}
+
+// SetVerdict records the outcome of checking the claim
+func (c *Claim) SetVerdict(verdict ClaimVerdict, details, checkedBy string) {
+	c.Verdict = verdict
+	c.VerdictDetails = details
+	c.CheckedBy = checkedBy
+	c.CheckedAt = time.Now()
+}
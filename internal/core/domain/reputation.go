@@ -0,0 +1,243 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// ReputationPriorStrength is the weight of a source's assessed
+	// reputation against its track record, in reviewed articles
+	ReputationPriorStrength = 10.0
+	// ReputationHalfLife is the age at which a review outcome counts half
+	ReputationHalfLife = 180 * 24 * time.Hour
+)
+
+// TrackRecord is the outcome of reviews of a source's articles, each
+// weighted by its severity and decayed by its age
+type TrackRecord struct {
+	Verified  float64   // weight of articles reviewers verified
+	Rejected  float64   // weight of articles reviewers rejected
+	DecayedAt time.Time // time the weights are decayed to
+}
+
+// decay returns the factor by which an outcome's weight shrinks from one
+// time to a later one
+func decay(from, to time.Time) float64 {
+	if from.IsZero() || !to.After(from) {
+		return 1
+	}
+	return math.Pow(0.5, float64(to.Sub(from))/float64(ReputationHalfLife))
+}
+
+// At returns the track record with its weights decayed to the given time
+func (t TrackRecord) At(at time.Time) TrackRecord {
+	f := decay(t.DecayedAt, at)
+	return TrackRecord{
+		Verified:  t.Verified * f,
+		Rejected:  t.Rejected * f,
+		DecayedAt: at,
+	}
+}
+
+// Weight returns the total weight of the reviewed articles
+func (t TrackRecord) Weight() float64 {
+	return t.Verified + t.Rejected
+}
+
+// LearnedReputation returns the mean and standard deviation of the beta
+// posterior of a source's reputation: the assessment is the prior mean,
+// worth ReputationPriorStrength articles, and the track record adds verified
+// articles as successes and rejected ones as failures.
+func LearnedReputation(assessment float64, record TrackRecord) (float64, float64) {
+	alpha := ReputationPriorStrength*assessment + record.Verified
+	beta := ReputationPriorStrength*(1-assessment) + record.Rejected
+	n := alpha + beta
+	return alpha / n, math.Sqrt(alpha * beta / (n * n * (n + 1)))
+}
+
+// ReputationOverride pins a source's reputation regardless of its track
+// record
+type ReputationOverride struct {
+	Reputation float64
+	SetBy      string
+	Reason     string
+	SetAt      time.Time
+}
+
+// ReputationAt returns the source's reputation at the given time: the
+// override if there is one, otherwise the learned reputation with the track
+// record decayed to that time
+func (s *Source) ReputationAt(at time.Time) float64 {
+	if s.Override != nil {
+		return s.Override.Reputation
+	}
+	mean, _ := LearnedReputation(s.Assessment, s.TrackRecord.At(at))
+	return mean
+}
+
+// Refresh decays the track record to the given time and recomputes the
+// effective reputation
+func (s *Source) Refresh(at time.Time) {
+	s.TrackRecord = s.TrackRecord.At(at)
+	s.Reputation = s.ReputationAt(at)
+	s.UpdatedAt = at
+}
+
+// ReviewOutcome is the outcome of the review of an article as counted in
+// the track record of its source
+type ReviewOutcome struct {
+	ArticleID  string
+	Domain     string // the source whose track record counts the outcome
+	Verified   bool
+	Weight     float64 // weight the outcome was recorded with
+	Flags      []FlagType
+	RecordedAt time.Time
+}
+
+// PendingOutcome is a change a review outcome made to a source's track
+// record that is not yet stored with the review outcomes
+type PendingOutcome struct {
+	Outcome   ReviewOutcome
+	Retracted bool // whether the outcome was removed rather than added
+}
+
+// RecordOutcome adds the outcome of the review of one of the source's
+// articles to its track record, as of the time it is recorded. The change
+// is pending until the outcome is stored; see SettleOutcome.
+func (s *Source) RecordOutcome(outcome ReviewOutcome) {
+	s.TrackRecord = s.TrackRecord.At(outcome.RecordedAt)
+	if outcome.Verified {
+		s.TrackRecord.Verified += outcome.Weight
+	} else {
+		s.TrackRecord.Rejected += outcome.Weight
+	}
+	s.setPending(PendingOutcome{Outcome: outcome})
+	s.Refresh(outcome.RecordedAt)
+}
+
+// RetractOutcome removes an outcome recorded for one of the source's
+// articles from its track record, with the weight it has left after
+// decaying since. The change is pending until the outcome is removed from
+// the store; see SettleOutcome.
+func (s *Source) RetractOutcome(outcome ReviewOutcome, at time.Time) {
+	s.TrackRecord = s.TrackRecord.At(at)
+	remaining := outcome.Weight * decay(outcome.RecordedAt, at)
+	if outcome.Verified {
+		s.TrackRecord.Verified = max(0, s.TrackRecord.Verified-remaining)
+	} else {
+		s.TrackRecord.Rejected = max(0, s.TrackRecord.Rejected-remaining)
+	}
+	s.setPending(PendingOutcome{Outcome: outcome, Retracted: true})
+	s.Refresh(at)
+}
+
+func (s *Source) setPending(pending PendingOutcome) {
+	if s.Pending == nil {
+		s.Pending = make(map[string]PendingOutcome)
+	}
+	s.Pending[pending.Outcome.ArticleID] = pending
+}
+
+// SettleOutcome forgets a pending change once it is stored. It reports
+// false if the change is no longer pending.
+func (s *Source) SettleOutcome(pending PendingOutcome) bool {
+	current, ok := s.Pending[pending.Outcome.ArticleID]
+	if !ok || current.Retracted != pending.Retracted || current.Outcome.Verified != pending.Outcome.Verified {
+		return false
+	}
+	delete(s.Pending, pending.Outcome.ArticleID)
+	return true
+}
+
+// SetOverride pins the source's reputation, or removes the override if it
+// is nil
+func (s *Source) SetOverride(override *ReputationOverride, at time.Time) {
+	s.Override = override
+	if override != nil && override.SetAt.IsZero() {
+		override.SetAt = at
+	}
+	s.Refresh(at)
+}
+
+// ReputationCause describes what changed a source's reputation
+type ReputationCause string
+
+const (
+	ReputationCauseAssessment      ReputationCause = "ASSESSMENT"       // the assessed reputation was set
+	ReputationCauseVerified        ReputationCause = "ARTICLE_VERIFIED" // reviewers verified an article
+	ReputationCauseRejected        ReputationCause = "ARTICLE_REJECTED" // reviewers rejected an article
+	ReputationCauseRetracted       ReputationCause = "REVIEW_RETRACTED" // an article's review outcome was withdrawn
+	ReputationCauseOverride        ReputationCause = "OVERRIDE"         // a reviewer pinned the reputation
+	ReputationCauseOverrideRemoved ReputationCause = "OVERRIDE_REMOVED" // the pinned reputation was released
+)
+
+// ReputationChange is an entry of a source's reputation history
+type ReputationChange struct {
+	Domain      string
+	At          time.Time
+	Cause       ReputationCause
+	Previous    float64 // effective reputation before the change
+	Reputation  float64 // effective reputation after the change
+	ArticleID   string  // the reviewed article, for article outcomes
+	Verified    bool    // for article outcomes, whether the article was verified
+	Weight      float64 // for article outcomes, the weight the outcome was recorded with
+	Flags       []FlagType
+	TrackRecord TrackRecord // the track record after the change
+	Actor       string      // who made a manual change
+	Details     string      // why the reputation changed, for people
+}
+
+// ReputationBasis names what a source's effective reputation rests on
+type ReputationBasis string
+
+const (
+	ReputationBasisOverride ReputationBasis = "OVERRIDE" // a reviewer's override
+	ReputationBasisLearned  ReputationBasis = "LEARNED"  // the assessment updated by the track record
+	ReputationBasisAssessed ReputationBasis = "ASSESSED" // the assessment alone, without a track record
+)
+
+// ReputationExplanation shows how a source's reputation came about
+type ReputationExplanation struct {
+	Domain        string
+	Reputation    float64 // effective reputation now
+	Basis         ReputationBasis
+	Assessment    float64
+	Provenance    ReputationProvenance
+	TrackRecord   TrackRecord // decayed to now
+	Learned       float64     // reputation learned from the assessment and the track record
+	Uncertainty   float64     // standard deviation of the learned reputation
+	Override      *ReputationOverride
+	PriorStrength float64       // weight of the assessment, in reviewed articles
+	HalfLife      time.Duration // age at which a review outcome counts half
+	History       []*ReputationChange
+}
+
+// ExplainReputation explains the source's reputation at the given time,
+// without its history
+func (s *Source) ExplainReputation(at time.Time) *ReputationExplanation {
+	record := s.TrackRecord.At(at)
+	learned, uncertainty := LearnedReputation(s.Assessment, record)
+
+	basis := ReputationBasisLearned
+	switch {
+	case s.Override != nil:
+		basis = ReputationBasisOverride
+	case record.Weight() == 0:
+		basis = ReputationBasisAssessed
+	}
+
+	return &ReputationExplanation{
+		Domain:        s.Domain,
+		Reputation:    s.ReputationAt(at),
+		Basis:         basis,
+		Assessment:    s.Assessment,
+		Provenance:    s.Provenance,
+		TrackRecord:   record,
+		Learned:       learned,
+		Uncertainty:   uncertainty,
+		Override:      s.Override,
+		PriorStrength: ReputationPriorStrength,
+		HalfLife:      ReputationHalfLife,
+	}
+}
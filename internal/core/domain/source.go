@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidSource is returned for sources that fail validation
+	ErrInvalidSource = errors.New("invalid source")
+	// ErrSourceExists is returned when registering a domain twice
+	ErrSourceExists = errors.New("source already exists")
+	// ErrSourceNotFound is returned when a source is not in the registry
+	ErrSourceNotFound = errors.New("source not found")
+	// ErrSourceConflict is returned when a source was changed since it was
+	// read
+	ErrSourceConflict = errors.New("source was modified concurrently")
+)
+
+// DefaultSourceReputation is the reputation of a source registered without
+// an assessment
+const DefaultSourceReputation = 0.5
+
+// Source is a news outlet in the source registry, identified by its domain
+// name.
+//
+// Its reputation starts from an assessment and is learned from the outcome
+// of reviews of its articles; see ReputationAt.
+type Source struct {
+	Domain      string  // lower-case host name without "www.", e.g. "bbc.co.uk"
+	Name        string  // display name, e.g. "BBC News"
+	Country     string  // ISO 3166-1 alpha-2 code of the country the source is based in
+	Ownership   string  // owner or ownership model, e.g. "public broadcaster"
+	Reputation  float64 // effective reputation, stored as of UpdatedAt
+	Assessment  float64 // reputation assessed before any track record, 0-1
+	Provenance  ReputationProvenance
+	TrackRecord TrackRecord
+	Pending     map[string]PendingOutcome // track record changes not yet stored with the review outcomes, by article ID
+	Override    *ReputationOverride
+	Version     int // incremented on every update, for optimistic concurrency
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// ReputationMethod describes how a source's reputation was established
+type ReputationMethod string
+
+const (
+	ReputationMethodDefault  ReputationMethod = "DEFAULT"  // no assessment, DefaultSourceReputation
+	ReputationMethodManual   ReputationMethod = "MANUAL"   // set by a reviewer
+	ReputationMethodImported ReputationMethod = "IMPORTED" // taken from an external rating
+)
+
+// IsValid reports whether m is a known reputation method
+func (m ReputationMethod) IsValid() bool {
+	switch m {
+	case ReputationMethodDefault, ReputationMethodManual, ReputationMethodImported:
+		return true
+	}
+	return false
+}
+
+// ReputationProvenance records where a source's assessed reputation comes
+// from
+type ReputationProvenance struct {
+	Method     ReputationMethod
+	AssessedBy string // reviewer or rating organisation
+	Reference  string // URL or note backing the score
+	AssessedAt time.Time
+}
+
+// NewSource creates a source with the default reputation
+func NewSource(domain, name string) *Source {
+	now := time.Now()
+	return &Source{
+		Domain:     SourceDomain(domain),
+		Name:       strings.TrimSpace(name),
+		Reputation: DefaultSourceReputation,
+		Assessment: DefaultSourceReputation,
+		Provenance: ReputationProvenance{
+			Method:     ReputationMethodDefault,
+			AssessedAt: now,
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Assess records a new assessed reputation and where it comes from. The
+// track record is kept, so the assessment changes the learned reputation
+// rather than replacing it.
+func (s *Source) Assess(reputation float64, provenance ReputationProvenance) {
+	now := time.Now()
+	s.Assessment = reputation
+	s.Provenance = provenance
+	if s.Provenance.AssessedAt.IsZero() {
+		s.Provenance.AssessedAt = now
+	}
+	s.Refresh(now)
+}
+
+// Validate checks the source's fields, returning an error wrapping
+// ErrInvalidSource
+func (s *Source) Validate() error {
+	switch {
+	case !strings.Contains(s.Domain, ".") || strings.ContainsAny(s.Domain, " /:") || s.Domain != SourceDomain(s.Domain):
+		return fmt.Errorf("%w: %q is not a domain name", ErrInvalidSource, s.Domain)
+	case strings.TrimSpace(s.Name) == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidSource)
+	case s.Country != "" && (len(s.Country) != 2 || strings.ToUpper(s.Country) != s.Country):
+		return fmt.Errorf("%w: country %q is not an ISO 3166-1 alpha-2 code", ErrInvalidSource, s.Country)
+	case s.Assessment < 0 || s.Assessment > 1:
+		return fmt.Errorf("%w: reputation %v is outside 0-1", ErrInvalidSource, s.Assessment)
+	case !s.Provenance.Method.IsValid():
+		return fmt.Errorf("%w: unknown reputation method %q", ErrInvalidSource, s.Provenance.Method)
+	case s.Override != nil && (s.Override.Reputation < 0 || s.Override.Reputation > 1):
+		return fmt.Errorf("%w: override %v is outside 0-1", ErrInvalidSource, s.Override.Reputation)
+	}
+	return nil
+}
+
+// SourceDomain extracts the registry domain from an article source given as
+// a URL or a host name: "https://www.bbc.co.uk/news" becomes "bbc.co.uk".
+// Sources that are names rather than domains, such as "BBC News", are
+// returned lower-cased.
+func SourceDomain(source string) string {
+	host := strings.ToLower(strings.TrimSpace(source))
+	if strings.Contains(host, "://") {
+		if u, err := url.Parse(host); err == nil && u.Host != "" {
+			host = u.Hostname()
+		}
+	} else if i := strings.IndexAny(host, "/?#"); i >= 0 && !strings.Contains(host[:i], " ") {
+		host = host[:i]
+	}
+	if i := strings.LastIndexByte(host, ':'); i >= 0 && !strings.Contains(host, " ") {
+		host = host[:i]
+	}
+	host = strings.TrimSuffix(host, ".")
+	return strings.TrimPrefix(host, "www.")
+}
+
+// ParentDomains returns the domain followed by each of its parent domains
+// with at least two labels: "news.bbc.co.uk" gives "news.bbc.co.uk",
+// "bbc.co.uk" and "co.uk"
+func ParentDomains(domain string) []string {
+	var parents []string
+	for strings.Count(domain, ".") >= 1 {
+		parents = append(parents, domain)
+		domain = domain[strings.IndexByte(domain, '.')+1:]
+	}
+	return parents
+}
@@ -0,0 +1,7 @@
+package domain
+
+// Topic is a keyphrase that describes what an article is about
+type Topic struct {
+	Phrase string  // lower-cased phrase as it appears in the text
+	Score  float64 // relevance to the article, 0-1, where 1 is the article's top topic
+}
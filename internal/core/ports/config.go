@@ -1,11 +1,14 @@
 package ports
 
+import "time"
+
 // ConfigProvider defines the interface for accessing application configuration
 type ConfigProvider interface {
 	GetMongoDBConfig() MongoDBConfig
 	GetRedisConfig() RedisConfig
 	GetPostgresConfig() PostgresConfig
 	GetLogConfig() LogConfig
+	GetAnalysisConfig() AnalysisConfig
 }
 
 // MongoDBConfig represents MongoDB configuration requirements
@@ -32,3 +35,18 @@ type LogConfig interface {
 	GetFormat() string
 	GetOutputPath() string
 }
+
+// AnalysisConfig represents configuration for the offline content analyzers
+type AnalysisConfig interface {
+	GetSentimentLexiconPath() string
+	GetEntityGazetteerPath() string
+	GetBiasLexiconPath() string
+	GetLanguageProfileDir() string
+	GetHateSpeechTermsPath() string
+	GetClassifierModelPath() string
+	GetCharacterModelPath() string
+	GetKnowledgeBasePath() string
+	GetClaimReviewDBPath() string
+	GetPluginDir() string
+	GetPluginCallTimeout() time.Duration
+}
@@ -0,0 +1,14 @@
+package primary
+
+import (
+	"context"
+
+	"github.com/reality-filter/internal/core/domain"
+)
+
+// AuthorProfiler defines the primary port for author style profiles
+type AuthorProfiler interface {
+	// GetAuthorProfile retrieves the stylometric profile built from an
+	// author's analyzed articles, or nil if there is none
+	GetAuthorProfile(ctx context.Context, author string) (*domain.AuthorProfile, error)
+}
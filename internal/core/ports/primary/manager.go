@@ -17,6 +17,9 @@ type ArticleManager interface {
 	// UpdateArticleStatus updates the status of an article
 	UpdateArticleStatus(ctx context.Context, articleID string, status domain.ArticleStatus) error
 
+	// UpdateClaimVerdict records a reviewer's verdict on one of an article's claims
+	UpdateClaimVerdict(ctx context.Context, articleID, claimID string, verdict domain.ClaimVerdict, details, reviewer string) error
+
 	// ListFlaggedArticles retrieves a list of flagged articles
 	ListFlaggedArticles(ctx context.Context, limit, offset int) ([]*domain.Article, error)
 }
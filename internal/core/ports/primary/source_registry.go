@@ -0,0 +1,38 @@
+package primary
+
+import (
+	"context"
+
+	"github.com/reality-filter/internal/core/domain"
+)
+
+// SourceRegistry defines the primary port for managing news sources and
+// their reputations
+type SourceRegistry interface {
+	// CreateSource registers a new source
+	CreateSource(ctx context.Context, source *domain.Source) error
+
+	// GetSource retrieves a source by domain, or nil if it is not registered
+	GetSource(ctx context.Context, domainName string) (*domain.Source, error)
+
+	// ListSources retrieves registered sources ordered by domain
+	ListSources(ctx context.Context, limit, offset int) ([]*domain.Source, error)
+
+	// UpdateSource replaces a registered source
+	UpdateSource(ctx context.Context, source *domain.Source) error
+
+	// DeleteSource removes a source from the registry
+	DeleteSource(ctx context.Context, domainName string) error
+
+	// ExplainReputation shows how a source's reputation came about, with a
+	// page of its history, or nil if the source is not registered
+	ExplainReputation(ctx context.Context, domainName string, limit, offset int) (*domain.ReputationExplanation, error)
+
+	// OverrideReputation pins a source's reputation regardless of its track
+	// record
+	OverrideReputation(ctx context.Context, domainName string, override domain.ReputationOverride) (*domain.Source, error)
+
+	// ClearReputationOverride releases a source's reputation back to the
+	// learned value
+	ClearReputationOverride(ctx context.Context, domainName, by string) (*domain.Source, error)
+}
@@ -0,0 +1,15 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/reality-filter/internal/core/domain"
+)
+
+// ClaimExtractor defines the secondary port for check-worthy claim extraction
+type ClaimExtractor interface {
+	// ExtractClaims splits text into sentences and returns those worth
+	// fact-checking. Entities previously extracted from the same text are
+	// attached to the claims that mention them.
+	ExtractClaims(ctx context.Context, text string, entities []domain.Entity) ([]domain.Claim, error)
+}
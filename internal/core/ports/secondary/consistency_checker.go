@@ -0,0 +1,15 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/reality-filter/internal/core/domain"
+)
+
+// ConsistencyChecker defines the secondary port for checks that compare
+// parts of an article with each other, such as the headline with the body
+type ConsistencyChecker interface {
+	// CheckConsistency inspects an article whose metadata has already been
+	// extracted and returns detected inconsistencies
+	CheckConsistency(ctx context.Context, article *domain.Article) ([]domain.Flag, error)
+}
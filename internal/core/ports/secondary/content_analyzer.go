@@ -17,3 +17,21 @@ type ContentAnalyzer interface {
 	// DetectBias detects bias in content
 	DetectBias(ctx context.Context, text string) ([]domain.Flag, error)
 }
+
+// rawContentKey is the context key under which the raw article content is
+// stored
+type rawContentKey struct{}
+
+// ContextWithRawContent returns a context carrying the article content as
+// submitted, before normalization, for analyzers that look at what
+// normalization removes, such as link targets and promotional boilerplate
+func ContextWithRawContent(ctx context.Context, content string) context.Context {
+	return context.WithValue(ctx, rawContentKey{}, content)
+}
+
+// RawContentFromContext returns the raw article content stored in the
+// context, or an empty string when there is none
+func RawContentFromContext(ctx context.Context) string {
+	content, _ := ctx.Value(rawContentKey{}).(string)
+	return content
+}
@@ -2,15 +2,21 @@ package secondary
 
 import (
 	"context"
+	"errors"
 
 	"github.com/reality-filter/internal/core/domain"
 )
 
+// ErrUnknownSource is returned by GetSourceReputation when the fact checker
+// has no reputation for the source
+var ErrUnknownSource = errors.New("unknown source")
+
 // FactChecker defines the secondary port for external fact-checking services
 type FactChecker interface {
 	// CheckFacts verifies facts in an article
 	CheckFacts(ctx context.Context, article *domain.Article) ([]domain.Flag, error)
 
-	// GetSourceReputation gets the reputation score of a news source
+	// GetSourceReputation gets the reputation score of a news source, or
+	// ErrUnknownSource if it has none
 	GetSourceReputation(ctx context.Context, source string) (float64, error)
 }
@@ -0,0 +1,29 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/reality-filter/internal/core/domain"
+)
+
+// KeyphraseExtractor defines the secondary port for topic extraction
+type KeyphraseExtractor interface {
+	// ExtractKeyphrases returns the phrases that best describe the text, most
+	// relevant first. The document ID identifies the text in the corpus
+	// statistics the phrases are weighed against, so that a reanalyzed
+	// document is only counted once.
+	ExtractKeyphrases(ctx context.Context, documentID, text string) ([]domain.Topic, error)
+}
+
+// DocumentFrequencyStore defines the secondary port for the corpus-wide
+// document frequencies of terms
+type DocumentFrequencyStore interface {
+	// AddDocument counts the distinct terms of a document. Documents that
+	// were already counted are ignored.
+	AddDocument(ctx context.Context, documentID string, terms []string) error
+
+	// DocumentFrequencies returns the number of documents containing each of
+	// the terms, omitting terms no document contains, and the total number of
+	// documents counted
+	DocumentFrequencies(ctx context.Context, terms []string) (map[string]int, int, error)
+}
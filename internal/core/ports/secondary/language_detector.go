@@ -0,0 +1,27 @@
+package secondary
+
+import "context"
+
+// LanguageDetector defines the secondary port for language identification
+type LanguageDetector interface {
+	// DetectLanguage returns the ISO 639-1 code of the text's language and a
+	// confidence between 0 and 1
+	DetectLanguage(ctx context.Context, text string) (string, float64, error)
+}
+
+// languageKey is the context key under which the detected language is stored
+type languageKey struct{}
+
+// ContextWithLanguage returns a context carrying the detected ISO 639-1
+// language code, so that downstream analyzers can pick language-specific
+// resources
+func ContextWithLanguage(ctx context.Context, language string) context.Context {
+	return context.WithValue(ctx, languageKey{}, language)
+}
+
+// LanguageFromContext returns the language code stored in the context, or an
+// empty string when the language is unknown
+func LanguageFromContext(ctx context.Context) string {
+	language, _ := ctx.Value(languageKey{}).(string)
+	return language
+}
@@ -0,0 +1,16 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/reality-filter/internal/core/domain"
+)
+
+// QuoteExtractor defines the secondary port for quote and attribution
+// extraction
+type QuoteExtractor interface {
+	// ExtractQuotes returns the direct and indirect quotes in text. Speakers
+	// are linked to the given entities, previously extracted from the same
+	// text, where possible.
+	ExtractQuotes(ctx context.Context, text string, entities []domain.Entity) ([]domain.Quote, error)
+}
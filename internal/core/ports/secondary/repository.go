@@ -2,6 +2,7 @@ package secondary
 
 import (
 	"context"
+	"time"
 
 	"github.com/reality-filter/internal/core/domain"
 )
@@ -17,6 +18,25 @@ type ArticleRepository interface {
 	// FindFlagged retrieves flagged articles with pagination
 	FindFlagged(ctx context.Context, limit, offset int) ([]*domain.Article, error)
 
+	// FindByStatus retrieves articles with the given status with pagination
+	FindByStatus(ctx context.Context, status domain.ArticleStatus, limit, offset int) ([]*domain.Article, error)
+
+	// FindByAuthor retrieves articles by the given author, most recently
+	// updated first, with pagination
+	FindByAuthor(ctx context.Context, author string, limit, offset int) ([]*domain.Article, error)
+
+	// CountTopics counts, for every topic phrase, the articles created in
+	// [from, to) that have the topic, and returns the total number of
+	// articles created in that period
+	CountTopics(ctx context.Context, from, to time.Time) (map[string]int, int, error)
+
+	// CountBySource counts the articles created in [from, to) per source
+	CountBySource(ctx context.Context, from, to time.Time) (map[string]int, error)
+
+	// CountFlags counts the flags of each type raised on articles created in
+	// [from, to)
+	CountFlags(ctx context.Context, from, to time.Time) (map[domain.FlagType]int, error)
+
 	// Update updates an existing article
 	Update(ctx context.Context, article *domain.Article) error
 }
@@ -0,0 +1,17 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/reality-filter/internal/core/domain"
+)
+
+// ReputationHistory defines the secondary port for the history of source
+// reputation changes
+type ReputationHistory interface {
+	// Append records a reputation change
+	Append(ctx context.Context, change *domain.ReputationChange) error
+
+	// List retrieves the changes to a source's reputation, most recent first
+	List(ctx context.Context, domainName string, limit, offset int) ([]*domain.ReputationChange, error)
+}
@@ -0,0 +1,23 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/reality-filter/internal/core/domain"
+)
+
+// ReviewOutcomeStore defines the secondary port for the review outcomes
+// counted in the track records of sources, one per article
+type ReviewOutcomeStore interface {
+	// Save stores the outcome counted for an article, replacing the one
+	// stored before
+	Save(ctx context.Context, outcome *domain.ReviewOutcome) error
+
+	// Find retrieves the outcome counted for an article, or nil if there is
+	// none
+	Find(ctx context.Context, articleID string) (*domain.ReviewOutcome, error)
+
+	// Delete removes the outcome counted for an article if the given source
+	// counts it
+	Delete(ctx context.Context, articleID, domainName string) error
+}
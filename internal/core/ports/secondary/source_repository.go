@@ -0,0 +1,32 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/reality-filter/internal/core/domain"
+)
+
+// SourceRepository defines the secondary port for the source registry
+type SourceRepository interface {
+	// Create stores a new source, or returns domain.ErrSourceExists if its
+	// domain is already registered
+	Create(ctx context.Context, source *domain.Source) error
+
+	// Update replaces a registered source and increments its version. It
+	// returns domain.ErrSourceNotFound if the source is not registered, and
+	// domain.ErrSourceConflict if it was updated since it was read.
+	Update(ctx context.Context, source *domain.Source) error
+
+	// FindByDomain retrieves a source by its domain, or nil if there is none
+	FindByDomain(ctx context.Context, domainName string) (*domain.Source, error)
+
+	// FindByName retrieves a source by its display name, ignoring case, or
+	// nil if there is none
+	FindByName(ctx context.Context, name string) (*domain.Source, error)
+
+	// List retrieves sources ordered by domain with pagination
+	List(ctx context.Context, limit, offset int) ([]*domain.Source, error)
+
+	// Delete removes a source, or returns domain.ErrSourceNotFound
+	Delete(ctx context.Context, domainName string) error
+}
@@ -0,0 +1,13 @@
+package secondary
+
+import (
+	"context"
+
+	"github.com/reality-filter/internal/core/domain"
+)
+
+// TitleAnalyzer defines the secondary port for headline analysis services
+type TitleAnalyzer interface {
+	// AnalyzeTitle inspects an article headline and returns detected issues
+	AnalyzeTitle(ctx context.Context, title string) ([]domain.Flag, error)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/reality-filter/internal/core/ports"
 )
@@ -14,6 +15,7 @@ type Config struct {
 	Redis    redisConfig
 	Postgres postgresConfig
 	Log      logConfig
+	Analysis analysisConfig
 }
 
 type mongoDBConfig struct {
@@ -43,6 +45,20 @@ type logConfig struct {
 	OutputPath string
 }
 
+type analysisConfig struct {
+	SentimentLexiconPath string
+	EntityGazetteerPath  string
+	BiasLexiconPath      string
+	LanguageProfileDir   string
+	HateSpeechTermsPath  string
+	ClassifierModelPath  string
+	CharacterModelPath   string
+	KnowledgeBasePath    string
+	ClaimReviewDBPath    string
+	PluginDir            string
+	PluginCallTimeoutMs  int
+}
+
 // LoadConfig loads configuration from environment variables
 func LoadConfig() (*Config, error) {
 	return &Config{
@@ -69,6 +85,19 @@ func LoadConfig() (*Config, error) {
 			Format:     getEnv("LOG_FORMAT", "console"),
 			OutputPath: getEnv("LOG_OUTPUT_PATH", "stdout"),
 		},
+		Analysis: analysisConfig{
+			SentimentLexiconPath: getEnv("SENTIMENT_LEXICON_PATH", ""),
+			EntityGazetteerPath:  getEnv("ENTITY_GAZETTEER_PATH", ""),
+			BiasLexiconPath:      getEnv("BIAS_LEXICON_PATH", ""),
+			LanguageProfileDir:   getEnv("LANGUAGE_PROFILE_DIR", ""),
+			HateSpeechTermsPath:  getEnv("HATE_SPEECH_TERMS_PATH", ""),
+			ClassifierModelPath:  getEnv("CLASSIFIER_MODEL_PATH", ""),
+			CharacterModelPath:   getEnv("CHARACTER_MODEL_PATH", ""),
+			KnowledgeBasePath:    getEnv("KNOWLEDGE_BASE_PATH", ""),
+			ClaimReviewDBPath:    getEnv("CLAIM_REVIEW_DB_PATH", ""),
+			PluginDir:            getEnv("PLUGIN_DIR", ""),
+			PluginCallTimeoutMs:  getEnvAsInt("PLUGIN_CALL_TIMEOUT_MS", 5000),
+		},
 	}, nil
 }
 
@@ -90,6 +119,10 @@ func (c *Config) GetLogConfig() ports.LogConfig {
 	return &c.Log
 }
 
+func (c *Config) GetAnalysisConfig() ports.AnalysisConfig {
+	return &c.Analysis
+}
+
 // MongoDB implementation
 func (c *mongoDBConfig) GetURI() string {
 	if c.URI != "" {
@@ -134,6 +167,51 @@ func (c *logConfig) GetOutputPath() string {
 	return c.OutputPath
 }
 
+// Analysis implementation
+func (c *analysisConfig) GetSentimentLexiconPath() string {
+	return c.SentimentLexiconPath
+}
+
+func (c *analysisConfig) GetEntityGazetteerPath() string {
+	return c.EntityGazetteerPath
+}
+
+func (c *analysisConfig) GetBiasLexiconPath() string {
+	return c.BiasLexiconPath
+}
+
+func (c *analysisConfig) GetLanguageProfileDir() string {
+	return c.LanguageProfileDir
+}
+
+func (c *analysisConfig) GetHateSpeechTermsPath() string {
+	return c.HateSpeechTermsPath
+}
+
+func (c *analysisConfig) GetClassifierModelPath() string {
+	return c.ClassifierModelPath
+}
+
+func (c *analysisConfig) GetCharacterModelPath() string {
+	return c.CharacterModelPath
+}
+
+func (c *analysisConfig) GetKnowledgeBasePath() string {
+	return c.KnowledgeBasePath
+}
+
+func (c *analysisConfig) GetClaimReviewDBPath() string {
+	return c.ClaimReviewDBPath
+}
+
+func (c *analysisConfig) GetPluginDir() string {
+	return c.PluginDir
+}
+
+func (c *analysisConfig) GetPluginCallTimeout() time.Duration {
+	return time.Duration(c.PluginCallTimeoutMs) * time.Millisecond
+}
+
 // Helper functions
 func getEnv(key, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
@@ -0,0 +1,258 @@
+// Package dates resolves English date expressions, including relative ones
+// such as "last Tuesday", against a reference time.
+package dates
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Precision is the granularity of a resolved date
+type Precision int
+
+const (
+	Day Precision = iota
+	Week
+	Month
+	Year
+	Decade
+)
+
+// Date is a resolved date expression covering [Start, End)
+type Date struct {
+	Start     time.Time
+	End       time.Time
+	Precision Precision
+	// Relative is true for expressions such as "yesterday" or "next week"
+	// whose meaning depends on the reference time
+	Relative bool
+	// YearInferred is true when the expression had no year and the year
+	// was chosen to put the date nearest the reference time
+	YearInferred bool
+}
+
+// ISO returns the date in ISO 8601 form at its precision: 2024-03-05,
+// 2024-W10, 2024-03, 2024, or the interval 1990/1999 for a decade
+func (d Date) ISO() string {
+	switch d.Precision {
+	case Week:
+		year, week := d.Start.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week)
+	case Month:
+		return d.Start.Format("2006-01")
+	case Year:
+		return d.Start.Format("2006")
+	case Decade:
+		return fmt.Sprintf("%d/%d", d.Start.Year(), d.Start.Year()+9)
+	}
+	return d.Start.Format("2006-01-02")
+}
+
+const monthPattern = `(jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|june?|july?|aug(?:ust)?|sept?(?:ember)?|oct(?:ober)?|nov(?:ember)?|dec(?:ember)?)`
+const weekdayPattern = `(monday|tuesday|wednesday|thursday|friday|saturday|sunday)`
+
+var (
+	monthDayYear = regexp.MustCompile(`^` + monthPattern + `\.?\s+(\d{1,2})(?:st|nd|rd|th)?(?:,?\s+(\d{4}))?$`)
+	dayMonthYear = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?\s+(?:of\s+)?` + monthPattern + `\.?(?:,?\s+(\d{4}))?$`)
+	monthYear    = regexp.MustCompile(`^` + monthPattern + `\.?,?\s+(\d{4})$`)
+	isoDate      = regexp.MustCompile(`^(\d{4})-(\d{2})-(\d{2})$`)
+	slashDate    = regexp.MustCompile(`^(\d{1,2})/(\d{1,2})/(\d{4}|\d{2})$`)
+	weekdayExpr  = regexp.MustCompile(`^(?:(last|next|this)\s+)?` + weekdayPattern + `$`)
+	periodExpr   = regexp.MustCompile(`^(last|next|this)\s+(week|month|year)$`)
+	decadeExpr   = regexp.MustCompile(`^((?:19|20)\d)0s$`)
+	yearExpr     = regexp.MustCompile(`^(\d{4})$`)
+
+	weekdays = map[string]time.Weekday{
+		"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday,
+		"wednesday": time.Wednesday, "thursday": time.Thursday,
+		"friday": time.Friday, "saturday": time.Saturday,
+	}
+)
+
+// ParseWeekday returns the weekday with the given English name
+func ParseWeekday(name string) (time.Weekday, bool) {
+	w, ok := weekdays[strings.ToLower(name)]
+	return w, ok
+}
+
+// Normalize resolves a date expression against the reference time, usually
+// an article's publication time. A bare weekday refers to its most recent
+// occurrence on or before the reference day, "last" to the one before that
+// day and "next" to the one after it. Day and month expressions without a
+// year take the year that puts them nearest the reference time.
+func Normalize(expr string, ref time.Time) (Date, bool) {
+	e := strings.ToLower(strings.Join(strings.Fields(expr), " "))
+	today := time.Date(ref.Year(), ref.Month(), ref.Day(), 0, 0, 0, 0, ref.Location())
+
+	switch e {
+	case "today":
+		return day(today, true), true
+	case "yesterday":
+		return day(today.AddDate(0, 0, -1), true), true
+	case "tomorrow":
+		return day(today.AddDate(0, 0, 1), true), true
+	}
+
+	if m := monthDayYear.FindStringSubmatch(e); m != nil {
+		return monthDay(parseMonth(m[1]), m[2], m[3], today)
+	}
+	if m := dayMonthYear.FindStringSubmatch(e); m != nil {
+		return monthDay(parseMonth(m[2]), m[1], m[3], today)
+	}
+	if m := monthYear.FindStringSubmatch(e); m != nil {
+		year, _ := strconv.Atoi(m[2])
+		start := time.Date(year, parseMonth(m[1]), 1, 0, 0, 0, 0, ref.Location())
+		return Date{Start: start, End: start.AddDate(0, 1, 0), Precision: Month}, true
+	}
+	if m := isoDate.FindStringSubmatch(e); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		return validDay(year, time.Month(month), m[3], ref.Location(), false)
+	}
+	if m := slashDate.FindStringSubmatch(e); m != nil {
+		return slash(m[1], m[2], m[3], ref.Location())
+	}
+	if m := weekdayExpr.FindStringSubmatch(e); m != nil {
+		return day(resolveWeekday(m[1], weekdays[m[2]], today), true), true
+	}
+	if m := periodExpr.FindStringSubmatch(e); m != nil {
+		return period(m[1], m[2], today), true
+	}
+	if m := decadeExpr.FindStringSubmatch(e); m != nil {
+		year, _ := strconv.Atoi(m[1] + "0")
+		start := time.Date(year, 1, 1, 0, 0, 0, 0, ref.Location())
+		return Date{Start: start, End: start.AddDate(10, 0, 0), Precision: Decade}, true
+	}
+	if m := yearExpr.FindStringSubmatch(e); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		start := time.Date(year, 1, 1, 0, 0, 0, 0, ref.Location())
+		return Date{Start: start, End: start.AddDate(1, 0, 0), Precision: Year}, true
+	}
+	return Date{}, false
+}
+
+func day(t time.Time, relative bool) Date {
+	return Date{Start: t, End: t.AddDate(0, 0, 1), Precision: Day, Relative: relative}
+}
+
+// monthDay resolves a day of a month, inferring a missing year
+func monthDay(month time.Month, dayText, yearText string, today time.Time) (Date, bool) {
+	if yearText != "" {
+		year, _ := strconv.Atoi(yearText)
+		return validDay(year, month, dayText, today.Location(), false)
+	}
+
+	// Choose among the previous, current and next year the one that puts
+	// the date closest to the reference day
+	best, found := Date{}, false
+	for _, year := range []int{today.Year() - 1, today.Year(), today.Year() + 1} {
+		d, ok := validDay(year, month, dayText, today.Location(), true)
+		if !ok {
+			continue
+		}
+		if !found || absDuration(d.Start.Sub(today)) < absDuration(best.Start.Sub(today)) {
+			best, found = d, true
+		}
+	}
+	return best, found
+}
+
+// validDay builds a day, rejecting dates such as February 30
+func validDay(year int, month time.Month, dayText string, loc *time.Location, inferred bool) (Date, bool) {
+	n, err := strconv.Atoi(dayText)
+	if err != nil || month < time.January || month > time.December {
+		return Date{}, false
+	}
+	t := time.Date(year, month, n, 0, 0, 0, 0, loc)
+	if t.Day() != n || t.Month() != month {
+		return Date{}, false
+	}
+	d := day(t, false)
+	d.YearInferred = inferred
+	return d, true
+}
+
+// slash resolves a numeric date, read month first unless the first number
+// cannot be a month
+func slash(first, second, yearText string, loc *time.Location) (Date, bool) {
+	a, _ := strconv.Atoi(first)
+	b, _ := strconv.Atoi(second)
+	year, _ := strconv.Atoi(yearText)
+	if len(yearText) == 2 {
+		year += 2000
+	}
+	month, dayOfMonth := a, b
+	if a > 12 {
+		month, dayOfMonth = b, a
+	}
+	return validDay(year, time.Month(month), strconv.Itoa(dayOfMonth), loc, false)
+}
+
+// resolveWeekday finds the weekday relative to today
+func resolveWeekday(modifier string, weekday time.Weekday, today time.Time) time.Time {
+	diff := int(weekday) - int(today.Weekday())
+	switch modifier {
+	case "last":
+		if diff >= 0 {
+			diff -= 7
+		}
+	case "next":
+		if diff <= 0 {
+			diff += 7
+		}
+	case "this":
+		// The occurrence in the current Monday-to-Sunday week
+		diff = isoWeekday(weekday) - isoWeekday(today.Weekday())
+	default:
+		if diff > 0 {
+			diff -= 7
+		}
+	}
+	return today.AddDate(0, 0, diff)
+}
+
+// period resolves "last week", "this month", "next year" and similar
+func period(modifier, unit string, today time.Time) Date {
+	offset := map[string]int{"last": -1, "this": 0, "next": 1}[modifier]
+	var d Date
+	switch unit {
+	case "week":
+		monday := today.AddDate(0, 0, 1-isoWeekday(today.Weekday())+7*offset)
+		d = Date{Start: monday, End: monday.AddDate(0, 0, 7), Precision: Week}
+	case "month":
+		start := time.Date(today.Year(), today.Month()+time.Month(offset), 1, 0, 0, 0, 0, today.Location())
+		d = Date{Start: start, End: start.AddDate(0, 1, 0), Precision: Month}
+	default:
+		start := time.Date(today.Year()+offset, 1, 1, 0, 0, 0, 0, today.Location())
+		d = Date{Start: start, End: start.AddDate(1, 0, 0), Precision: Year}
+	}
+	d.Relative = true
+	return d
+}
+
+// isoWeekday numbers weekdays from Monday (1) to Sunday (7)
+func isoWeekday(w time.Weekday) int {
+	if w == time.Sunday {
+		return 7
+	}
+	return int(w)
+}
+
+func parseMonth(name string) time.Month {
+	for m := time.January; m <= time.December; m++ {
+		if strings.HasPrefix(strings.ToLower(m.String()), name[:3]) {
+			return m
+		}
+	}
+	return 0
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
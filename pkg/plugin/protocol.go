@@ -0,0 +1,155 @@
+// Package plugin defines the protocol spoken between Reality Filter and
+// out-of-process analyzer plugins.
+//
+// A plugin is an executable that reads requests from its standard input and
+// writes responses to its standard output, one JSON object per line. Standard
+// error is free for the plugin's own logging. Requests are sent one at a
+// time: the host waits for the response carrying a request's ID before it
+// sends the next one.
+//
+// The first request is always a handshake, in which the host announces the
+// protocol version it speaks and the plugin replies with its name, version
+// and the methods it implements. A plugin that does not speak the host's
+// version must answer the handshake with an error. The last request is a
+// shutdown, after which the plugin should exit; it may also be killed at any
+// time, for example when a call exceeds its deadline.
+package plugin
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ProtocolVersion is the version of the protocol described by this package.
+// It changes whenever a message changes incompatibly.
+const ProtocolVersion = 1
+
+// Methods a plugin can be asked to run
+const (
+	MethodHandshake        = "handshake"
+	MethodShutdown         = "shutdown"
+	MethodAnalyzeSentiment = "analyze_sentiment"
+	MethodExtractEntities  = "extract_entities"
+	MethodDetectBias       = "detect_bias"
+	MethodCheckFacts       = "check_facts"
+	MethodSourceReputation = "source_reputation"
+)
+
+// Error codes returned in Error.Code
+const (
+	ErrorCodeUnsupportedVersion = "unsupported_version"
+	ErrorCodeUnknownMethod      = "unknown_method"
+	ErrorCodeInvalidParams      = "invalid_params"
+	ErrorCodeInternal           = "internal"
+)
+
+// Request is a message sent from the host to a plugin
+type Request struct {
+	ID     uint64          `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is a plugin's reply to the request with the same ID. Exactly one
+// of Result and Error is set.
+type Response struct {
+	ID     uint64          `json:"id"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+// Error describes why a plugin could not serve a request
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+// HandshakeParams are sent with the handshake request
+type HandshakeParams struct {
+	ProtocolVersion int    `json:"protocolVersion"`
+	Host            string `json:"host"`
+}
+
+// HandshakeResult describes the plugin and the methods it implements
+type HandshakeResult struct {
+	ProtocolVersion int      `json:"protocolVersion"`
+	Name            string   `json:"name"`
+	Version         string   `json:"version"`
+	Capabilities    []string `json:"capabilities"`
+}
+
+// TextParams are sent with analyze_sentiment, extract_entities and
+// detect_bias requests
+type TextParams struct {
+	Text     string `json:"text"`
+	Language string `json:"language,omitempty"` // ISO 639-1 code, empty when unknown
+}
+
+// SentimentResult is returned by analyze_sentiment
+type SentimentResult struct {
+	Score float64 `json:"score"` // 0 is negative, 0.5 neutral and 1 positive
+}
+
+// EntitiesResult is returned by extract_entities
+type EntitiesResult struct {
+	Entities []Entity `json:"entities"`
+}
+
+// Entity is a named entity found in the text
+type Entity struct {
+	Type     string `json:"type"` // PERSON, PLACE, DATE, ORGANIZATION or PRODUCT
+	Value    string `json:"value"`
+	Count    int    `json:"count"`
+	Mentions []Span `json:"mentions,omitempty"`
+}
+
+// Span identifies a range of the text by character (rune) offsets, with
+// Start inclusive and End exclusive
+type Span struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// FlagsResult is returned by detect_bias and check_facts
+type FlagsResult struct {
+	Flags []Flag `json:"flags"`
+}
+
+// Flag is an issue the plugin found. Type is one of the flag types of the
+// API, such as BIASED or FACTUAL_ERROR.
+type Flag struct {
+	Type       string  `json:"type"`
+	Confidence float64 `json:"confidence"`
+	Details    string  `json:"details"`
+}
+
+// ArticleParams are sent with check_facts requests
+type ArticleParams struct {
+	Article Article `json:"article"`
+}
+
+// Article is the part of an article a plugin can check
+type Article struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	Source    string    `json:"source"`
+	Author    string    `json:"author"`
+	Tags      []string  `json:"tags,omitempty"`
+	Language  string    `json:"language,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// SourceParams are sent with source_reputation requests
+type SourceParams struct {
+	Source string `json:"source"`
+}
+
+// ReputationResult is returned by source_reputation
+type ReputationResult struct {
+	Score float64 `json:"score"` // between 0 and 1
+}
@@ -0,0 +1,158 @@
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// maxMessageSize bounds the length of a single protocol message
+const maxMessageSize = 16 << 20
+
+// Handlers are the methods a Go plugin implements. Nil handlers are left out
+// of the capabilities announced in the handshake.
+type Handlers struct {
+	AnalyzeSentiment func(ctx context.Context, params TextParams) (SentimentResult, error)
+	ExtractEntities  func(ctx context.Context, params TextParams) (EntitiesResult, error)
+	DetectBias       func(ctx context.Context, params TextParams) (FlagsResult, error)
+	CheckFacts       func(ctx context.Context, params ArticleParams) (FlagsResult, error)
+	SourceReputation func(ctx context.Context, params SourceParams) (ReputationResult, error)
+}
+
+// Capabilities returns the methods for which a handler is set
+func (h Handlers) Capabilities() []string {
+	var capabilities []string
+	if h.AnalyzeSentiment != nil {
+		capabilities = append(capabilities, MethodAnalyzeSentiment)
+	}
+	if h.ExtractEntities != nil {
+		capabilities = append(capabilities, MethodExtractEntities)
+	}
+	if h.DetectBias != nil {
+		capabilities = append(capabilities, MethodDetectBias)
+	}
+	if h.CheckFacts != nil {
+		capabilities = append(capabilities, MethodCheckFacts)
+	}
+	if h.SourceReputation != nil {
+		capabilities = append(capabilities, MethodSourceReputation)
+	}
+	return capabilities
+}
+
+// Serve runs the plugin side of the protocol, reading requests from in and
+// writing responses to out until a shutdown request arrives or in is closed.
+// Go plugins typically call it with os.Stdin and os.Stdout.
+func Serve(ctx context.Context, name, version string, handlers Handlers, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+	writer := bufio.NewWriter(out)
+	encoder := json.NewEncoder(writer)
+
+	for scanner.Scan() {
+		var request Request
+		if err := json.Unmarshal(scanner.Bytes(), &request); err != nil {
+			return fmt.Errorf("failed to decode request: %w", err)
+		}
+
+		response := Response{ID: request.ID}
+		result, err := dispatch(ctx, name, version, handlers, request)
+		if err != nil {
+			response.Error = toError(err)
+		} else if response.Result, err = json.Marshal(result); err != nil {
+			response.Error = &Error{Code: ErrorCodeInternal, Message: err.Error()}
+		}
+
+		if err := encoder.Encode(response); err != nil {
+			return fmt.Errorf("failed to write response: %w", err)
+		}
+		if err := writer.Flush(); err != nil {
+			return fmt.Errorf("failed to write response: %w", err)
+		}
+
+		if request.Method == MethodShutdown {
+			return nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read request: %w", err)
+	}
+	return nil
+}
+
+// dispatch decodes a request's parameters and runs the matching handler
+func dispatch(ctx context.Context, name, version string, handlers Handlers, request Request) (any, error) {
+	switch request.Method {
+	case MethodHandshake:
+		var params HandshakeParams
+		if err := decodeParams(request, &params); err != nil {
+			return nil, err
+		}
+		if params.ProtocolVersion != ProtocolVersion {
+			return nil, &Error{
+				Code:    ErrorCodeUnsupportedVersion,
+				Message: fmt.Sprintf("plugin speaks protocol version %d, host speaks %d", ProtocolVersion, params.ProtocolVersion),
+			}
+		}
+		return HandshakeResult{
+			ProtocolVersion: ProtocolVersion,
+			Name:            name,
+			Version:         version,
+			Capabilities:    handlers.Capabilities(),
+		}, nil
+
+	case MethodShutdown:
+		return struct{}{}, nil
+
+	case MethodAnalyzeSentiment:
+		return call(ctx, request, handlers.AnalyzeSentiment)
+	case MethodExtractEntities:
+		return call(ctx, request, handlers.ExtractEntities)
+	case MethodDetectBias:
+		return call(ctx, request, handlers.DetectBias)
+	case MethodCheckFacts:
+		return call(ctx, request, handlers.CheckFacts)
+	case MethodSourceReputation:
+		return call(ctx, request, handlers.SourceReputation)
+	}
+	return nil, &Error{Code: ErrorCodeUnknownMethod, Message: request.Method}
+}
+
+// call decodes the request's parameters and runs handler, which may be nil
+// when the plugin does not implement the method
+func call[P, R any](ctx context.Context, request Request, handler func(context.Context, P) (R, error)) (any, error) {
+	if handler == nil {
+		return nil, &Error{Code: ErrorCodeUnknownMethod, Message: request.Method}
+	}
+	var params P
+	if err := decodeParams(request, &params); err != nil {
+		return nil, err
+	}
+	result, err := handler(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func decodeParams(request Request, params any) error {
+	if len(request.Params) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(request.Params, params); err != nil {
+		return &Error{Code: ErrorCodeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+// toError converts a handler error to a protocol error
+func toError(err error) *Error {
+	var protocolErr *Error
+	if errors.As(err, &protocolErr) {
+		return protocolErr
+	}
+	return &Error{Code: ErrorCodeInternal, Message: err.Error()}
+}
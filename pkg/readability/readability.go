@@ -0,0 +1,276 @@
+// Package readability computes readability formulas and style metrics for
+// article text.
+package readability
+
+import (
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+// Metrics holds readability and style measurements. Pointer fields are nil
+// when the measurement does not apply to the text's language.
+type Metrics struct {
+	// FleschKincaidGrade, GunningFog and SMOG are US school grade levels.
+	// Their syllable-based formulas are calibrated for English only.
+	FleschKincaidGrade *float64
+	GunningFog         *float64
+	SMOG               *float64
+	// AvgSentenceLength is the mean number of words per sentence
+	AvgSentenceLength float64
+	// PassiveVoiceRatio is the share of sentences containing a passive
+	// construction. Only English is supported.
+	PassiveVoiceRatio *float64
+	// ExclamationDensity is the number of exclamation marks per sentence
+	ExclamationDensity float64
+	// AllCapsRatio is the share of words of two or more letters written
+	// entirely in capitals. It is nil for scripts without letter case.
+	AllCapsRatio *float64
+}
+
+// syllableLanguages lists the languages the syllable-based grade formulas
+// are defined for
+var syllableLanguages = map[string]bool{"en": true}
+
+// Compute returns the metrics of text written in the given ISO 639-1
+// language
+func Compute(text, language string) Metrics {
+	var m Metrics
+
+	sentences := tokenize.Sentences(text)
+	var words []string
+	for _, t := range tokenize.Words(text) {
+		words = append(words, t.Text)
+	}
+	if len(sentences) == 0 || len(words) == 0 {
+		return m
+	}
+
+	m.AvgSentenceLength = round(float64(len(words)) / float64(len(sentences)))
+	m.ExclamationDensity = round(float64(strings.Count(text, "!")+strings.Count(text, "！")) / float64(len(sentences)))
+	m.AllCapsRatio = allCapsRatio(words)
+
+	if syllableLanguages[language] {
+		m.FleschKincaidGrade, m.GunningFog, m.SMOG = gradeLevels(sentences)
+	}
+	if language == "en" {
+		ratio := passiveRatio(sentences)
+		m.PassiveVoiceRatio = &ratio
+	}
+	return m
+}
+
+// gradeLevels computes the Flesch–Kincaid grade, the Gunning Fog index and
+// the SMOG grade from English syllable counts
+func gradeLevels(sentences []tokenize.Token) (*float64, *float64, *float64) {
+	var words, syllables, complexWords, polysyllables int
+	for _, sentence := range sentences {
+		for i, t := range tokenize.Words(sentence.Text) {
+			if !hasLetter(t.Text) {
+				continue
+			}
+			words++
+			n := countSyllables(t.Text)
+			syllables += n
+			if n >= 3 {
+				polysyllables++
+			}
+			if isComplexWord(t.Text, i == 0) {
+				complexWords++
+			}
+		}
+	}
+	if words == 0 {
+		return nil, nil, nil
+	}
+
+	wordsPerSentence := float64(words) / float64(len(sentences))
+	fk := round(0.39*wordsPerSentence + 11.8*float64(syllables)/float64(words) - 15.59)
+	fog := round(0.4 * (wordsPerSentence + 100*float64(complexWords)/float64(words)))
+	smog := round(1.0430*math.Sqrt(float64(polysyllables)*30/float64(len(sentences))) + 3.1291)
+	return &fk, &fog, &smog
+}
+
+// isComplexWord applies the Gunning Fog definition: three or more syllables,
+// not counting proper nouns, hyphenated compounds, or syllables added by the
+// suffixes -es, -ed and -ing
+func isComplexWord(word string, sentenceStart bool) bool {
+	if strings.Contains(word, "-") {
+		return false
+	}
+	if r := []rune(word)[0]; unicode.IsUpper(r) && !sentenceStart {
+		return false
+	}
+	lower := strings.ToLower(word)
+	for _, suffix := range []string{"ing", "es", "ed"} {
+		if strings.HasSuffix(lower, suffix) && len(lower) > len(suffix)+2 {
+			lower = strings.TrimSuffix(lower, suffix)
+			break
+		}
+	}
+	return countSyllables(lower) >= 3
+}
+
+// countSyllables estimates the syllables of an English word by counting
+// vowel groups, discounting a silent final e and a non-syllabic -es or -ed
+func countSyllables(word string) int {
+	w := strings.ToLower(tokenize.Normalize(word))
+	if w == "" {
+		return 0
+	}
+	if len(w) <= 3 {
+		return 1
+	}
+
+	count := 0
+	prevVowel := false
+	for _, r := range w {
+		vowel := strings.ContainsRune("aeiouy", r)
+		if vowel && !prevVowel {
+			count++
+		}
+		prevVowel = vowel
+	}
+
+	switch {
+	case strings.HasSuffix(w, "le") && !isVowel(w[len(w)-3]):
+		// "table", "little": the final -le is syllabic
+	case strings.HasSuffix(w, "e") && !strings.HasSuffix(w, "ee"):
+		count--
+	case strings.HasSuffix(w, "es") || strings.HasSuffix(w, "ed"):
+		// -es and -ed only form a syllable after sibilants and t/d
+		stem := w[:len(w)-2]
+		if !strings.HasSuffix(stem, "t") && !strings.HasSuffix(stem, "d") &&
+			!strings.HasSuffix(stem, "s") && !strings.HasSuffix(stem, "x") &&
+			!strings.HasSuffix(stem, "z") && !strings.HasSuffix(stem, "ch") &&
+			!strings.HasSuffix(stem, "sh") && !strings.HasSuffix(stem, "c") &&
+			!strings.HasSuffix(stem, "g") {
+			count--
+		}
+	}
+	if count < 1 {
+		count = 1
+	}
+	return count
+}
+
+func isVowel(b byte) bool {
+	return strings.IndexByte("aeiouy", b) >= 0
+}
+
+var (
+	beForms = map[string]bool{
+		"am": true, "is": true, "are": true, "was": true, "were": true, "be": true,
+		"been": true, "being": true, "get": true, "gets": true, "got": true, "gotten": true,
+		"isn't": true, "aren't": true, "wasn't": true, "weren't": true,
+	}
+	// irregularParticiples are common past participles not ending in -ed
+	irregularParticiples = map[string]bool{
+		"born": true, "brought": true, "built": true, "bought": true, "caught": true,
+		"chosen": true, "done": true, "drawn": true, "driven": true, "eaten": true,
+		"fallen": true, "felt": true, "found": true, "forgotten": true, "given": true,
+		"held": true, "hidden": true, "hit": true, "hurt": true, "kept": true,
+		"known": true, "laid": true, "led": true, "left": true, "lost": true,
+		"made": true, "meant": true, "met": true, "paid": true, "put": true,
+		"read": true, "run": true, "said": true, "seen": true, "sent": true,
+		"set": true, "shot": true, "shown": true, "shut": true, "sold": true,
+		"spent": true, "spoken": true, "stolen": true, "struck": true, "taken": true,
+		"taught": true, "thought": true, "told": true, "thrown": true, "understood": true,
+		"won": true, "worn": true, "written": true, "beaten": true, "broken": true,
+		"cut": true, "forgiven": true, "frozen": true, "ridden": true, "shaken": true,
+	}
+	// adjectivalParticiples end in -ed but usually describe a state after
+	// "be" rather than forming a passive
+	adjectivalParticiples = map[string]bool{
+		"interested": true, "tired": true, "concerned": true, "excited": true,
+		"worried": true, "pleased": true, "surprised": true, "bored": true,
+		"supposed": true, "used": true, "married": true, "scared": true,
+	}
+)
+
+// passiveRatio returns the share of sentences with a form of "be" or "get"
+// followed, within two adverbs, by a past participle
+func passiveRatio(sentences []tokenize.Token) float64 {
+	passive := 0
+	for _, sentence := range sentences {
+		if isPassive(sentence.Text) {
+			passive++
+		}
+	}
+	return round(float64(passive) / float64(len(sentences)))
+}
+
+func isPassive(sentence string) bool {
+	tokens := tokenize.Words(sentence)
+	for i, t := range tokens {
+		if !beForms[strings.ToLower(t.Text)] {
+			continue
+		}
+		for j := i + 1; j < len(tokens) && j <= i+3; j++ {
+			w := strings.ToLower(tokens[j].Text)
+			if isParticiple(w) {
+				return true
+			}
+			if w != "not" && w != "being" && w != "been" && !strings.HasSuffix(w, "ly") {
+				break
+			}
+		}
+	}
+	return false
+}
+
+func isParticiple(w string) bool {
+	if irregularParticiples[w] {
+		return true
+	}
+	return len(w) > 4 && strings.HasSuffix(w, "ed") && !adjectivalParticiples[w]
+}
+
+// allCapsRatio returns the share of words with two or more letters that are
+// all uppercase, or nil if no word has cased letters
+func allCapsRatio(words []string) *float64 {
+	cased, caps := 0, 0
+	for _, w := range words {
+		letters, upper, hasCase := 0, 0, false
+		for _, r := range w {
+			if !unicode.IsLetter(r) {
+				continue
+			}
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+				hasCase = true
+			} else if unicode.IsLower(r) {
+				hasCase = true
+			}
+		}
+		if !hasCase || letters < 2 {
+			continue
+		}
+		cased++
+		if upper == letters {
+			caps++
+		}
+	}
+	if cased == 0 {
+		return nil
+	}
+	ratio := round(float64(caps) / float64(cased))
+	return &ratio
+}
+
+func hasLetter(word string) bool {
+	for _, r := range word {
+		if unicode.IsLetter(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// round rounds to three decimal places so stored metrics stay readable
+func round(x float64) float64 {
+	return math.Round(x*1000) / 1000
+}
@@ -0,0 +1,77 @@
+package sanitize
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// boilerplateLine matches whole lines of page furniture that are not
+	// part of the article. Plain text has a paragraph per line, so phrases
+	// that can also open a sentence, such as "Read more" or "Copyright",
+	// only match when little or nothing follows them.
+	boilerplateLine = regexp.MustCompile(`(?i)^\s*(?:` +
+		`advertisement|sponsored(?: content)?|` +
+		`share (?:this(?: article| story| post)?|on [\w ,&]{1,40})[.!:]?|` +
+		`click here(?: to [\w ]{1,30})?[.!]?|` +
+		`read more(?:\s*(?:»|›|>>|\.\.\.|…)|:.{0,100}|[.!]?)|` +
+		`related(?: articles| stories| coverage)?(?::.{0,100})?|` +
+		`(?:sign up|subscribe)\b.{0,60}\b(?:newsletter|updates|inbox)\b.{0,20}|` +
+		`follow us(?: on [\w ,&]{1,40})?[.!]?|` +
+		`(?:©|\(c\)|copyright)\s*(?:©\s*)?\d{4}\b.{0,80}|©.{0,80}|.*\ball rights reserved\.?|` +
+		`.*\b(?:we use|this (?:site|website) uses) cookies\b.*|accept (?:all )?cookies|` +
+		`(?:tweet|share|email|print|comments?)(?:\s*\|\s*(?:tweet|share|email|print|comments?))*` +
+		`)\s*$`)
+
+	// trackingParam matches analytics parameters in URLs
+	trackingParam = regexp.MustCompile(`([?&])(?:utm_[a-z]+|fbclid|gclid|dclid|msclkid|mc_cid|mc_eid|igshid|_hsenc|_hsmi|yclid)=[^&#\s]*(&(?:amp;)?)?`)
+)
+
+// maxBoilerplateLine is the length in bytes beyond which a line is taken for
+// article text whatever it says; page furniture is short
+const maxBoilerplateLine = 200
+
+func isBoilerplate(line string) bool {
+	line = strings.TrimSpace(line)
+	return line != "" && len(line) <= maxBoilerplateLine && boilerplateLine.MatchString(line)
+}
+
+// removeBoilerplate drops lines of page furniture and the tracking
+// parameters of URLs
+func removeBoilerplate(pieces []piece) []piece {
+	text, owner := join(pieces)
+
+	var ranges [][2]int
+	for start := 0; start < len(text); {
+		end := strings.IndexByte(text[start:], '\n')
+		if end < 0 {
+			end = len(text)
+		} else {
+			end += start
+		}
+		if line := text[start:end]; isBoilerplate(line) {
+			ranges = append(ranges, [2]int{start, end})
+		}
+		start = end + 1
+	}
+	pieces = dropRanges(pieces, owner, ranges)
+
+	// Consecutive parameters share separators, so remove them one at a time
+	for {
+		text, owner = join(pieces)
+		m := trackingParam.FindStringSubmatchIndex(text)
+		if m == nil {
+			return pieces
+		}
+		start, end := m[0], m[1]
+		switch {
+		case text[m[2]] == '?' && m[4] >= 0:
+			// Keep the "?" for the parameters that follow
+			start++
+		case text[m[2]] == '&' && m[4] >= 0:
+			// Keep the "&" separating the neighbouring parameters
+			end = m[4]
+		}
+		pieces = dropRanges(pieces, owner, [][2]int{{start, end}})
+	}
+}
@@ -0,0 +1,49 @@
+package sanitize
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSanitizeKeepsSentencesOpeningWithFurnitureWords(t *testing.T) {
+	sentences := []string{
+		"Copyright holders sued the company over the leak.",
+		"Read more than twenty pages of the filing and you see why.",
+		"Follow us closely, the judge told the jury.",
+		"Click here and there, the designers said, and nothing happens.",
+		"Related research found the same effect in mice.",
+		"Share prices fell sharply after the announcement.",
+		"Subscribers complained that the newsletter arrived late.",
+	}
+	for _, sentence := range sentences {
+		raw := sentence + "\nThe court ruled on Monday."
+		if got := Sanitize(raw).Text; !strings.Contains(got, sentence) {
+			t.Errorf("Sanitize(%q).Text = %q, want it to keep the first sentence", raw, got)
+		}
+	}
+}
+
+func TestSanitizeRemovesFurnitureLines(t *testing.T) {
+	lines := []string{
+		"Read more",
+		"Read more: Council approves the budget",
+		"Follow us on Twitter and Facebook",
+		"Click here to subscribe",
+		"© 2024 Daily Herald. All rights reserved.",
+		"Copyright 2024 Reuters",
+		"Sign up for our newsletter",
+		"Share this article",
+		"Related: Five things to know",
+		"Advertisement",
+	}
+	for _, line := range lines {
+		raw := "The court ruled on Monday.\n" + line + "\nThe appeal follows."
+		got := Sanitize(raw).Text
+		if strings.Contains(got, line) {
+			t.Errorf("Sanitize(%q).Text = %q, want %q removed", raw, got, line)
+		}
+		if !strings.Contains(got, "The court ruled on Monday.") || !strings.Contains(got, "The appeal follows.") {
+			t.Errorf("Sanitize(%q).Text = %q, want the article text kept", raw, got)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package sanitize
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	htmlTag    = regexp.MustCompile(`(?i)<(?:/?[a-z][a-z0-9]*(?:\s[^>]*)?/?|!--[\s\S]*?--|![a-z][^>]*)>`)
+	htmlEntity = regexp.MustCompile(`&(?:[a-zA-Z][a-zA-Z0-9]{1,31}|#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6});`)
+	tagName    = regexp.MustCompile(`^</?([a-zA-Z][a-zA-Z0-9]*)`)
+)
+
+// skippedElements have content that is never article text
+var skippedElements = map[string]bool{
+	"script": true, "style": true, "noscript": true, "iframe": true,
+	"nav": true, "header": true, "footer": true, "aside": true, "form": true,
+	"button": true, "svg": true, "template": true, "select": true,
+}
+
+// blockElements start a new paragraph
+var blockElements = map[string]bool{
+	"p": true, "div": true, "section": true, "article": true, "main": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"ul": true, "ol": true, "li": true, "table": true, "tr": true,
+	"blockquote": true, "pre": true, "figure": true, "figcaption": true,
+	"dl": true, "dt": true, "dd": true, "hr": true,
+}
+
+// looksLikeHTML reports whether the text contains markup
+func looksLikeHTML(text string) bool {
+	return htmlTag.MatchString(text)
+}
+
+// stripHTML removes tags, comments and non-content elements, decodes
+// character references and turns block elements into paragraph breaks and
+// <br> into line breaks
+func stripHTML(raw string) []piece {
+	var pieces []piece
+	skipping := ""
+	depth := 0
+	pos := 0
+
+	emitText := func(start, end int) {
+		if skipping != "" || start >= end {
+			return
+		}
+		last := start
+		for _, m := range htmlEntity.FindAllStringIndex(raw[start:end], -1) {
+			m0, m1 := start+m[0], start+m[1]
+			pieces = append(pieces, runePieces(raw, last, m0)...)
+			if decoded := html.UnescapeString(raw[m0:m1]); decoded != raw[m0:m1] {
+				pieces = append(pieces, piece{text: decoded, rawStart: m0, rawEnd: m1})
+			} else {
+				pieces = append(pieces, runePieces(raw, m0, m1)...)
+			}
+			last = m1
+		}
+		pieces = append(pieces, runePieces(raw, last, end)...)
+	}
+
+	for _, m := range htmlTag.FindAllStringIndex(raw, -1) {
+		emitText(pos, m[0])
+		pos = m[1]
+
+		tag := raw[m[0]:m[1]]
+		nameMatch := tagName.FindStringSubmatch(tag)
+		if nameMatch == nil {
+			continue // comment or doctype
+		}
+		name := strings.ToLower(nameMatch[1])
+		closing := strings.HasPrefix(tag, "</")
+		selfClosing := strings.HasSuffix(tag, "/>")
+
+		if skippedElements[name] && !selfClosing {
+			switch {
+			case !closing && (skipping == "" || skipping == name):
+				skipping = name
+				depth++
+			case closing && skipping == name:
+				depth--
+				if depth == 0 {
+					skipping = ""
+				}
+			}
+			continue
+		}
+		if skipping != "" {
+			continue
+		}
+
+		switch {
+		case name == "br":
+			pieces = append(pieces, piece{text: "\n", rawStart: m[0], rawEnd: m[1]})
+		case blockElements[name]:
+			pieces = append(pieces, piece{text: "\n\n", rawStart: m[0], rawEnd: m[1]})
+		case name == "td" || name == "th":
+			pieces = append(pieces, piece{text: " ", rawStart: m[0], rawEnd: m[1]})
+		}
+	}
+	emitText(pos, len(raw))
+	return pieces
+}
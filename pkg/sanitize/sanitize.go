@@ -0,0 +1,261 @@
+// Package sanitize turns submitted article content, which is often raw HTML
+// with tracking junk and typographic characters, into clean text for the
+// analyzers while keeping a map from the clean text back to the original.
+package sanitize
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Segment maps a range of the clean text to the range of the raw text it
+// came from. Offsets are in characters (runes), with Start inclusive and End
+// exclusive. When both ranges have the same length the mapping is
+// character by character; otherwise the whole clean range maps to the
+// whole raw range.
+type Segment struct {
+	CleanStart int
+	CleanEnd   int
+	RawStart   int
+	RawEnd     int
+}
+
+// Result is the cleaned text and its offset map
+type Result struct {
+	Text     string
+	Segments []Segment
+}
+
+// piece is a run of clean text together with the byte range of the raw
+// text it was produced from
+type piece struct {
+	text             string
+	rawStart, rawEnd int
+}
+
+// Sanitize cleans raw content in four passes: HTML markup is stripped with
+// block elements turned into paragraph breaks, boilerplate lines and
+// tracking parameters are removed, characters are normalized (zero-width
+// characters dropped, typographic quotes, primes and minus signs made plain, Unicode
+// NFKC applied), and whitespace is collapsed.
+func Sanitize(raw string) Result {
+	var pieces []piece
+	if looksLikeHTML(raw) {
+		pieces = stripHTML(raw)
+	} else {
+		pieces = runePieces(raw, 0, len(raw))
+	}
+	pieces = removeBoilerplate(pieces)
+	pieces = normalizeCharacters(pieces)
+	pieces = collapseWhitespace(pieces)
+	return build(raw, pieces)
+}
+
+// runePieces returns one piece per rune of raw[start:end]
+func runePieces(raw string, start, end int) []piece {
+	pieces := make([]piece, 0, end-start)
+	for i := start; i < end; {
+		_, size := utf8.DecodeRuneInString(raw[i:end])
+		pieces = append(pieces, piece{text: raw[i : i+size], rawStart: i, rawEnd: i + size})
+		i += size
+	}
+	return pieces
+}
+
+// join concatenates the text of the pieces and records, for every byte of
+// the result, the index of the piece it came from
+func join(pieces []piece) (string, []int) {
+	var b strings.Builder
+	var owner []int
+	for i, p := range pieces {
+		b.WriteString(p.text)
+		for k := 0; k < len(p.text); k++ {
+			owner = append(owner, i)
+		}
+	}
+	return b.String(), owner
+}
+
+// dropRanges removes the pieces that overlap the given byte ranges of the
+// joined text
+func dropRanges(pieces []piece, owner []int, ranges [][2]int) []piece {
+	if len(ranges) == 0 {
+		return pieces
+	}
+	drop := make([]bool, len(pieces))
+	for _, r := range ranges {
+		for k := r[0]; k < r[1]; k++ {
+			drop[owner[k]] = true
+		}
+	}
+	kept := pieces[:0:0]
+	for i, p := range pieces {
+		if !drop[i] {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+// typographic maps characters to plain equivalents that NFKC keeps as they
+// are. Zero-width and formatting characters map to nothing.
+var typographic = map[rune]string{
+	'\u2018': "'", '\u2019': "'", '\u201a': "'", '\u201b': "'", '\u2032': "'",
+	'\u201c': `"`, '\u201d': `"`, '\u201e': `"`, '\u201f': `"`, '\u2033': `"`,
+	'\u00ab': `"`, '\u00bb': `"`,
+	'\u2013': "-", '\u2212': "-",
+	// zero-width spaces and joiners, word joiner, byte order mark, soft
+	// hyphen and directional marks
+	'\u200b': "", '\u200c': "", '\u200d': "", '\u2060': "", '\ufeff': "",
+	'\u00ad': "", '\u200e': "", '\u200f': "",
+}
+
+// normalizeCharacters applies the typographic replacements and NFKC. NFKC
+// may compose several runes into one, so it is applied per normalization
+// segment, each becoming a single piece spanning its sources.
+func normalizeCharacters(pieces []piece) []piece {
+	replaced := make([]piece, 0, len(pieces))
+	for _, p := range pieces {
+		r, size := utf8.DecodeRuneInString(p.text)
+		if size == len(p.text) {
+			if plain, ok := typographic[r]; ok {
+				if plain != "" {
+					replaced = append(replaced, piece{text: plain, rawStart: p.rawStart, rawEnd: p.rawEnd})
+				}
+				continue
+			}
+		}
+		replaced = append(replaced, p)
+	}
+
+	text, owner := join(replaced)
+	if norm.NFKC.IsNormalString(text) {
+		return replaced
+	}
+
+	var normalized []piece
+	for start := 0; start < len(text); {
+		n := norm.NFKC.NextBoundaryInString(text[start:], true)
+		if n <= 0 {
+			n = len(text) - start
+		}
+		segment := text[start : start+n]
+		first, last := owner[start], owner[start+n-1]
+		if out := norm.NFKC.String(segment); out != segment {
+			normalized = append(normalized, piece{
+				text:     out,
+				rawStart: replaced[first].rawStart,
+				rawEnd:   replaced[last].rawEnd,
+			})
+		} else {
+			for i := first; i <= last; i++ {
+				if len(normalized) == 0 || !samePiece(normalized[len(normalized)-1], replaced[i]) {
+					normalized = append(normalized, replaced[i])
+				}
+			}
+		}
+		start += n
+	}
+	return normalized
+}
+
+func samePiece(a, b piece) bool {
+	return a.rawStart == b.rawStart && a.rawEnd == b.rawEnd && a.text == b.text
+}
+
+// collapseWhitespace turns runs of spaces into one space and runs of line
+// breaks into one line break, or a blank line when the run contained one.
+// Spaces around line breaks and at the ends of the text are dropped.
+func collapseWhitespace(pieces []piece) []piece {
+	var out []piece
+	var run []piece
+	flush := func(final bool) {
+		if len(run) == 0 {
+			return
+		}
+		newlines := 0
+		for _, p := range run {
+			newlines += strings.Count(p.text, "\n")
+		}
+		if len(out) > 0 && !final {
+			ws := " "
+			switch {
+			case newlines >= 2:
+				ws = "\n\n"
+			case newlines == 1:
+				ws = "\n"
+			}
+			out = append(out, piece{text: ws, rawStart: run[0].rawStart, rawEnd: run[len(run)-1].rawEnd})
+		}
+		run = run[:0]
+	}
+	for _, p := range pieces {
+		if isSpace(p.text) {
+			run = append(run, p)
+			continue
+		}
+		flush(false)
+		out = append(out, p)
+	}
+	flush(true)
+	return out
+}
+
+func isSpace(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// build assembles the clean text and merges the pieces into segments in
+// rune offsets
+func build(raw string, pieces []piece) Result {
+	// runeAt converts byte offsets of raw to rune offsets
+	runeAt := make([]int, len(raw)+1)
+	n := 0
+	for i := 0; i < len(raw); {
+		_, size := utf8.DecodeRuneInString(raw[i:])
+		for k := 0; k < size; k++ {
+			runeAt[i+k] = n
+		}
+		i += size
+		n++
+	}
+	runeAt[len(raw)] = n
+
+	var b strings.Builder
+	var segments []Segment
+	clean := 0
+	for _, p := range pieces {
+		b.WriteString(p.text)
+		length := utf8.RuneCountInString(p.text)
+		s := Segment{
+			CleanStart: clean,
+			CleanEnd:   clean + length,
+			RawStart:   runeAt[p.rawStart],
+			RawEnd:     runeAt[p.rawEnd],
+		}
+		clean += length
+
+		if k := len(segments) - 1; k >= 0 && isLinear(segments[k]) && isLinear(s) &&
+			segments[k].CleanEnd == s.CleanStart && segments[k].RawEnd == s.RawStart {
+			segments[k].CleanEnd, segments[k].RawEnd = s.CleanEnd, s.RawEnd
+			continue
+		}
+		segments = append(segments, s)
+	}
+	return Result{Text: b.String(), Segments: segments}
+}
+
+func isLinear(s Segment) bool {
+	return s.CleanEnd-s.CleanStart == s.RawEnd-s.RawStart
+}
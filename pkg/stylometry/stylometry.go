@@ -0,0 +1,241 @@
+// Package stylometry measures the habits that identify a writer, such as how
+// often they use common function words and punctuation, and compares a text
+// with a profile built from the same writer's earlier texts.
+package stylometry
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+// MinWords is the length below which a text is too short for its feature
+// frequencies to be stable
+const MinWords = 100
+
+// Features maps feature names to values. Function word and punctuation
+// features are rates per 1000 words; names contain no dots, so that they can
+// be stored as document keys.
+type Features map[string]float64
+
+const (
+	functionWordPrefix = "fw_"
+	punctuationPrefix  = "punct_"
+	sentenceLength     = "sentence_length"
+	wordLength         = "word_length"
+)
+
+// functionWords holds the most frequent function words per ISO 639-1
+// language. Writers use them unconsciously and consistently, whatever the
+// topic.
+var functionWords = map[string][]string{
+	"en": {
+		"the", "of", "and", "a", "to", "in", "is", "that", "it", "was",
+		"for", "on", "with", "as", "he", "she", "they", "be", "at", "by",
+		"this", "had", "not", "but", "from", "have", "or", "which", "an", "were",
+		"are", "been", "has", "their", "would", "there", "if", "we", "when", "so",
+		"what", "all", "its", "also", "who", "than", "more", "can", "will", "into",
+	},
+	"de": {
+		"der", "die", "und", "in", "den", "von", "zu", "das", "mit", "sich",
+		"des", "auf", "für", "ist", "im", "dem", "nicht", "ein", "eine", "als",
+		"auch", "es", "an", "werden", "aus", "er", "hat", "dass", "sie", "nach",
+		"wird", "bei", "einer", "um", "am", "sind", "noch", "wie", "einem", "über",
+	},
+	"es": {
+		"de", "la", "que", "el", "en", "y", "a", "los", "se", "del",
+		"las", "un", "por", "con", "no", "una", "su", "para", "es", "al",
+		"lo", "como", "más", "pero", "sus", "le", "ya", "o", "fue", "este",
+		"ha", "sí", "porque", "esta", "son", "entre", "cuando", "muy", "sin", "sobre",
+	},
+	"fr": {
+		"de", "la", "le", "et", "les", "des", "en", "un", "du", "une",
+		"que", "est", "pour", "qui", "dans", "a", "par", "plus", "pas", "au",
+		"sur", "ne", "se", "ce", "il", "sont", "mais", "avec", "comme", "ou",
+		"elle", "leur", "été", "aussi", "nous", "cette", "ont", "ses", "son", "si",
+	},
+}
+
+// punctuation names the marks whose rates are measured. Typographic variants
+// are counted with their plain forms.
+var punctuation = map[rune]string{
+	',': "comma", ';': "semicolon", ':': "colon", '!': "exclamation", '?': "question",
+	'(': "parenthesis", '"': "quote", '“': "quote", '”': "quote", '«': "quote", '»': "quote",
+	'—': "dash", '–': "dash", '…': "ellipsis", '\'': "apostrophe", '’': "apostrophe",
+}
+
+// punctuationLabels are the readable plural names of the punctuation features
+var punctuationLabels = map[string]string{
+	"comma": "commas", "semicolon": "semicolons", "colon": "colons",
+	"exclamation": "exclamation marks", "question": "question marks",
+	"parenthesis": "parentheses", "quote": "quotation marks", "dash": "dashes",
+	"ellipsis": "ellipses", "apostrophe": "apostrophes",
+}
+
+// Extract measures the features of text written in the given ISO 639-1
+// language. It returns nil when the text has fewer than MinWords words.
+// Function word rates are only measured for languages with a word list.
+func Extract(text, language string) Features {
+	tokens := tokenize.Words(text)
+	if len(tokens) < MinWords {
+		return nil
+	}
+	sentences := tokenize.Sentences(text)
+	per1000 := 1000 / float64(len(tokens))
+
+	features := make(Features)
+
+	counts := make(map[string]int, len(tokens))
+	var letters int
+	for _, t := range tokens {
+		counts[strings.ToLower(tokenize.Normalize(t.Text))]++
+		letters += utf8.RuneCountInString(t.Text)
+	}
+	for _, w := range functionWords[language] {
+		features[functionWordPrefix+w] = round(float64(counts[w]) * per1000)
+	}
+	features[wordLength] = round(float64(letters) / float64(len(tokens)))
+
+	for _, name := range punctuation {
+		features[punctuationPrefix+name] = 0
+	}
+	// Ellipses typed as three dots are counted once, not as three periods
+	text = strings.ReplaceAll(text, "...", "…")
+	for _, r := range text {
+		if name, ok := punctuation[r]; ok {
+			features[punctuationPrefix+name] += per1000
+		}
+	}
+	for _, name := range punctuation {
+		features[punctuationPrefix+name] = round(features[punctuationPrefix+name])
+	}
+
+	if len(sentences) > 0 {
+		features[sentenceLength] = round(float64(len(tokens)) / float64(len(sentences)))
+	}
+	return features
+}
+
+// Profile summarises the features of a writer's texts
+type Profile struct {
+	Samples int
+	Mean    map[string]float64
+	StdDev  map[string]float64
+}
+
+// BuildProfile returns the mean and sample standard deviation of every
+// feature over the samples. A feature missing from a sample counts as zero.
+func BuildProfile(samples []Features) Profile {
+	p := Profile{
+		Samples: len(samples),
+		Mean:    make(map[string]float64),
+		StdDev:  make(map[string]float64),
+	}
+	if len(samples) == 0 {
+		return p
+	}
+
+	for _, sample := range samples {
+		for name, value := range sample {
+			p.Mean[name] += value
+		}
+	}
+	for name := range p.Mean {
+		p.Mean[name] /= float64(len(samples))
+	}
+
+	if len(samples) < 2 {
+		for name := range p.Mean {
+			p.StdDev[name] = 0
+		}
+		return p
+	}
+	for name, mean := range p.Mean {
+		var squares float64
+		for _, sample := range samples {
+			d := sample[name] - mean
+			squares += d * d
+		}
+		p.StdDev[name] = math.Sqrt(squares / float64(len(samples)-1))
+	}
+	return p
+}
+
+// Deviation is how far a single feature lies from the profile
+type Deviation struct {
+	Feature string
+	Value   float64
+	Mean    float64
+	StdDev  float64
+	Z       float64
+}
+
+// Comparison is the result of comparing a text with a profile
+type Comparison struct {
+	// Delta is the mean absolute z-score over the compared features, after
+	// Burrows' Delta. Texts by the profiled writer typically score below 1.
+	Delta float64
+	// Deviations lists the compared features, most unusual first
+	Deviations []Deviation
+}
+
+// Compare measures how far the features lie from the profile. Standard
+// deviations are floored at a tenth of the mean, and at 0.5, so that a
+// feature that happened to be constant across a few samples does not
+// dominate. Features absent from both are skipped.
+func Compare(p Profile, features Features) Comparison {
+	var c Comparison
+	var sum float64
+	for name, mean := range p.Mean {
+		value := features[name]
+		if mean == 0 && value == 0 {
+			continue
+		}
+		sd := math.Max(p.StdDev[name], math.Max(0.1*math.Abs(mean), 0.5))
+		z := (value - mean) / sd
+		sum += math.Abs(z)
+		c.Deviations = append(c.Deviations, Deviation{
+			Feature: name,
+			Value:   value,
+			Mean:    mean,
+			StdDev:  p.StdDev[name],
+			Z:       round(z),
+		})
+	}
+	if len(c.Deviations) == 0 {
+		return c
+	}
+
+	c.Delta = round(sum / float64(len(c.Deviations)))
+	sort.Slice(c.Deviations, func(i, j int) bool {
+		a, b := math.Abs(c.Deviations[i].Z), math.Abs(c.Deviations[j].Z)
+		if a != b {
+			return a > b
+		}
+		return c.Deviations[i].Feature < c.Deviations[j].Feature
+	})
+	return c
+}
+
+// Describe returns a readable name for a feature
+func Describe(feature string) string {
+	switch {
+	case strings.HasPrefix(feature, functionWordPrefix):
+		return `"` + strings.TrimPrefix(feature, functionWordPrefix) + `" per 1000 words`
+	case strings.HasPrefix(feature, punctuationPrefix):
+		return punctuationLabels[strings.TrimPrefix(feature, punctuationPrefix)] + " per 1000 words"
+	case feature == sentenceLength:
+		return "words per sentence"
+	case feature == wordLength:
+		return "letters per word"
+	}
+	return feature
+}
+
+// round keeps two decimals
+func round(x float64) float64 {
+	return math.Round(x*100) / 100
+}
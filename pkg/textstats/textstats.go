@@ -0,0 +1,70 @@
+// Package textstats computes word, sentence and paragraph counts and reading
+// time for article text.
+package textstats
+
+import (
+	"math"
+	"unicode/utf8"
+
+	"github.com/reality-filter/pkg/tokenize"
+)
+
+// DefaultWordsPerMinute is the silent reading speed used for languages
+// without a specific rate
+const DefaultWordsPerMinute = 200
+
+// wordsPerMinute holds average adult silent reading speeds per ISO 639-1
+// language. Chinese and Japanese rates are in characters per minute, which
+// matches how tokenize.Words counts those scripts.
+var wordsPerMinute = map[string]float64{
+	"ar": 138,
+	"de": 179,
+	"en": 228,
+	"es": 218,
+	"fi": 161,
+	"fr": 195,
+	"he": 187,
+	"it": 188,
+	"ja": 357,
+	"nl": 202,
+	"pl": 166,
+	"pt": 181,
+	"ru": 184,
+	"sv": 199,
+	"tr": 166,
+	"zh": 255,
+}
+
+// Stats summarises the size of a text
+type Stats struct {
+	Words      int
+	Sentences  int
+	Paragraphs int
+	Characters int
+	// ReadingTime is the estimated reading time in whole minutes, rounded up
+	ReadingTime int
+}
+
+// Compute returns the statistics of text written in the given ISO 639-1
+// language
+func Compute(text, language string) Stats {
+	words := len(tokenize.Words(text))
+	stats := Stats{
+		Words:      words,
+		Sentences:  len(tokenize.Sentences(text)),
+		Paragraphs: len(tokenize.Paragraphs(text)),
+		Characters: utf8.RuneCountInString(text),
+	}
+	if words > 0 {
+		stats.ReadingTime = int(math.Ceil(float64(words) / ReadingSpeed(language)))
+	}
+	return stats
+}
+
+// ReadingSpeed returns the reading speed for a language in words per minute
+func ReadingSpeed(language string) float64 {
+	if wpm, ok := wordsPerMinute[language]; ok {
+		return wpm
+	}
+	return DefaultWordsPerMinute
+}
@@ -0,0 +1,245 @@
+// Package tokenize provides word and sentence segmentation shared by the
+// offline text analyzers.
+package tokenize
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Token is a segment of the source text together with its byte offsets
+type Token struct {
+	Text  string
+	Start int
+	End   int
+}
+
+// Words splits text into word tokens. Apostrophes and hyphens inside a word
+// are kept so that "don't" and "well-known" stay single tokens. Scripts that
+// are written without spaces (Chinese and Japanese) yield one token per
+// character, the unit their reading speeds and word statistics are based on.
+func Words(text string) []Token {
+	var tokens []Token
+	start := -1
+	for i, r := range text {
+		if IsIdeographic(r) {
+			if start >= 0 {
+				tokens = append(tokens, Token{Text: text[start:i], Start: start, End: i})
+				start = -1
+			}
+			end := i + utf8.RuneLen(r)
+			tokens = append(tokens, Token{Text: text[i:end], Start: i, End: end})
+			continue
+		}
+		if isWordRune(r) || (start >= 0 && joinsWord(text, start, i, r)) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			tokens = append(tokens, Token{Text: text[start:i], Start: start, End: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, Token{Text: text[start:], Start: start, End: len(text)})
+	}
+	return tokens
+}
+
+// Sentences splits text into sentences on terminal punctuation followed by
+// whitespace, and on blank lines. Leading and trailing whitespace is trimmed
+// from every sentence; offsets refer to the trimmed text.
+func Sentences(text string) []Token {
+	var sentences []Token
+	start := 0
+	emit := func(end int) {
+		if token := trimToken(text, start, end); token.Text != "" {
+			sentences = append(sentences, token)
+		}
+		start = end
+	}
+
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+		switch {
+		case isTerminal(r):
+			end := i + size
+			// Absorb runs such as "?!" or "..." and closing quotes/brackets
+			for end < len(text) {
+				next, n := utf8.DecodeRuneInString(text[end:])
+				if !isTerminal(next) && !isCloser(next) {
+					break
+				}
+				end += n
+			}
+			if end >= len(text) || startsWithSpace(text[end:]) || isFullwidthTerminal(r) {
+				if !(r == '.' && isAbbreviation(text[start:i])) {
+					emit(end)
+				}
+			}
+			i = end
+		case r == '\n' && strings.HasPrefix(strings.TrimLeft(text[i+1:], " \t\r"), "\n"):
+			emit(i)
+			i += size
+		default:
+			i += size
+		}
+	}
+	emit(len(text))
+	return sentences
+}
+
+// Paragraphs splits text into paragraphs separated by blank lines. Text that
+// has no blank lines but several lines is treated as one paragraph per line.
+func Paragraphs(text string) []Token {
+	var paragraphs []Token
+	var lines []Token
+	blankSeparated := false
+	start := -1
+	offset := 0
+	for _, line := range strings.SplitAfter(text, "\n") {
+		lineStart := offset
+		offset += len(line)
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			if start >= 0 {
+				blankSeparated = true
+				paragraphs = append(paragraphs, trimToken(text, start, lineStart))
+				start = -1
+			}
+			continue
+		}
+		lines = append(lines, trimToken(text, lineStart, offset))
+		if start < 0 {
+			start = lineStart
+		}
+	}
+	if start >= 0 {
+		paragraphs = append(paragraphs, trimToken(text, start, len(text)))
+	}
+
+	if !blankSeparated && len(lines) > 1 {
+		return lines
+	}
+	return paragraphs
+}
+
+// IsIdeographic reports whether r belongs to a script written without spaces
+// between words, where each character is counted as a word
+func IsIdeographic(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r)
+}
+
+// Normalize lower-cases a token and strips surrounding punctuation
+func Normalize(word string) string {
+	return strings.ToLower(strings.TrimFunc(word, func(r rune) bool {
+		return !isWordRune(r)
+	}))
+}
+
+// RuneOffsets converts a byte offset range in text into rune offsets
+func RuneOffsets(text string, start, end int) (int, int) {
+	runeStart := utf8.RuneCountInString(text[:start])
+	return runeStart, runeStart + utf8.RuneCountInString(text[start:end])
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
+
+// joinsWord reports whether the punctuation rune at index i continues the
+// word that began at start: apostrophes and hyphens between letters, and
+// decimal or thousands separators between digits
+func joinsWord(text string, start, i int, r rune) bool {
+	next := i + utf8.RuneLen(r)
+	switch r {
+	case '\'', '’', '-':
+		return nextIsWordRune(text, next)
+	case '.', ',':
+		prev, _ := utf8.DecodeLastRuneInString(text[start:i])
+		return unicode.IsDigit(prev) && nextIsDigit(text, next)
+	}
+	return false
+}
+
+func nextIsDigit(text string, i int) bool {
+	if i >= len(text) {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(text[i:])
+	return unicode.IsDigit(r)
+}
+
+func nextIsWordRune(text string, i int) bool {
+	if i >= len(text) {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(text[i:])
+	return isWordRune(r)
+}
+
+func isTerminal(r rune) bool {
+	switch r {
+	case '.', '!', '?', '…', '。', '！', '？':
+		return true
+	}
+	return false
+}
+
+func isCloser(r rune) bool {
+	switch r {
+	case '"', '\'', ')', ']', '”', '’', '»':
+		return true
+	}
+	return false
+}
+
+// isFullwidthTerminal reports whether r ends a sentence in scripts that do
+// not put a space after sentence punctuation
+func isFullwidthTerminal(r rune) bool {
+	return r == '。' || r == '！' || r == '？'
+}
+
+// trimToken returns the token for text[start:end] without surrounding
+// whitespace
+func trimToken(text string, start, end int) Token {
+	seg := text[start:end]
+	trimmed := strings.TrimLeftFunc(seg, unicode.IsSpace)
+	start += len(seg) - len(trimmed)
+	trimmed = strings.TrimRightFunc(trimmed, unicode.IsSpace)
+	return Token{Text: trimmed, Start: start, End: start + len(trimmed)}
+}
+
+func startsWithSpace(s string) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return unicode.IsSpace(r)
+}
+
+// abbreviations that end with a period but rarely end a sentence
+var abbreviations = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "dr": true, "prof": true, "sr": true,
+	"jr": true, "st": true, "vs": true, "etc": true, "inc": true, "ltd": true,
+	"co": true, "corp": true, "gen": true, "gov": true, "sen": true, "rep": true,
+	"u.s": true, "u.k": true, "e.g": true, "i.e": true, "no": true, "jan": true,
+	"feb": true, "mar": true, "apr": true, "aug": true, "sep": true, "sept": true,
+	"oct": true, "nov": true, "dec": true, "a.m": true, "p.m": true,
+}
+
+// isAbbreviation reports whether the text before a period ends with a known
+// abbreviation or a single capital letter initial
+func isAbbreviation(before string) bool {
+	idx := strings.LastIndexFunc(before, unicode.IsSpace)
+	last := before[idx+1:]
+	last = strings.TrimLeft(last, "(\"'“‘")
+	if last == "" {
+		return false
+	}
+	if utf8.RuneCountInString(last) == 1 {
+		r, _ := utf8.DecodeRuneInString(last)
+		return unicode.IsUpper(r)
+	}
+	return abbreviations[strings.ToLower(last)]
+}